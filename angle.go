@@ -0,0 +1,63 @@
+package transform
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// angleFunc converts an angle to degrees wrapped into [0,360) (angle=deg) or to
+// radians wrapped into [-π,π) (angle=rad). Values without unit (°, deg or rad)
+// are in the unit of the parameter.
+func angleFunc(fl FieldLevel) error {
+	to := fl.Param()
+	if to != "deg" && to != "rad" {
+		return fmt.Errorf("%w: angle=%s", ErrInvalidParam, to)
+	}
+
+	s := strings.TrimSpace(fl.String())
+	if s == "" {
+		return nil
+	}
+
+	v, unit, err := splitUnit(s)
+	if err != nil {
+		return fmt.Errorf("angle: %w", err)
+	}
+
+	switch unit = strings.ToLower(unit); {
+	case unit == "" || unit == to:
+	case (unit == "°" || unit == "deg") && to == "rad":
+		v = v * math.Pi / 180
+	case unit == "°" || unit == "deg":
+	case unit == "rad":
+		v = v * 180 / math.Pi
+	default:
+		return fmt.Errorf("angle: invalid unit %q of %q", unit, s)
+	}
+
+	if to == "deg" {
+		v = wrapAngle(v, 0, 360)
+	} else {
+		v = wrapAngle(v, -math.Pi, 2*math.Pi)
+	}
+
+	SetString(fl, formatMeasure(v))
+
+	return nil
+}
+
+// wrapAngle wraps the angle into [lower,lower+period)
+func wrapAngle(v, lower, period float64) float64 {
+	v = math.Mod(v-lower, period)
+	if v < 0 {
+		v += period
+	}
+
+	// rounding may end up at the excluded upper bound
+	if v >= period || formatMeasure(v) == formatMeasure(period) {
+		v = 0
+	}
+
+	return v + lower
+}
@@ -0,0 +1,62 @@
+package transform_test
+
+import (
+	"testing"
+
+	"github.com/zeiss/go-transform"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAngle(t *testing.T) {
+	type testStruct struct {
+		Deg string `transform:"angle=deg"`
+		Rad string `transform:"angle=rad"`
+	}
+
+	tests := []struct {
+		name string
+		in   *testStruct
+		out  *testStruct
+		err  bool
+	}{
+		{
+			name: "empty",
+			in:   &testStruct{},
+			out:  &testStruct{},
+		},
+		{
+			name: "wrap",
+			in:   &testStruct{Deg: "-90", Rad: "4"},
+			out:  &testStruct{Deg: "270", Rad: "-2.283185307"},
+		},
+		{
+			name: "upper bound",
+			in:   &testStruct{Deg: "720", Rad: "3.141592653589793"},
+			out:  &testStruct{Deg: "0", Rad: "-3.141592654"},
+		},
+		{
+			name: "convert",
+			in:   &testStruct{Deg: "3.141592653589793 rad", Rad: "90°"},
+			out:  &testStruct{Deg: "180", Rad: "1.570796327"},
+		},
+		{
+			name: "invalid unit",
+			in:   &testStruct{Deg: "90 grad"},
+			err:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := transform.Transform(tt.in)
+			if tt.err {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.out, tt.in)
+		})
+	}
+}
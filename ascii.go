@@ -0,0 +1,115 @@
+package transform
+
+import (
+	"bytes"
+	"strings"
+	"unicode/utf8"
+)
+
+// asciiSpace marks the ASCII whitespace characters as defined by unicode.IsSpace
+var asciiSpace = [256]bool{'\t': true, '\n': true, '\v': true, '\f': true, '\r': true, ' ': true}
+
+// isASCII returns true if the buffer only contains ASCII characters
+func isASCII(b []byte) bool {
+	for _, c := range b {
+		if c >= utf8.RuneSelf {
+			return false
+		}
+	}
+
+	return true
+}
+
+// toLowerASCII converts the buffer to lower case, it works in place for ASCII input
+func toLowerASCII(b []byte) []byte {
+	if !isASCII(b) {
+		return append(b[:0], bytes.ToLower(b)...)
+	}
+
+	for i, c := range b {
+		if 'A' <= c && c <= 'Z' {
+			b[i] = c + 'a' - 'A'
+		}
+	}
+
+	return b
+}
+
+// toUpperASCII converts the buffer to upper case, it works in place for ASCII input
+func toUpperASCII(b []byte) []byte {
+	if !isASCII(b) {
+		return append(b[:0], bytes.ToUpper(b)...)
+	}
+
+	for i, c := range b {
+		if 'a' <= c && c <= 'z' {
+			b[i] = c - ('a' - 'A')
+		}
+	}
+
+	return b
+}
+
+// trimSpaceASCII removes leading and trailing whitespace without decoding UTF-8,
+// it falls back to bytes.TrimSpace as soon as a non ASCII character is at the edge
+func trimSpaceASCII(b []byte) []byte {
+	start := 0
+	for ; start < len(b); start++ {
+		c := b[start]
+		if c >= utf8.RuneSelf {
+			return bytes.TrimSpace(b[start:])
+		}
+
+		if !asciiSpace[c] {
+			break
+		}
+	}
+
+	stop := len(b)
+	for ; stop > start; stop-- {
+		c := b[stop-1]
+		if c >= utf8.RuneSelf {
+			return bytes.TrimSpace(b[start:stop])
+		}
+
+		if !asciiSpace[c] {
+			break
+		}
+	}
+
+	return b[start:stop]
+}
+
+// toLowerString converts the string to lower case, ASCII strings without
+// upper case characters are returned without allocation
+func toLowerString(s string) string {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c >= utf8.RuneSelf {
+			return strings.ToLower(s)
+		}
+
+		if 'A' <= c && c <= 'Z' {
+			return string(toLowerASCII([]byte(s)))
+		}
+	}
+
+	return s
+}
+
+// toUpperString converts the string to upper case, ASCII strings without
+// lower case characters are returned without allocation
+func toUpperString(s string) string {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c >= utf8.RuneSelf {
+			return strings.ToUpper(s)
+		}
+
+		if 'a' <= c && c <= 'z' {
+			return string(toUpperASCII([]byte(s)))
+		}
+	}
+
+	return s
+}
@@ -0,0 +1,122 @@
+package transform_test
+
+import (
+	"testing"
+
+	"github.com/zeiss/go-transform"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestASCII(t *testing.T) {
+	trans := transform.New()
+
+	type testStruct struct {
+		Lower string `transform:"trim,lowercase"`
+		Upper string `transform:"trim,uppercase"`
+	}
+
+	tests := []struct {
+		name string
+		in   *testStruct
+		out  *testStruct
+	}{
+		{
+			name: "ascii",
+			in: &testStruct{
+				Lower: "\t Hello World \n",
+				Upper: "\t Hello World \n",
+			},
+			out: &testStruct{
+				Lower: "hello world",
+				Upper: "HELLO WORLD",
+			},
+		},
+		{
+			name: "unicode",
+			in: &testStruct{
+				Lower: "  Größe ÄÖÜ  ",
+				Upper: "  Größe äöü  ",
+			},
+			out: &testStruct{
+				Lower: "größe äöü",
+				Upper: "GRÖßE ÄÖÜ",
+			},
+		},
+		{
+			name: "unicode edge",
+			in: &testStruct{
+				Lower: " Ä ",
+				Upper: " ä ",
+			},
+			out: &testStruct{
+				Lower: "ä",
+				Upper: "Ä",
+			},
+		},
+		{
+			name: "whitespace",
+			in: &testStruct{
+				Lower: " \t\r\n ",
+				Upper: "",
+			},
+			out: &testStruct{
+				Lower: "",
+				Upper: "",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := trans.Transform(tt.in)
+			require.NoError(t, err)
+			require.Equal(t, tt.out, tt.in)
+		})
+	}
+}
+
+func BenchmarkASCII(b *testing.B) {
+	trans := transform.New()
+
+	type testStruct struct {
+		Name string `transform:"trim,lowercase"`
+	}
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		err := trans.Transform(&testStruct{Name: "  John.Doe@Example.COM  "})
+		require.NoError(b, err)
+	}
+}
+
+func BenchmarkUnicode(b *testing.B) {
+	trans := transform.New()
+
+	type testStruct struct {
+		Name string `transform:"trim,lowercase"`
+	}
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		err := trans.Transform(&testStruct{Name: "  Jürgen.Größe@Example.COM  "})
+		require.NoError(b, err)
+	}
+}
+
+func BenchmarkASCIIUppercase(b *testing.B) {
+	trans := transform.New()
+
+	type testStruct struct {
+		Name string `transform:"uppercase"`
+	}
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		err := trans.Transform(&testStruct{Name: "john.doe@example.com"})
+		require.NoError(b, err)
+	}
+}
@@ -0,0 +1,87 @@
+package transform
+
+import (
+	"bytes"
+	"sync"
+)
+
+// maxPooledBuffer is the maximum capacity of a buffer that is returned to the pool
+const maxPooledBuffer = 64 << 10
+
+// bufFunc rewrites the string in the buffer and returns the result.
+// It may reuse the memory of the buffer.
+type bufFunc func(b []byte) []byte
+
+// bufTransformers are the string functions that can run on a shared buffer,
+// consecutive functions are applied in a single pass without intermediate strings.
+var bufTransformers = map[string]bufFunc{
+	"trim":      trimSpaceASCII,
+	"ltrim":     trimLeftBuf,
+	"rtrim":     trimRightBuf,
+	"lowercase": toLowerASCII,
+	"uppercase": toUpperASCII,
+}
+
+// buffered returns the buffer function of a string function,
+// unless the function is replaced or its calls are wrapped by middleware
+func (t *TransformerImpl) buffered(name string) (bufFunc, bool) {
+	bf, ok := bufTransformers[name]
+	if !ok || len(t.middleware) > 0 || t.replaced(name) {
+		return nil, false
+	}
+
+	return bf, true
+}
+
+var bufPool = sync.Pool{
+	New: func() interface{} {
+		b := make([]byte, 0, 64)
+		return &b
+	},
+}
+
+func trimLeftBuf(b []byte) []byte {
+	return bytes.TrimLeft(b, " ")
+}
+
+func trimRightBuf(b []byte) []byte {
+	return bytes.TrimRight(b, " ")
+}
+
+// buffer is a pooled buffer holding the value of a field during a pipeline
+type buffer struct {
+	pooled *[]byte
+	b      []byte
+}
+
+// reset fills the buffer with the string, it takes a buffer from the pool if needed
+func (buf *buffer) reset(s string) {
+	if buf.pooled == nil {
+		buf.pooled = bufPool.Get().(*[]byte)
+	}
+
+	buf.b = append((*buf.pooled)[:0], s...)
+}
+
+// active returns true if the buffer holds a value
+func (buf *buffer) active() bool {
+	return buf.pooled != nil
+}
+
+// release returns the buffer to the pool
+func (buf *buffer) release() {
+	if buf.pooled == nil {
+		return
+	}
+
+	if cap(buf.b) > cap(*buf.pooled) {
+		*buf.pooled = buf.b[:0]
+	}
+
+	if cap(*buf.pooled) <= maxPooledBuffer {
+		bufPool.Put(buf.pooled)
+	}
+
+	buf.pooled = nil
+	buf.b = nil
+}
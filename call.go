@@ -0,0 +1,108 @@
+package transform
+
+import (
+	"context"
+)
+
+// Limiter throttles calls of a transform function, it is implemented by *rate.Limiter
+type Limiter interface {
+	// Wait blocks until the call is allowed or the context is done
+	Wait(ctx context.Context) error
+}
+
+// WithRateLimiter throttles the calls of the named transform function with the limiter,
+// which is shared by all calls of this transformer (e.g. for costly external lookups)
+func WithRateLimiter(name string, limiter Limiter) TransformerOpt {
+	return func(o *TransformerImpl) {
+		if o.limiters == nil {
+			o.limiters = make(map[string]Limiter)
+		}
+
+		o.limiters[name] = limiter
+	}
+}
+
+// Breaker is a circuit breaker guarding calls of a transform function.
+// Libraries like sony/gobreaker can be adapted with a few lines.
+type Breaker interface {
+	// Execute calls fn if the circuit allows it and records the result,
+	// it returns an error without calling fn if the circuit is open
+	Execute(fn func() error) error
+}
+
+// breaker is a circuit breaker with the fallback used while the circuit is open
+type breaker struct {
+	Breaker
+	fallback Func
+}
+
+// WithBreaker guards the calls of the named transform function with the circuit breaker.
+// While the circuit is open, the fallback is called instead of the function,
+// a nil fallback returns the error of the breaker. Use PassThrough to leave the field unchanged.
+func WithBreaker(name string, b Breaker, fallback Func) TransformerOpt {
+	return func(o *TransformerImpl) {
+		if o.breakers == nil {
+			o.breakers = make(map[string]breaker)
+		}
+
+		o.breakers[name] = breaker{b, fallback}
+	}
+}
+
+// PassThrough leaves the field unchanged, it is a fallback for open circuit breakers
+func PassThrough(_ FieldLevel) error {
+	return nil
+}
+
+// call calls the transform function of the name wrapped by the middleware of the transformer
+func (t *TransformerImpl) call(name string, fn Func, fl FieldLevel) error {
+	if len(t.middleware) == 0 {
+		return t.invoke(name, fn, fl)
+	}
+
+	return t.wrap(name, func(fl FieldLevel) error {
+		return t.invoke(name, fn, fl)
+	})(fl)
+}
+
+// invoke calls the transform function of the name, applying the retry policy,
+// the circuit breaker and the rate limiter of the function
+func (t *TransformerImpl) invoke(name string, fn Func, fl FieldLevel) error {
+	ctx := contextOf(fl)
+
+	limited := func() error {
+		if l, ok := t.limiters[name]; ok {
+			if err := l.Wait(ctx); err != nil {
+				return err
+			}
+		}
+
+		return fn(fl)
+	}
+
+	attempt := limited
+
+	if b, ok := t.breakers[name]; ok {
+		attempt = func() error {
+			called := false
+
+			err := b.Execute(func() error {
+				called = true
+				return limited()
+			})
+
+			if err != nil && !called && b.fallback != nil {
+				return b.fallback(fl) // the circuit is open
+			}
+
+			return err
+		}
+	}
+
+	policy, ok := t.retries[name]
+	if !ok {
+		return attempt()
+	}
+
+	return policy.Do(ctx, attempt)
+}
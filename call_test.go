@@ -0,0 +1,115 @@
+package transform_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/zeiss/go-transform"
+
+	"github.com/stretchr/testify/require"
+)
+
+type countingLimiter struct {
+	calls int
+	err   error
+}
+
+func (l *countingLimiter) Wait(_ context.Context) error {
+	l.calls++
+	return l.err
+}
+
+func TestRateLimiter(t *testing.T) {
+	type testStruct struct {
+		First  string `transform:"canonicaljson"`
+		Second string `transform:"canonicaljson"`
+		Name   string `transform:"trim"`
+	}
+
+	limiter := &countingLimiter{}
+	trans := transform.New(transform.WithRateLimiter("canonicaljson", limiter))
+
+	err := trans.Transform(&testStruct{First: "{}", Second: "[]", Name: "  test  "})
+	require.NoError(t, err)
+	require.Equal(t, 2, limiter.calls)
+
+	errLimit := errors.New("rate limit exceeded")
+	trans = transform.New(transform.WithRateLimiter("canonicaljson", &countingLimiter{err: errLimit}))
+
+	in := &testStruct{First: `{"b":1,"a":2}`}
+	err = trans.Transform(in)
+	require.ErrorIs(t, err, errLimit)
+	require.Equal(t, `{"b":1,"a":2}`, in.First)
+}
+
+var errOpen = errors.New("circuit open")
+
+type fakeBreaker struct {
+	open bool
+}
+
+func (b *fakeBreaker) Execute(fn func() error) error {
+	if b.open {
+		return errOpen
+	}
+
+	return fn()
+}
+
+func TestBreaker(t *testing.T) {
+	type testStruct struct {
+		Payload string `transform:"canonicaljson"`
+	}
+
+	tests := []struct {
+		name     string
+		open     bool
+		fallback transform.Func
+		out      string
+		err      error
+	}{
+		{
+			name: "closed",
+			out:  `{"a":1,"b":2}`,
+		},
+		{
+			name:     "pass through",
+			open:     true,
+			fallback: transform.PassThrough,
+			out:      `{"b":2,"a":1}`,
+		},
+		{
+			name: "default",
+			open: true,
+			fallback: func(fl transform.FieldLevel) error {
+				transform.SetString(fl, "{}")
+				return nil
+			},
+			out: "{}",
+		},
+		{
+			name: "error",
+			open: true,
+			out:  `{"b":2,"a":1}`,
+			err:  errOpen,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trans := transform.New(transform.WithBreaker("canonicaljson", &fakeBreaker{open: tt.open}, tt.fallback))
+
+			in := &testStruct{Payload: `{"b":2,"a":1}`}
+			err := trans.Transform(in)
+
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, tt.out, in.Payload)
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package transform
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+func canonicalJSONFunc(fl FieldLevel) error {
+	s := fl.String()
+	if strings.TrimSpace(s) == "" {
+		return nil
+	}
+
+	b, err := CanonicalJSON([]byte(s))
+	if err != nil {
+		return err
+	}
+
+	SetString(fl, string(b))
+
+	return nil
+}
+
+// CanonicalJSON re-serializes the JSON document with sorted object keys, without
+// insignificant whitespace and with a stable number format, so that signatures
+// computed over the result are deterministic. Object keys are sorted by their UTF-16
+// code units as by RFC 8785. Numbers keep their value, a fraction that would change
+// as an IEEE 754 double is an error.
+func CanonicalJSON(b []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, fmt.Errorf("canonicaljson: %w", err)
+	}
+
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("canonicaljson: unexpected data after top-level value")
+	}
+
+	buf := &bytes.Buffer{}
+	if err := writeCanonical(buf, v); err != nil {
+		return nil, fmt.Errorf("canonicaljson: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func writeCanonical(buf *bytes.Buffer, v interface{}) error {
+	switch v := v.(type) {
+	case nil:
+		buf.WriteString("null")
+	case bool:
+		buf.WriteString(strconv.FormatBool(v))
+	case json.Number:
+		n, err := canonicalNumber(v)
+		if err != nil {
+			return err
+		}
+
+		buf.WriteString(n)
+	case string:
+		return writeString(buf, v)
+	case []interface{}:
+		buf.WriteByte('[')
+
+		for i, e := range v {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+
+			if err := writeCanonical(buf, e); err != nil {
+				return err
+			}
+		}
+
+		buf.WriteByte(']')
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+
+		sort.Slice(keys, func(i, j int) bool { return lessUTF16(keys[i], keys[j]) })
+
+		buf.WriteByte('{')
+
+		for i, k := range keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+
+			if err := writeString(buf, k); err != nil {
+				return err
+			}
+
+			buf.WriteByte(':')
+
+			if err := writeCanonical(buf, v[k]); err != nil {
+				return err
+			}
+		}
+
+		buf.WriteByte('}')
+	default:
+		return fmt.Errorf("unexpected type %T", v)
+	}
+
+	return nil
+}
+
+// writeString writes the JSON string without escaping HTML characters
+func writeString(buf *bytes.Buffer, s string) error {
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+
+	if err := enc.Encode(s); err != nil {
+		return err
+	}
+
+	buf.Truncate(buf.Len() - 1) // remove the newline of the encoder
+
+	return nil
+}
+
+// lessUTF16 compares the strings by their UTF-16 code units, which differs from the byte order
+// of UTF-8 for characters outside the basic multilingual plane and U+E000 to U+FFFF
+func lessUTF16(a, b string) bool {
+	ua, ub := utf16.Encode([]rune(a)), utf16.Encode([]rune(b))
+
+	for i := 0; i < len(ua) && i < len(ub); i++ {
+		if ua[i] != ub[i] {
+			return ua[i] < ub[i]
+		}
+	}
+
+	return len(ua) < len(ub)
+}
+
+// canonicalNumber formats integers without fraction and exponent and all other
+// numbers like ECMAScript does (shortest representation, exponent outside [1e-6, 1e21)).
+// Integers that are not exact doubles keep all their digits, other numbers that are
+// not exact doubles are an error, as the format must not change their value.
+func canonicalNumber(n json.Number) (string, error) {
+	if i, err := strconv.ParseInt(string(n), 10, 64); err == nil {
+		return strconv.FormatInt(i, 10), nil
+	}
+
+	f, err := strconv.ParseFloat(string(n), 64)
+	if err != nil {
+		return "", err
+	}
+
+	s := formatDouble(f)
+
+	exact, ok := new(big.Rat).SetString(string(n))
+	if !ok {
+		return "", fmt.Errorf("invalid number %s", n)
+	}
+
+	if formatted, _ := new(big.Rat).SetString(s); formatted.Cmp(exact) == 0 {
+		return s, nil
+	}
+
+	if exact.IsInt() {
+		return exact.Num().String(), nil
+	}
+
+	return "", fmt.Errorf("number %s is not exactly representable as a double", n)
+}
+
+// formatDouble formats the double like ECMAScript does
+func formatDouble(f float64) string {
+	if f == 0 {
+		return "0"
+	}
+
+	if abs := math.Abs(f); abs >= 1e21 || abs < 1e-6 {
+		s := strconv.FormatFloat(f, 'e', -1, 64)
+		mantissa, exp, _ := strings.Cut(s, "e")
+		sign, digits := exp[:1], strings.TrimLeft(exp[1:], "0")
+
+		return mantissa + "e" + sign + digits
+	}
+
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
@@ -0,0 +1,73 @@
+package transform_test
+
+import (
+	"testing"
+
+	"github.com/zeiss/go-transform"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanonicalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		out  string
+		err  bool
+	}{
+		{name: "null", in: "null", out: "null"},
+		{name: "object", in: `{ "b": 1, "a": [true, false, null] }`, out: `{"a":[true,false,null],"b":1}`},
+		{name: "nested", in: `{"z":{"y":"x","a":"b"}}`, out: `{"z":{"a":"b","y":"x"}}`},
+		{name: "html", in: `{"a":"<b>&</b>"}`, out: `{"a":"<b>&</b>"}`},
+		{name: "unicode", in: `{"a":"ä"}`, out: `{"a":"ä"}`},
+		{name: "integer", in: `[1, -0, 100]`, out: `[1,0,100]`},
+		{name: "float", in: `[1.0, 1.50, 1e2, 0.000001]`, out: `[1,1.5,100,0.000001]`},
+		{name: "exponent", in: `[1e-7, 1e21, -2.5E+30]`, out: `[1e-7,1e+21,-2.5e+30]`},
+		{name: "uint64 max", in: `[18446744073709551615, -18446744073709551616]`, out: `[18446744073709551615,-18446744073709551616]`},
+		{name: "long integer", in: `[123456789012345678901234567890, 1.5e30]`, out: `[123456789012345678901234567890,1.5e+30]`},
+		{name: "long fraction", in: `[0.12345678901234567890]`, err: true},
+		{name: "long mantissa", in: `[1.00000000000000000001]`, err: true},
+		{name: "out of range", in: `[1e400]`, err: true},
+		{name: "keys", in: `{"\ue000":1,"\ud83d\ude00":2,"a":3}`, out: "{\"a\":3,\"😀\":2,\"\ue000\":1}"},
+		{name: "invalid", in: `{"a":`, err: true},
+		{name: "trailing", in: `{} {}`, err: true},
+		{name: "trailing delimiter", in: `{} ]`, err: true},
+		{name: "trailing whitespace", in: "{} \n", out: `{}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := transform.CanonicalJSON([]byte(tt.in))
+
+			if tt.err {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.out, string(out))
+		})
+	}
+}
+
+func TestCanonicalJSONTransformer(t *testing.T) {
+	trans := transform.New()
+
+	type testStruct struct {
+		Payload string `transform:"canonicaljson"`
+	}
+
+	in := &testStruct{Payload: `{"b": 2, "a": 1}`}
+	err := trans.Transform(in)
+	require.NoError(t, err)
+	require.Equal(t, `{"a":1,"b":2}`, in.Payload)
+
+	in = &testStruct{}
+	err = trans.Transform(in)
+	require.NoError(t, err)
+	require.Empty(t, in.Payload)
+
+	in = &testStruct{Payload: "not json"}
+	err = trans.Transform(in)
+	require.Error(t, err)
+}
@@ -0,0 +1,208 @@
+package transform
+
+import (
+	"math"
+	"reflect"
+)
+
+// Clone returns a deep copy of the pointer to a struct.
+// Pointers shared by multiple fields stay shared in the copy, unexported
+// fields are copied shallow.
+func Clone(s interface{}) (interface{}, error) {
+	ifv, err := structValue(s)
+	if err != nil || !ifv.IsValid() {
+		return s, err
+	}
+
+	return newCopier().copy(reflect.ValueOf(s)).Interface(), nil
+}
+
+// copier creates deep copies of values, shared pointers stay shared in the copy
+type copier struct {
+	seen map[pointer]reflect.Value
+}
+
+func newCopier() *copier {
+	return &copier{
+		seen: make(map[pointer]reflect.Value),
+	}
+}
+
+// copy returns a deep copy of the value
+func (c *copier) copy(src reflect.Value) reflect.Value {
+	dst := reflect.New(src.Type()).Elem()
+	c.copyInto(dst, src)
+
+	return dst
+}
+
+// nolint:gocyclo
+func (c *copier) copyInto(dst, src reflect.Value) {
+	// nolint:exhaustive
+	switch src.Kind() {
+	case reflect.Ptr:
+		if src.IsNil() {
+			return
+		}
+
+		p := pointer{src.Pointer(), src.Type()}
+		if v, ok := c.seen[p]; ok {
+			dst.Set(v)
+			return
+		}
+
+		v := reflect.New(src.Type().Elem())
+		c.seen[p] = v
+		c.copyInto(v.Elem(), src.Elem())
+		dst.Set(v)
+	case reflect.Interface:
+		if src.IsNil() {
+			return
+		}
+
+		dst.Set(c.copy(src.Elem()))
+	case reflect.Struct:
+		dst.Set(src) // unexported fields are copied shallow
+
+		for i := 0; i < src.NumField(); i++ {
+			if dst.Field(i).CanSet() {
+				c.copyInto(dst.Field(i), src.Field(i))
+			}
+		}
+	case reflect.Slice:
+		if src.IsNil() {
+			return
+		}
+
+		v := reflect.MakeSlice(src.Type(), src.Len(), src.Len())
+		for i := 0; i < src.Len(); i++ {
+			c.copyInto(v.Index(i), src.Index(i))
+		}
+
+		dst.Set(v)
+	case reflect.Array:
+		for i := 0; i < src.Len(); i++ {
+			c.copyInto(dst.Index(i), src.Index(i))
+		}
+	case reflect.Map:
+		if src.IsNil() {
+			return
+		}
+
+		v := reflect.MakeMapWithSize(src.Type(), src.Len())
+		iter := src.MapRange()
+		for iter.Next() {
+			v.SetMapIndex(c.copy(iter.Key()), c.copy(iter.Value()))
+		}
+
+		dst.Set(v)
+	default:
+		dst.Set(src)
+	}
+}
+
+// visit is a pair of pointers compared by equal, it guards against cycles
+type visit struct {
+	a, b uintptr
+	typ  reflect.Type
+}
+
+// equal returns true if the values are deeply equal like reflect.DeepEqual, but functions are
+// not compared. reflect.DeepEqual treats all non-nil functions as different, while a copy shares
+// the functions of the original and transformations never change them.
+// nolint:gocyclo
+func equal(a, b reflect.Value, visited map[visit]bool) bool {
+	if a.IsValid() != b.IsValid() {
+		return false
+	}
+
+	if !a.IsValid() {
+		return true
+	}
+
+	if a.Type() != b.Type() {
+		return false
+	}
+
+	// nolint:exhaustive
+	switch a.Kind() {
+	case reflect.Func:
+		return true
+	case reflect.Ptr:
+		if a.IsNil() || b.IsNil() {
+			return a.IsNil() == b.IsNil()
+		}
+
+		if a.Pointer() == b.Pointer() {
+			return true
+		}
+
+		v := visit{a.Pointer(), b.Pointer(), a.Type()}
+		if visited[v] {
+			return true
+		}
+
+		visited[v] = true
+
+		return equal(a.Elem(), b.Elem(), visited)
+	case reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			return a.IsNil() == b.IsNil()
+		}
+
+		return equal(a.Elem(), b.Elem(), visited)
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			if !equal(a.Field(i), b.Field(i), visited) {
+				return false
+			}
+		}
+
+		return true
+	case reflect.Slice, reflect.Array:
+		if a.Kind() == reflect.Slice && a.IsNil() != b.IsNil() {
+			return false
+		}
+
+		if a.Len() != b.Len() {
+			return false
+		}
+
+		for i := 0; i < a.Len(); i++ {
+			if !equal(a.Index(i), b.Index(i), visited) {
+				return false
+			}
+		}
+
+		return true
+	case reflect.Map:
+		if a.IsNil() != b.IsNil() || a.Len() != b.Len() {
+			return false
+		}
+
+		iter := a.MapRange()
+		for iter.Next() {
+			bv := b.MapIndex(iter.Key())
+			if !bv.IsValid() || !equal(iter.Value(), bv, visited) {
+				return false
+			}
+		}
+
+		return true
+	case reflect.Bool:
+		return a.Bool() == b.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() == b.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return a.Uint() == b.Uint()
+	case reflect.Float32, reflect.Float64:
+		return math.Float64bits(a.Float()) == math.Float64bits(b.Float())
+	case reflect.Complex64, reflect.Complex128:
+		return a.Complex() == b.Complex()
+	case reflect.String:
+		return a.String() == b.String()
+	default:
+		// channels and unsafe pointers are copied shallow
+		return a.Pointer() == b.Pointer()
+	}
+}
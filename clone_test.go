@@ -0,0 +1,75 @@
+package transform_test
+
+import (
+	"testing"
+
+	"github.com/zeiss/go-transform"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClone(t *testing.T) {
+	type address struct {
+		City string
+	}
+
+	type testStruct struct {
+		Name     string
+		NamePtr  *string
+		Address  *address
+		Billing  *address
+		Tags     []string
+		Labels   map[string]string
+		Array    [2]address
+		Any      interface{}
+		internal *address
+	}
+
+	name := "test"
+	addr := &address{City: "Jena"}
+	internal := &address{City: "Berlin"}
+
+	in := &testStruct{
+		Name:     "test",
+		NamePtr:  &name,
+		Address:  addr,
+		Billing:  addr,
+		Tags:     []string{"a", "b"},
+		Labels:   map[string]string{"a": "b"},
+		Array:    [2]address{{City: "Munich"}},
+		Any:      &address{City: "Hamburg"},
+		internal: internal,
+	}
+
+	out, err := transform.Clone(in)
+	require.NoError(t, err)
+	require.Equal(t, in, out)
+
+	cp := out.(*testStruct)
+	require.NotSame(t, in.NamePtr, cp.NamePtr)
+	require.NotSame(t, in.Address, cp.Address)
+	require.Same(t, cp.Address, cp.Billing)
+	require.NotSame(t, in.Any, cp.Any)
+	require.Same(t, in.internal, cp.internal)
+
+	cp.Tags[0] = "c"
+	cp.Labels["a"] = "c"
+	cp.Address.City = "Dresden"
+
+	require.Equal(t, []string{"a", "b"}, in.Tags)
+	require.Equal(t, map[string]string{"a": "b"}, in.Labels)
+	require.Equal(t, "Jena", in.Address.City)
+}
+
+func TestCloneErrors(t *testing.T) {
+	out, err := transform.Clone((*struct{})(nil))
+	require.NoError(t, err)
+	require.Nil(t, out)
+
+	_, err = transform.Clone(struct{}{})
+	require.ErrorIs(t, err, transform.ErrNoPointer)
+
+	s := "test"
+	_, err = transform.Clone(&s)
+	require.ErrorIs(t, err, transform.ErrNoStruct)
+}
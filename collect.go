@@ -0,0 +1,31 @@
+package transform
+
+import "strings"
+
+// TransformErrors are the errors of the failed fields of a transformation in the order of the fields,
+// they are returned by a transformer created with WithCollectErrors
+type TransformErrors []error
+
+// Error implements the error interface
+func (e TransformErrors) Error() string {
+	s := make([]string, len(e))
+	for i, err := range e {
+		s[i] = err.Error()
+	}
+
+	return strings.Join(s, "; ")
+}
+
+// Unwrap returns the errors of the fields
+func (e TransformErrors) Unwrap() []error {
+	return e
+}
+
+// WithCollectErrors transforms all fields of a struct, even if some of them fail.
+// The errors of the failed fields (e.g. FieldError) are returned as TransformErrors,
+// so APIs can report all invalid fields of a request in a single response.
+func WithCollectErrors() TransformerOpt {
+	return func(o *TransformerImpl) {
+		o.collectErrors = true
+	}
+}
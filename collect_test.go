@@ -0,0 +1,69 @@
+package transform_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/zeiss/go-transform"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithCollectErrors(t *testing.T) {
+	type address struct {
+		City string `transform:"fail"`
+		Zip  string `transform:"trim"`
+	}
+
+	type testStruct struct {
+		Price    string `transform:"float=2"`
+		Name     string `transform:"trim"`
+		Address  address
+		Previous []address
+		Amount   string `transform:"float=2"`
+	}
+
+	errFail := errors.New("failed")
+	fail := transform.WithTransformation("fail", func(fl transform.FieldLevel) error { return errFail })
+
+	in := func() *testStruct {
+		return &testStruct{
+			Price:    "ten",
+			Name:     " John ",
+			Address:  address{Zip: " 10115 "},
+			Previous: []address{{Zip: " 1 "}, {Zip: " 2 "}},
+			Amount:   "1.5",
+		}
+	}
+
+	s := in()
+	err := transform.New(fail).Transform(s)
+	require.ErrorContains(t, err, `invalid number "ten"`)
+	require.Equal(t, " John ", s.Name)
+
+	s = in()
+	err = transform.New(fail, transform.WithCollectErrors()).Transform(s)
+	require.ErrorContains(t, err, `invalid number "ten"`)
+	require.ErrorIs(t, err, errFail)
+	require.Equal(t, "John", s.Name)
+	require.Equal(t, "10115", s.Address.Zip)
+	require.Equal(t, "2", s.Previous[1].Zip)
+	require.Equal(t, "1.50", s.Amount)
+
+	var errs transform.TransformErrors
+	require.ErrorAs(t, err, &errs)
+	require.Len(t, errs, 4)
+
+	paths := make([]string, len(errs))
+	for i, err := range errs {
+		var ferr *transform.FieldError
+		require.ErrorAs(t, err, &ferr)
+		paths[i] = ferr.Path
+	}
+
+	require.Equal(t, []string{"Price", "Address.City", "Previous[0].City", "Previous[1].City"}, paths)
+
+	r := transform.New(fail, transform.WithCollectErrors()).TransformWithReport(in())
+	require.Len(t, r.Errors, 4)
+	require.Equal(t, "John", r.Changes[0].New)
+}
@@ -0,0 +1,354 @@
+package transform
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// CompiledTransformer transforms values of a single struct type with the fields and functions
+// resolved by Compile, so a call only reads and writes the tagged fields
+type CompiledTransformer struct {
+	t     *TransformerImpl
+	typ   reflect.Type
+	steps []step
+	// generic is true if the type or the transformer needs the reflection walk of Transform
+	generic bool
+}
+
+// step transforms a field of the struct value
+type step func(r *run, v reflect.Value) error
+
+// run is the state of a call of a compiled transformer
+type run struct {
+	// seen maps pointers to the result of their transformation
+	seen map[pointer]reflect.Value
+	// shared maps string pointers to the result of the functions of a tag
+	shared map[sharedString]reflect.Value
+}
+
+// compiledCall is a function of a field resolved by Compile
+type compiledCall struct {
+	name  string
+	param string
+	fn    Func
+	buf   bufFunc
+}
+
+// Compile analyzes the struct type of the sample, which is a struct or a (nil) pointer to a struct,
+// and returns a transformer for pointers to values of the type. Unknown functions are returned
+// as error by a transformer created with WithErrorOnUnknownFunc or WithStrictMode.
+// Functions added to the transformer after Compile are not used by the compiled transformer.
+//
+// Types with slices, maps, interfaces with a handler, enums or recursive types, and transformers
+// with a recorder, metrics, a program, a skip function, diagnostics, collected errors or an
+// idempotency guard are transformed by Transform.
+func (t *TransformerImpl) Compile(sample interface{}) (*CompiledTransformer, error) {
+	typ := reflect.TypeOf(sample)
+	if typ == nil {
+		return nil, ErrNoStruct
+	}
+
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	if typ.Kind() != reflect.Struct {
+		return nil, &KindError{Kind: typ.Kind(), Err: ErrNoStruct}
+	}
+
+	if t.strict {
+		if err := t.checkFuncs(typ); err != nil {
+			return nil, err
+		}
+	}
+
+	c := &CompiledTransformer{t: t, typ: typ}
+
+	if t.recorder != nil || t.metrics != nil || t.program != nil || t.skipFunc != nil ||
+		t.diagnostics || t.collectErrors || t.idempotent || t.initNilPointers || t.maxDepth > 0 {
+		c.generic = true
+		return c, nil
+	}
+
+	steps, ok, err := t.compileStruct(typ, location{}, map[reflect.Type]bool{})
+	if err != nil {
+		return nil, err
+	}
+
+	c.steps, c.generic = steps, !ok
+
+	return c, nil
+}
+
+// Transform transforms the struct s points to, it must be of the compiled type
+func (c *CompiledTransformer) Transform(s interface{}) error {
+	v := reflect.ValueOf(s)
+	if v.Kind() != reflect.Ptr || v.Type().Elem() != c.typ {
+		return fmt.Errorf("%w: %s, got %T", ErrTypeMismatch, reflect.PointerTo(c.typ), s)
+	}
+
+	if v.IsNil() {
+		return nil // bail out of if this nil
+	}
+
+	if c.generic {
+		return c.t.Transform(s)
+	}
+
+	r := run{}
+
+	for _, s := range c.steps {
+		if err := s(&r, v.Elem()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// compileStruct returns the steps of the fields of the struct type in the order of their transformation,
+// ok is false if the type needs the reflection walk
+func (t *TransformerImpl) compileStruct(typ reflect.Type, loc location, visiting map[reflect.Type]bool) ([]step, bool, error) {
+	if visiting[typ] {
+		return nil, false, nil // recursive types are walked
+	}
+
+	visiting[typ] = true
+	defer delete(visiting, typ)
+
+	p, err := t.planOf(typ, loc)
+	if err != nil || p.structLevel {
+		return nil, false, err // struct-level transformations are walked
+	}
+
+	order := p.order
+	if order == nil {
+		order = make([]int, len(p.fields))
+		for i := range order {
+			order[i] = i
+		}
+	}
+
+	steps := []step{}
+
+	for _, i := range order {
+		f := p.fields[i]
+
+		if !f.field.IsExported() && !f.promoted {
+			continue // unexported fields can't be set, the exported fields of embedded structs can
+		}
+
+		fl := f.locate(loc)
+
+		et := f.field.Type
+		if et.Kind() == reflect.Ptr {
+			et = et.Elem()
+		}
+
+		// nolint:exhaustive
+		switch et.Kind() {
+		case reflect.String:
+			s, err := t.compileString(f, fl)
+			if err != nil {
+				return nil, false, err
+			}
+
+			steps = append(steps, s)
+		case reflect.Struct:
+			nested, ok, err := t.compileStruct(et, fl, visiting)
+			if err != nil || !ok {
+				return nil, ok, err
+			}
+
+			if len(nested) > 0 {
+				steps = append(steps, nestedStep(f.index, nested))
+			}
+		case reflect.Slice, reflect.Array:
+			if !t.elementsNoop(et, f.tag) {
+				return nil, false, nil
+			}
+		case reflect.Map:
+			if _, dive := diveFuncs(f.tag); dive {
+				return nil, false, nil
+			}
+		case reflect.Interface:
+			if _, ok := t.interfaceHandlers[f.field.Type]; ok {
+				return nil, false, nil
+			}
+		default:
+			if _, ok := t.enum(f.field.Type); ok && f.tag != "" {
+				return nil, false, nil
+			}
+		}
+	}
+
+	return steps, true, nil
+}
+
+// elementsNoop returns true if the elements of the slice or array type are not transformed
+func (t *TransformerImpl) elementsNoop(typ reflect.Type, tag string) bool {
+	et := typ.Elem()
+	if et.Kind() == reflect.Ptr {
+		et = et.Elem()
+	}
+
+	// nolint:exhaustive
+	switch et.Kind() {
+	case reflect.String:
+		_, dive := diveFuncs(tag)
+		return !dive
+	case reflect.Struct:
+		return t.skipType(et)
+	}
+
+	return true
+}
+
+// nestedStep returns the step of a nested struct or pointer to struct,
+// a struct pointed to by multiple fields is only transformed once
+func nestedStep(index int, steps []step) step {
+	return func(r *run, v reflect.Value) error {
+		fv := v.Field(index)
+
+		if fv.Kind() == reflect.Ptr {
+			if fv.IsNil() {
+				return nil
+			}
+
+			p := pointer{fv.Pointer(), fv.Type()}
+			if _, ok := r.seen[p]; ok {
+				return nil
+			}
+
+			r.remember(p, fv)
+
+			fv = fv.Elem()
+		}
+
+		for _, s := range steps {
+			if err := s(r, fv); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// compileString returns the step of a string field with the functions of its tag
+func (t *TransformerImpl) compileString(f fieldPlan, loc location) (step, error) {
+	funcs, omit := omitEmpty(f.funcs)
+	calls := make([]compiledCall, 0, len(funcs))
+
+	for _, fn := range funcs {
+		if bf, ok := t.buffered(fn); ok {
+			calls = append(calls, compiledCall{name: fn, buf: bf})
+			continue
+		}
+
+		name, param, _ := strings.Cut(fn, "=")
+
+		resolved, ok := t.lookup(name)
+		if !ok && name != "" && t.errorOnUnknown {
+			return nil, &UnknownFuncError{Path: loc.path, Name: name}
+		}
+
+		calls = append(calls, compiledCall{name: name, param: param, fn: resolved})
+
+		if !ok {
+			break // the functions after an unknown function are skipped
+		}
+	}
+
+	tmpl := fieldLevel{field: f.field, tagName: t.TagName, tag: f.tag, funcs: f.funcs, loc: loc}
+	alloc := hasDefault(f.funcs)
+
+	return func(r *run, v reflect.Value) error {
+		fv := v.Field(f.index)
+
+		if fv.Kind() == reflect.Ptr && fv.IsNil() && alloc {
+			fv.Set(reflect.New(fv.Type().Elem()))
+		}
+
+		if omit && ((fv.Kind() == reflect.Ptr && fv.IsNil()) || reflect.Indirect(fv).String() == "") {
+			return nil
+		}
+
+		if fv.Kind() != reflect.Ptr || t.repeatShared {
+			return t.runCalls(fv, v, tmpl, calls)
+		}
+
+		if fv.IsNil() {
+			return nil
+		}
+
+		p := sharedString{pointer{fv.Pointer(), fv.Type()}, f.tag}
+		if shared, ok := r.shared[p]; ok {
+			fv.Set(shared)
+			return nil
+		}
+
+		if err := t.runCalls(fv, v, tmpl, calls); err != nil {
+			return err
+		}
+
+		if r.shared == nil {
+			r.shared = make(map[sharedString]reflect.Value)
+		}
+
+		r.shared[p] = fv
+
+		return nil
+	}, nil
+}
+
+// runCalls applies the resolved functions to the field value,
+// consecutive string functions share a single buffer
+func (t *TransformerImpl) runCalls(fv, parent reflect.Value, tmpl fieldLevel, calls []compiledCall) error {
+	field := tmpl
+	field.val, field.parent = fv, parent
+
+	var buf buffer
+	defer buf.release()
+
+	for _, c := range calls {
+		if c.buf != nil {
+			if !buf.active() {
+				buf.reset(field.String())
+			}
+
+			buf.b = c.buf(buf.b)
+
+			continue
+		}
+
+		if buf.active() {
+			SetString(field, string(buf.b))
+			buf.release()
+		}
+
+		if c.fn == nil {
+			return nil // bail out if we don't have the function
+		}
+
+		if err := t.call(c.name, c.fn, withParam(field, c.param)); err != nil {
+			return t.fieldError(field, c.name, err)
+		}
+	}
+
+	if buf.active() {
+		SetString(field, string(buf.b))
+	}
+
+	return nil
+}
+
+// remember records the pointer and the result of its transformation
+func (r *run) remember(p pointer, v reflect.Value) {
+	if r.seen == nil {
+		r.seen = make(map[pointer]reflect.Value)
+	}
+
+	r.seen[p] = v
+}
@@ -0,0 +1,145 @@
+package transform_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/zeiss/go-transform"
+
+	"github.com/stretchr/testify/require"
+)
+
+type compileAddress struct {
+	City    string  `json:"city" transform:"trim,uppercase"`
+	Country *string `transform:"trim,lowercase"`
+}
+
+type compileStruct struct {
+	Slug     string `transform:"slugfrom=Title"`
+	Title    string `transform:"trim"`
+	Name     string `transform:"rtrim,ltrim,uppercase,truncate=4"`
+	Nick     *string
+	Alias    *string `transform:"trim"`
+	Nil      *string `transform:"trim"`
+	Count    int
+	Bytes    []byte
+	Address  compileAddress
+	Billing  *compileAddress
+	Shipping *compileAddress
+	Tags     []string
+	internal string `transform:"trim"` //nolint:unused
+}
+
+func newCompileStruct() *compileStruct {
+	alias, country := " al ", " DE "
+	billing := &compileAddress{City: " munich ", Country: &country}
+
+	return &compileStruct{
+		Title:    " Hello World ",
+		Name:     "  john doe ",
+		Nick:     &alias,
+		Alias:    &alias,
+		Address:  compileAddress{City: " berlin "},
+		Billing:  billing,
+		Shipping: billing,
+		Tags:     []string{" a "},
+	}
+}
+
+func TestCompile(t *testing.T) {
+	for _, opts := range [][]transform.TransformerOpt{
+		nil,
+		{transform.WithCollectErrors()},
+		{transform.WithRepeatSharedPointers()},
+	} {
+		trans := transform.New(opts...)
+
+		compiled, err := trans.Compile(compileStruct{})
+		require.NoError(t, err)
+
+		for i := 0; i < 2; i++ {
+			got, want := newCompileStruct(), newCompileStruct()
+
+			require.NoError(t, compiled.Transform(got))
+			require.NoError(t, trans.Transform(want))
+			require.Equal(t, want, got)
+			require.Equal(t, "JOHN", got.Name)
+			require.Equal(t, "BERLIN", got.Address.City)
+		}
+	}
+
+	compiled, err := transform.New().Compile(&compileStruct{})
+	require.NoError(t, err)
+	require.NoError(t, compiled.Transform((*compileStruct)(nil)))
+	require.ErrorIs(t, compiled.Transform(&compileAddress{}), transform.ErrTypeMismatch)
+	require.ErrorIs(t, compiled.Transform(compileStruct{}), transform.ErrTypeMismatch)
+}
+
+func TestCompileGeneric(t *testing.T) {
+	type element struct {
+		Name string `transform:"trim"`
+	}
+
+	type testStruct struct {
+		Name     string            `transform:"trim"`
+		Elements []element         `transform:"trim"`
+		Tags     []string          `transform:"dive,trim"`
+		Labels   map[string]string `transform:"dive,trim"`
+	}
+
+	compiled, err := transform.New().Compile(testStruct{})
+	require.NoError(t, err)
+
+	s := testStruct{Name: " a ", Elements: []element{{Name: " b "}}, Tags: []string{" c "}, Labels: map[string]string{"k": " d "}}
+	require.NoError(t, compiled.Transform(&s))
+	require.Equal(t, testStruct{Name: "a", Elements: []element{{Name: "b"}}, Tags: []string{"c"}, Labels: map[string]string{"k": "d"}}, s)
+}
+
+func TestCompileErrors(t *testing.T) {
+	type testStruct struct {
+		Address compileAddress
+		Price   string `transform:"fail"`
+	}
+
+	errFail := errors.New("failed")
+	fail := transform.WithTransformation("fail", func(fl transform.FieldLevel) error { return errFail })
+
+	compiled, err := transform.New(fail).Compile(testStruct{})
+	require.NoError(t, err)
+
+	err = compiled.Transform(&testStruct{})
+	require.ErrorIs(t, err, errFail)
+
+	var ferr *transform.FieldError
+	require.ErrorAs(t, err, &ferr)
+	require.Equal(t, "Price", ferr.Path)
+	require.Equal(t, "fail", ferr.Func)
+
+	_, err = transform.New(transform.WithErrorOnUnknownFunc()).Compile(testStruct{})
+
+	var uerr *transform.UnknownFuncError
+	require.ErrorAs(t, err, &uerr)
+	require.Equal(t, "Price", uerr.Path)
+
+	_, err = transform.New().Compile("")
+	require.ErrorIs(t, err, transform.ErrNoStruct)
+}
+
+func BenchmarkCompiled(b *testing.B) {
+	compiled, err := transform.New().Compile(compileAddress{})
+	require.NoError(b, err)
+
+	for i := 0; i < b.N; i++ {
+		err := compiled.Transform(&compileAddress{City: "  test  "})
+		require.NoError(b, err)
+	}
+}
+
+func BenchmarkUncompiled(b *testing.B) {
+	trans := transform.New()
+
+	for i := 0; i < b.N; i++ {
+		err := trans.Transform(&compileAddress{City: "  test  "})
+		require.NoError(b, err)
+	}
+}
@@ -0,0 +1,162 @@
+package transform_test
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/zeiss/go-transform"
+
+	"github.com/stretchr/testify/require"
+)
+
+type concurrentAddress struct {
+	City string `transform:"trim,uppercase"`
+}
+
+type concurrentUser struct {
+	Name      string `transform:"trim,lowercase" metric:"name"`
+	FullName  string `transform:"format={{.Name}} {{.Last}}"`
+	Last      string `transform:"squish"`
+	Alias     string `transform:"pseudonym=k"`
+	Payload   string `transform:"canonicaljson"`
+	Slug      string `transform:"slugfrom=Name"`
+	Home      *concurrentAddress
+	Addresses []concurrentAddress
+}
+
+func newConcurrentUser(i int) *concurrentUser {
+	return &concurrentUser{
+		Name:      fmt.Sprintf(" USER %d ", i),
+		Last:      "  Doe  ",
+		Alias:     "alias",
+		Payload:   `{"b":1,"a":2}`,
+		Home:      &concurrentAddress{City: " jena "},
+		Addresses: []concurrentAddress{{City: " berlin "}},
+	}
+}
+
+func TestConcurrentTransform(t *testing.T) {
+	for _, frozen := range []bool{false, true} {
+		t.Run(fmt.Sprintf("frozen=%v", frozen), func(t *testing.T) {
+			var buf bytes.Buffer
+
+			trans := transform.New(
+				transform.WithHMACKey("k", []byte("secret")),
+				transform.WithRecorder(transform.NewRecorder(&buf, transform.WithRecorderSampleRate(0.5))),
+				transform.WithMetrics(newCounters()),
+				transform.WithProgram(transform.MustCompileRules(`apply("Last", "uppercase")`)),
+			)
+
+			if frozen {
+				trans.Freeze()
+			}
+
+			const goroutines = 16
+
+			var wg sync.WaitGroup
+
+			errs := make(chan error, goroutines*4)
+
+			for i := 0; i < goroutines; i++ {
+				wg.Add(1)
+
+				go func(i int) {
+					defer wg.Done()
+
+					u := newConcurrentUser(i)
+					if err := trans.Transform(u); err != nil {
+						errs <- err
+						return
+					}
+
+					if u.FullName != fmt.Sprintf("user %d Doe", i) || u.Home.City != "JENA" {
+						errs <- fmt.Errorf("unexpected result %+v", u)
+					}
+
+					if _, _, err := trans.TransformCOW(newConcurrentUser(i)); err != nil {
+						errs <- err
+					}
+
+					if _, err := trans.TransformPatch(newConcurrentUser(i)); err != nil {
+						errs <- err
+					}
+
+					if r := trans.TransformWithReport(newConcurrentUser(i)); !r.Ok() {
+						errs <- r.Err()
+					}
+				}(i)
+			}
+
+			wg.Wait()
+			close(errs)
+
+			for err := range errs {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestConcurrentRegister(t *testing.T) {
+	trans := transform.New()
+
+	type testStruct struct {
+		Name string `transform:"trim,concurrent_0"`
+	}
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+
+		go func(i int) {
+			defer wg.Done()
+
+			transform.Register(fmt.Sprintf("concurrent_%d", i), func(fl transform.FieldLevel) error { return nil })
+		}(i)
+
+		go func() {
+			defer wg.Done()
+
+			err := trans.Transform(&testStruct{Name: " a "})
+			require.NoError(t, err)
+		}()
+	}
+
+	wg.Wait()
+}
+
+func TestConcurrentRegisterTransformation(t *testing.T) {
+	trans := transform.New()
+
+	type testStruct struct {
+		Name string `transform:"trim,lowercase,instance_0"`
+	}
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+
+		go func(i int) {
+			defer wg.Done()
+
+			err := trans.RegisterTransformation(fmt.Sprintf("instance_%d", i), func(fl transform.FieldLevel) error { return nil })
+			require.NoError(t, err)
+		}(i)
+
+		go func() {
+			defer wg.Done()
+
+			s := &testStruct{Name: " A "}
+
+			err := trans.Transform(s)
+			require.NoError(t, err)
+			require.Equal(t, "a", s.Name)
+		}()
+	}
+
+	wg.Wait()
+}
@@ -0,0 +1,61 @@
+package transform
+
+import (
+	"context"
+	"fmt"
+)
+
+// ErrCanceled is returned by TransformCtx if the context is done before all fields are transformed
+var ErrCanceled = newError("transformer: transformation canceled")
+
+// FuncCtx transforms the field value with the context of TransformCtx (e.g. to read the locale or tenant of a request)
+type FuncCtx func(ctx context.Context, fl FieldLevel) error
+
+// Func returns the function as Func, it receives the context of TransformCtx
+// or context.Background() for the other methods of the transformer
+func (fn FuncCtx) Func() Func {
+	if fn == nil {
+		return nil
+	}
+
+	return func(fl FieldLevel) error {
+		return fn(contextOf(fl), fl)
+	}
+}
+
+// WithTransformationCtx adds a context-aware transform function, it replaces a function of the same name
+func WithTransformationCtx(name string, fn FuncCtx) TransformerOpt {
+	return WithTransformation(name, fn.Func())
+}
+
+// contextOf returns the context of the transformation of the field
+func contextOf(fl FieldLevel) context.Context {
+	if f, ok := fl.(fieldLevel); ok && f.ctx != nil {
+		return f.ctx
+	}
+
+	return context.Background()
+}
+
+// withContext returns the field with the context of the transformation
+func withContext(fl FieldLevel, ctx context.Context) FieldLevel {
+	if f, ok := fl.(fieldLevel); ok && ctx != nil {
+		f.ctx = ctx
+		return f
+	}
+
+	return fl
+}
+
+// canceled returns ErrCanceled if the context of the transformation is done
+func (st *state) canceled() error {
+	if st.ctx == nil {
+		return nil
+	}
+
+	if err := st.ctx.Err(); err != nil {
+		return fmt.Errorf("%w: %w", ErrCanceled, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package transform_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/zeiss/go-transform"
+
+	"github.com/stretchr/testify/require"
+)
+
+type tenantKey struct{}
+
+// tenantPrefix prefixes the value with the tenant of the context
+func tenantPrefix(ctx context.Context, fl transform.FieldLevel) error {
+	if tenant, ok := ctx.Value(tenantKey{}).(string); ok {
+		transform.SetString(fl, tenant+":"+fl.String())
+	}
+
+	return nil
+}
+
+func TestTransformCtx(t *testing.T) {
+	type testStruct struct {
+		ID   string `transform:"trim,tenant"`
+		Name string `transform:"trim"`
+	}
+
+	trans := transform.New(transform.WithTransformationCtx("tenant", tenantPrefix))
+
+	ctx := context.WithValue(context.Background(), tenantKey{}, "acme")
+
+	s := testStruct{ID: " 42 ", Name: " John "}
+	require.NoError(t, trans.TransformCtx(ctx, &s))
+	require.Equal(t, testStruct{ID: "acme:42", Name: "John"}, s)
+
+	// without a context the function receives context.Background()
+	s = testStruct{ID: " 42 "}
+	require.NoError(t, trans.Transform(&s))
+	require.Equal(t, "42", s.ID)
+}
+
+func TestTransformCtxCanceled(t *testing.T) {
+	type testStruct struct {
+		First  string `transform:"cancel"`
+		Second string `transform:"trim"`
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	trans := transform.New(transform.WithTransformationCtx("cancel", func(context.Context, transform.FieldLevel) error {
+		cancel()
+		return nil
+	}))
+
+	s := testStruct{Second: " x "}
+
+	err := trans.TransformCtx(ctx, &s)
+	require.ErrorIs(t, err, transform.ErrCanceled)
+	require.ErrorIs(t, err, context.Canceled)
+	require.True(t, errors.Is(err, transform.ErrTransform))
+	require.Equal(t, " x ", s.Second)
+
+	err = transform.TransformCtx(ctx, &testStruct{})
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestTransformCtxInterfaceHandler(t *testing.T) {
+	type testStruct struct {
+		Value interface{}
+	}
+
+	var got context.Context
+
+	trans := transform.New()
+	require.NoError(t, trans.RegisterInterfaceHandler(reflect.TypeOf((*interface{})(nil)).Elem(), transform.FuncCtx(func(ctx context.Context, _ transform.FieldLevel) error {
+		got = ctx
+		return nil
+	}).Func()))
+
+	ctx := context.WithValue(context.Background(), tenantKey{}, "acme")
+	require.NoError(t, trans.TransformCtx(ctx, &testStruct{Value: 1}))
+	require.Equal(t, "acme", got.Value(tenantKey{}))
+}
@@ -0,0 +1,150 @@
+package transform
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrAmbiguousDate is returned by date if the order of the day and the month can't be told without a hint
+var ErrAmbiguousDate = newError("transformer: ambiguous date")
+
+// dateOutputs are the output layouts of date
+var dateOutputs = map[string]string{
+	"":        time.DateOnly,
+	"iso":     time.DateOnly,
+	"rfc3339": time.RFC3339,
+}
+
+// namedDateLayouts are the accepted layouts of date with month names or a zone
+var namedDateLayouts = []string{
+	time.RFC3339Nano,
+	time.DateTime,
+	time.DateOnly,
+	time.RFC1123,
+	time.RFC1123Z,
+	"Jan 2 2006",
+	"Jan 2, 2006",
+	"January 2 2006",
+	"January 2, 2006",
+	"2 Jan 2006",
+	"2 January 2006",
+	"02-Jan-2006",
+	"Mon Jan 2 2006",
+	"Mon, Jan 2, 2006",
+}
+
+// dateFunc parses a date in a common format and rewrites it as an ISO 8601 date or RFC 3339 time
+// (date=iso or date=rfc3339). Numeric dates with two numbers below 13 (e.g. 02/01/2006) are ambiguous,
+// the hint dmy or mdy selects the order of the day and the month (date=iso:dmy).
+func dateFunc(fl FieldLevel) error {
+	name, hint, _ := strings.Cut(fl.Param(), ":")
+
+	layout, ok := dateOutputs[name]
+	if !ok || (hint != "" && hint != "dmy" && hint != "mdy") {
+		return fmt.Errorf("%w: date=%s", ErrInvalidParam, fl.Param())
+	}
+
+	s := strings.TrimSpace(fl.String())
+	if s == "" {
+		return nil
+	}
+
+	d, err := parseDate(s, hint)
+	if err != nil {
+		return err
+	}
+
+	SetString(fl, d.Format(layout))
+
+	return nil
+}
+
+// parseDate parses a date with a named layout, a numeric date separated by slashes, dots or dashes,
+// an ISO 8601 basic date (20060102) or the seconds since the epoch
+func parseDate(s, hint string) (time.Time, error) {
+	for _, l := range namedDateLayouts {
+		if d, err := time.Parse(l, s); err == nil {
+			return d, nil
+		}
+	}
+
+	if isDigits(s) {
+		if len(s) == 8 {
+			if d, err := time.Parse("20060102", s); err == nil {
+				return d, nil
+			}
+		}
+
+		if sec, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return time.Unix(sec, 0).UTC(), nil
+		}
+	}
+
+	if d, ok, err := parseNumericDate(s, hint); ok {
+		return d, err
+	}
+
+	return time.Time{}, fmt.Errorf("date: invalid date %q", s)
+}
+
+// parseNumericDate parses a date of three numbers separated by slashes, dots or dashes,
+// it returns false if the value is not such a date
+func parseNumericDate(s, hint string) (time.Time, bool, error) {
+	i := strings.IndexAny(s, "/.-")
+	if i < 0 {
+		return time.Time{}, false, nil
+	}
+
+	parts := strings.Split(s, s[i:i+1])
+	if len(parts) != 3 {
+		return time.Time{}, false, nil
+	}
+
+	n := [3]int{}
+	for i, p := range parts {
+		if !isDigits(p) || len(p) > 4 {
+			return time.Time{}, false, nil
+		}
+
+		n[i], _ = strconv.Atoi(p)
+	}
+
+	var year, month, day int
+
+	switch {
+	case len(parts[0]) == 4:
+		year, month, day = n[0], n[1], n[2] // 2006/01/02
+	case len(parts[2]) != 4:
+		return time.Time{}, false, nil // two-digit years are not supported
+	case hint == "dmy" || (hint == "" && n[0] > 12):
+		year, month, day = n[2], n[1], n[0]
+	case hint == "mdy" || (hint == "" && n[1] > 12) || n[0] == n[1]:
+		year, month, day = n[2], n[0], n[1]
+	default:
+		return time.Time{}, true, fmt.Errorf("%w %q, use the hint dmy or mdy", ErrAmbiguousDate, s)
+	}
+
+	d := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	if d.Year() != year || int(d.Month()) != month || d.Day() != day {
+		return time.Time{}, true, fmt.Errorf("date: invalid date %q", s)
+	}
+
+	return d, true, nil
+}
+
+// isDigits returns true if the string is a non-empty sequence of ASCII digits
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+
+	return true
+}
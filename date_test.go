@@ -0,0 +1,69 @@
+package transform_test
+
+import (
+	"testing"
+
+	"github.com/zeiss/go-transform"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDate(t *testing.T) {
+	tests := []struct {
+		name string
+		tag  string
+		in   string
+		out  string
+		err  error
+	}{
+		{name: "empty", tag: "date", in: "", out: ""},
+		{name: "iso", tag: "date", in: "2006-01-02", out: "2006-01-02"},
+		{name: "rfc3339", tag: "date=iso", in: "2006-01-02T15:04:05+07:00", out: "2006-01-02"},
+		{name: "datetime", tag: "date=rfc3339", in: "2006-01-02 15:04:05", out: "2006-01-02T15:04:05Z"},
+		{name: "slashes day first", tag: "date", in: "25/12/2023", out: "2023-12-25"},
+		{name: "slashes month first", tag: "date", in: "12/25/2023", out: "2023-12-25"},
+		{name: "same day and month", tag: "date", in: "03.03.2023", out: "2023-03-03"},
+		{name: "year first", tag: "date", in: "2023/1/2", out: "2023-01-02"},
+		{name: "ambiguous", tag: "date", in: "02/01/2006", err: transform.ErrAmbiguousDate},
+		{name: "hint dmy", tag: "date=iso:dmy", in: "02/01/2006", out: "2006-01-02"},
+		{name: "hint mdy", tag: "date=iso:mdy", in: "02/01/2006", out: "2006-02-01"},
+		{name: "month name", tag: "date", in: "Jan 2 2006", out: "2006-01-02"},
+		{name: "month name comma", tag: "date", in: " January 2, 2006 ", out: "2006-01-02"},
+		{name: "day month name", tag: "date", in: "02-Jan-2006", out: "2006-01-02"},
+		{name: "rfc1123", tag: "date=rfc3339", in: "Mon, 02 Jan 2006 15:04:05 +0100", out: "2006-01-02T15:04:05+01:00"},
+		{name: "basic", tag: "date", in: "20060102", out: "2006-01-02"},
+		{name: "epoch", tag: "date=rfc3339", in: "1136214245", out: "2006-01-02T15:04:05Z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := taggedStruct(tt.tag, tt.in)
+
+			err := transform.Transform(v.Interface())
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+				require.Equal(t, tt.in, v.Elem().Field(0).String())
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.out, v.Elem().Field(0).String())
+		})
+	}
+
+	invalid := []struct {
+		tag string
+		in  string
+		err error
+	}{
+		{tag: "date=unix", in: "2006-01-02", err: transform.ErrInvalidParam},
+		{tag: "date=iso:ymd", in: "2006-01-02", err: transform.ErrInvalidParam},
+		{tag: "date=iso:dmy", in: "31/02/2006", err: transform.ErrTransform},
+		{tag: "date", in: "tomorrow", err: transform.ErrTransform},
+	}
+
+	for _, tt := range invalid {
+		require.ErrorIs(t, transform.Transform(taggedStruct(tt.tag, tt.in).Interface()), tt.err)
+	}
+}
@@ -0,0 +1,60 @@
+package transform
+
+import (
+	"reflect"
+	"strings"
+)
+
+// WithInitNilPointers allocates nil pointers to structs before their fields are transformed,
+// so defaults (e.g. default=) are applied to the fields of nested structs that were not set
+func WithInitNilPointers() TransformerOpt {
+	return func(o *TransformerImpl) {
+		o.initNilPointers = true
+	}
+}
+
+// defaultFunc sets an empty value to the parameter (default=unknown), nil pointers to strings
+// are allocated for the function
+func defaultFunc(fl FieldLevel) error {
+	if fl.String() == "" {
+		SetString(fl, fl.Param())
+	}
+
+	return nil
+}
+
+// hasDefault returns true if the functions contain the default function
+func hasDefault(funcs []string) bool {
+	for _, fn := range funcs {
+		if name, _, _ := strings.Cut(fn, "="); name == "default" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// initNil allocates the nil pointer of the field if it is a pointer to a struct and WithInitNilPointers is set,
+// or a pointer to a string with the default function
+func (t *TransformerImpl) initNil(f FieldLevel) {
+	v := f.Field()
+	if v.Kind() != reflect.Ptr || !v.IsNil() || !v.CanSet() {
+		return
+	}
+
+	// nolint:exhaustive
+	switch v.Type().Elem().Kind() {
+	case reflect.Struct:
+		if !t.initNilPointers || t.skipType(v.Type().Elem()) {
+			return
+		}
+	case reflect.String:
+		if !hasDefault(f.Funcs()) {
+			return
+		}
+	default:
+		return
+	}
+
+	v.Set(reflect.New(v.Type().Elem()))
+}
@@ -0,0 +1,90 @@
+package transform_test
+
+import (
+	"testing"
+
+	"github.com/zeiss/go-transform"
+
+	"github.com/stretchr/testify/require"
+)
+
+type defaultsAddress struct {
+	Country string `transform:"trim,default=DE"`
+}
+
+type defaultsStruct struct {
+	Name     string           `transform:"trim,default=unknown"`
+	Nickname *string          `transform:"trim,default=none"`
+	Comment  *string          `transform:"trim"`
+	Address  *defaultsAddress `json:"address"`
+}
+
+func TestDefault(t *testing.T) {
+	nick := " jd "
+
+	tests := []struct {
+		name string
+		opts []transform.TransformerOpt
+		in   *defaultsStruct
+		out  *defaultsStruct
+	}{
+		{
+			name: "empty",
+			in:   &defaultsStruct{Name: "  "},
+			out:  &defaultsStruct{Name: "unknown", Nickname: &[]string{"none"}[0]},
+		},
+		{
+			name: "set",
+			in:   &defaultsStruct{Name: " John ", Nickname: &nick, Address: &defaultsAddress{Country: "FR"}},
+			out:  &defaultsStruct{Name: "John", Nickname: &[]string{"jd"}[0], Address: &defaultsAddress{Country: "FR"}},
+		},
+		{
+			name: "init nil pointers",
+			opts: []transform.TransformerOpt{transform.WithInitNilPointers()},
+			in:   &defaultsStruct{},
+			out: &defaultsStruct{
+				Name:     "unknown",
+				Nickname: &[]string{"none"}[0],
+				Address:  &defaultsAddress{Country: "DE"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			trans := transform.New(tc.opts...)
+
+			in := *tc.in
+			require.NoError(t, trans.Transform(&in))
+			require.Equal(t, tc.out, &in)
+
+			compiled, err := trans.Compile(defaultsStruct{})
+			require.NoError(t, err)
+
+			in = *tc.in
+			require.NoError(t, compiled.Transform(&in))
+			require.Equal(t, tc.out, &in)
+		})
+	}
+
+	require.Equal(t, " jd ", nick)
+}
+
+func TestInitNilPointersDiagnostics(t *testing.T) {
+	r := transform.New(transform.WithInitNilPointers(), transform.WithDiagnostics()).TransformWithReport(&defaultsStruct{})
+	require.NoError(t, r.Err())
+	require.Equal(t, []string{"Comment"}, unreachedPaths(r.Unreached))
+
+	r = transform.New(transform.WithDiagnostics()).TransformWithReport(&defaultsStruct{})
+	require.NoError(t, r.Err())
+	require.Equal(t, []string{"Comment", "Address.Country"}, unreachedPaths(r.Unreached))
+}
+
+func unreachedPaths(u []transform.Unreached) []string {
+	paths := make([]string, len(u))
+	for i, r := range u {
+		paths[i] = r.Path
+	}
+
+	return paths
+}
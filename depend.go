@@ -0,0 +1,202 @@
+package transform
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ErrDependencyCycle is returned if the cross-field functions of a struct depend on each other
+var ErrDependencyCycle = newError("transformer: dependency cycle")
+
+// crossFieldTransformers are the functions reading the field of the struct named in the parameter.
+// The fields of a struct are transformed in dependency order, so the source is always transformed first,
+// format depends on the fields referenced by its template.
+var crossFieldTransformers = map[string]struct{}{
+	"copyfrom": {},
+	"slugfrom": {},
+	"hashof":   {},
+}
+
+// templateFields matches the fields referenced by the template of format (e.g. {{.FirstName}})
+var templateFields = regexp.MustCompile(`\.([A-Za-z_][A-Za-z0-9_]*)`)
+
+// copyFromFunc copies the value of the field named in the parameter (copyfrom=Email)
+func copyFromFunc(fl FieldLevel) error {
+	s, err := sourceString(fl)
+	if err != nil {
+		return err
+	}
+
+	SetString(fl, s)
+
+	return nil
+}
+
+// slugFromFunc sets a URL slug of the field named in the parameter (slugfrom=Title)
+func slugFromFunc(fl FieldLevel) error {
+	s, err := sourceString(fl)
+	if err != nil {
+		return err
+	}
+
+	SetString(fl, slug(s))
+
+	return nil
+}
+
+// hashOfFunc sets the hex encoded SHA-256 of the field named in the parameter (hashof=Email)
+func hashOfFunc(fl FieldLevel) error {
+	s, err := sourceString(fl)
+	if err != nil {
+		return err
+	}
+
+	sum := sha256.Sum256([]byte(s))
+	SetString(fl, hex.EncodeToString(sum[:]))
+
+	return nil
+}
+
+// sourceString returns the string value of the field of the parent struct named in the parameter
+func sourceString(fl FieldLevel) (string, error) {
+	return siblingString(fl, fl.Param())
+}
+
+// siblingString returns the string value of the named field of the parent struct
+func siblingString(fl FieldLevel, name string) (string, error) {
+	parent := fl.Parent()
+	if !parent.IsValid() {
+		return "", fmt.Errorf("%w: %s", ErrUnknownField, name)
+	}
+
+	ft, ok := parent.Type().FieldByName(name)
+	if !ok || !ft.IsExported() {
+		return "", fmt.Errorf("%w: %s", ErrUnknownField, name)
+	}
+
+	v, err := parent.FieldByIndexErr(ft.Index)
+	if err != nil {
+		return "", nil // a nil embedded pointer holds an empty value
+	}
+
+	return fieldString(v), nil
+}
+
+// slug returns the lowercase alphanumeric ASCII characters of the value separated by dashes
+func slug(s string) string {
+	b := make([]byte, 0, len(s))
+	dash := false
+
+	for _, r := range strings.ToLower(s) {
+		if r < 128 && ('a' <= r && r <= 'z' || '0' <= r && r <= '9') {
+			if dash && len(b) > 0 {
+				b = append(b, '-')
+			}
+
+			b = append(b, byte(r))
+			dash = false
+
+			continue
+		}
+
+		dash = true
+	}
+
+	return string(b)
+}
+
+// dependencies returns the names of the fields the field depends on
+func dependencies(fl FieldLevel) []string {
+	var deps []string
+
+	for _, f := range fl.Funcs() {
+		name, param, _ := strings.Cut(f, "=")
+		if _, ok := crossFieldTransformers[name]; ok {
+			deps = append(deps, param)
+		}
+
+		if _, src, ok := strings.Cut(param, ":"); ok && name == "enrich" {
+			deps = append(deps, src)
+		}
+
+		if name == "timezone" {
+			deps = append(deps, strings.Split(param, ":")...)
+		}
+
+		if name == "format" {
+			for _, m := range templateFields.FindAllStringSubmatch(param, -1) {
+				deps = append(deps, m[1])
+			}
+		}
+	}
+
+	return deps
+}
+
+// sortFields returns the fields in dependency order, keeping the order of the struct otherwise
+func sortFields(fields []FieldLevel) ([]FieldLevel, error) {
+	deps := make([][]string, len(fields))
+	found := false
+
+	for i, f := range fields {
+		deps[i] = dependencies(f)
+		found = found || len(deps[i]) > 0
+	}
+
+	if !found {
+		return fields, nil
+	}
+
+	index := make(map[string]int, len(fields))
+	for i, f := range fields {
+		index[f.FieldName()] = i
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	marks := make([]int, len(fields))
+	sorted := make([]FieldLevel, 0, len(fields))
+
+	var visit func(i int) error
+	visit = func(i int) error {
+		switch marks[i] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("%w: %s", ErrDependencyCycle, fields[i].Path())
+		}
+
+		marks[i] = visiting
+
+		for _, name := range deps[i] {
+			j, ok := index[name]
+			if !ok || j == i {
+				continue // the source is not transformed or the field itself
+			}
+
+			if err := visit(j); err != nil {
+				return err
+			}
+		}
+
+		marks[i] = visited
+		sorted = append(sorted, fields[i])
+
+		return nil
+	}
+
+	for i := range fields {
+		if err := visit(i); err != nil {
+			return nil, err
+		}
+	}
+
+	return sorted, nil
+}
@@ -0,0 +1,94 @@
+package transform_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/zeiss/go-transform"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCrossField(t *testing.T) {
+	// the derived fields are declared before their sources on purpose
+	type testStruct struct {
+		Slug     string `transform:"slugfrom=Title"`
+		Hash     string `transform:"hashof=Email"`
+		Username string `transform:"copyfrom=Email,trim"`
+		Title    string `transform:"trim"`
+		Email    string `transform:"trim,lowercase"`
+	}
+
+	in := &testStruct{
+		Title: "  Hello, Wörld!  2024 ",
+		Email: " Foo@Example.COM ",
+	}
+
+	err := transform.Transform(in)
+	require.NoError(t, err)
+
+	sum := sha256.Sum256([]byte("foo@example.com"))
+
+	require.Equal(t, &testStruct{
+		Slug:     "hello-w-rld-2024",
+		Hash:     hex.EncodeToString(sum[:]),
+		Username: "foo@example.com",
+		Title:    "Hello, Wörld!  2024",
+		Email:    "foo@example.com",
+	}, in)
+}
+
+func TestCrossFieldChain(t *testing.T) {
+	type testStruct struct {
+		C string `transform:"copyfrom=B"`
+		B string `transform:"copyfrom=A,uppercase"`
+		A string `transform:"trim"`
+	}
+
+	in := &testStruct{A: " a "}
+
+	err := transform.Transform(in)
+	require.NoError(t, err)
+	require.Equal(t, &testStruct{A: "a", B: "A", C: "A"}, in)
+}
+
+func TestCrossFieldNilEmbedded(t *testing.T) {
+	type inner struct {
+		Name string
+	}
+
+	type testStruct struct {
+		*inner
+		Copy string `transform:"copyfrom=Name"`
+		Slug string `transform:"slugfrom=Name"`
+	}
+
+	in := &testStruct{Copy: "old", Slug: "old"}
+	require.NoError(t, transform.Transform(in))
+	require.Equal(t, &testStruct{}, in)
+
+	in = &testStruct{inner: &inner{Name: "Jena City"}}
+	require.NoError(t, transform.Transform(in))
+	require.Equal(t, "Jena City", in.Copy)
+	require.Equal(t, "jena-city", in.Slug)
+}
+
+func TestCrossFieldCycle(t *testing.T) {
+	type testStruct struct {
+		A string `transform:"copyfrom=B"`
+		B string `transform:"copyfrom=A"`
+	}
+
+	err := transform.Transform(&testStruct{})
+	require.ErrorIs(t, err, transform.ErrDependencyCycle)
+}
+
+func TestCrossFieldUnknown(t *testing.T) {
+	type testStruct struct {
+		A string `transform:"copyfrom=Missing"`
+	}
+
+	err := transform.Transform(&testStruct{})
+	require.ErrorIs(t, err, transform.ErrUnknownField)
+}
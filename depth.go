@@ -0,0 +1,34 @@
+package transform
+
+import (
+	"fmt"
+)
+
+// ErrMaxDepthExceeded is returned if a value is nested deeper than the maximum depth
+var ErrMaxDepthExceeded = newError("transformer: maximum depth exceeded")
+
+// WithMaxDepth limits the nesting of structs, slices, arrays and maps below the transformed value to n levels,
+// deeper values return ErrMaxDepthExceeded with their path. It guards against pathological user-supplied documents,
+// the default is no limit. A field of the transformed struct or a key of the transformed map is at level 0.
+func WithMaxDepth(n int) TransformerOpt {
+	return func(o *TransformerImpl) {
+		o.maxDepth = n
+	}
+}
+
+// descend enters the nested value at the location, it is left by ascend
+func (t *TransformerImpl) descend(st *state, loc location) error {
+	st.depth++
+
+	if t.maxDepth > 0 && st.depth > t.maxDepth {
+		st.depth--
+		return fmt.Errorf("%w: %s", ErrMaxDepthExceeded, loc.path)
+	}
+
+	return nil
+}
+
+// ascend leaves the nested value entered by descend
+func (st *state) ascend() {
+	st.depth--
+}
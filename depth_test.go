@@ -0,0 +1,74 @@
+package transform_test
+
+import (
+	"testing"
+
+	"github.com/zeiss/go-transform"
+
+	"github.com/stretchr/testify/require"
+)
+
+type depthNode struct {
+	Name  string `transform:"trim"`
+	Next  *depthNode
+	Items []depthNode
+}
+
+// chain returns a list of nodes nested n levels below the returned node
+func chain(n int) *depthNode {
+	root := &depthNode{Name: " 0 "}
+
+	for node := root; n > 0; n-- {
+		node.Next = &depthNode{Name: " x "}
+		node = node.Next
+	}
+
+	return root
+}
+
+func TestMaxDepth(t *testing.T) {
+	tests := []struct {
+		name string
+		max  int
+		in   *depthNode
+		path string
+	}{
+		{name: "unlimited", in: chain(100)},
+		{name: "within", max: 3, in: chain(3)},
+		{name: "exceeded", max: 2, in: chain(3), path: "Next.Next.Next"},
+		{name: "elements", max: 1, in: &depthNode{Items: []depthNode{{Name: " a "}}}, path: "Items[0]"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := transform.New(transform.WithMaxDepth(tc.max)).Transform(tc.in)
+			if tc.path != "" {
+				require.ErrorIs(t, err, transform.ErrMaxDepthExceeded)
+				require.ErrorContains(t, err, ": "+tc.path)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, "0", tc.in.Name)
+		})
+	}
+}
+
+func TestMaxDepthMapAny(t *testing.T) {
+	rules := transform.MapRules{"**.name": "trim"}
+	doc := func() map[string]interface{} {
+		return map[string]interface{}{
+			"a": map[string]interface{}{
+				"b": []interface{}{map[string]interface{}{"name": " x "}},
+			},
+		}
+	}
+
+	trans := transform.New(transform.WithMaxDepth(3))
+	require.NoError(t, trans.TransformMapAny(doc(), rules))
+
+	err := transform.New(transform.WithMaxDepth(2)).TransformMapAny(doc(), rules)
+	require.ErrorIs(t, err, transform.ErrMaxDepthExceeded)
+	require.ErrorContains(t, err, ": a.b[0]")
+}
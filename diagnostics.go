@@ -0,0 +1,134 @@
+package transform
+
+import "reflect"
+
+// Unreached is a tagged field that was not transformed
+type Unreached struct {
+	// Path is the Go path of the field (e.g. Address.City)
+	Path string
+	// Pointer is the JSON pointer of the field using the names of the json tags
+	Pointer string
+	// Reason is the reason the field was not reached (e.g. nil pointer)
+	Reason string
+}
+
+// WithDiagnostics reports the tagged fields that were not reached by TransformWithReport,
+// because they are unexported, of an unsupported kind or behind a nil pointer.
+// The fields behind a nil pointer are taken from the type of the pointer.
+func WithDiagnostics() TransformerOpt {
+	return func(o *TransformerImpl) {
+		o.diagnostics = true
+	}
+}
+
+// diagnose records the tagged fields at the location that are not transformed
+func (t *TransformerImpl) diagnose(st *state, loc location, ft reflect.StructField, v reflect.Value, tag string) {
+	if !ft.IsExported() && !t.promoted(ft) {
+		if tag != "" {
+			st.unreach(loc, "unexported field")
+		}
+
+		return
+	}
+
+	typ, isNil := ft.Type, false
+	if typ.Kind() == reflect.Ptr {
+		typ, isNil = typ.Elem(), v.IsNil()
+	}
+
+	// nolint:exhaustive
+	switch typ.Kind() {
+	case reflect.String:
+		if tag != "" && isNil && !hasDefault(funcsOf(tag)) {
+			st.unreach(loc, "nil pointer")
+		}
+	case reflect.Struct:
+		if isNil && !t.initNilPointers {
+			t.unreachType(st, loc, typ, map[reflect.Type]bool{})
+		}
+	}
+
+	if k, ok := t.unsupportedKind(ft.Type, tag); ok {
+		st.unreach(loc, "unsupported kind "+k.String())
+	}
+}
+
+// unsupportedKind returns the kind of the field type if the tag is set but the field is not transformed,
+// as it is neither a string, struct, interface or enum, nor a slice, array or map handled by dive
+func (t *TransformerImpl) unsupportedKind(typ reflect.Type, tag string) (reflect.Kind, bool) {
+	if tag == "" {
+		return reflect.Invalid, false
+	}
+
+	if _, ok := t.enum(typ); ok {
+		return reflect.Invalid, false
+	}
+
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	_, dive := diveFuncs(tag)
+
+	// nolint:exhaustive
+	switch typ.Kind() {
+	case reflect.String, reflect.Struct, reflect.Interface:
+		return reflect.Invalid, false
+	case reflect.Slice, reflect.Array:
+		et := typ.Elem()
+		if et.Kind() == reflect.Ptr {
+			et = et.Elem()
+		}
+
+		if et.Kind() == reflect.Struct || dive && et.Kind() == reflect.String {
+			return reflect.Invalid, false
+		}
+	case reflect.Map:
+		if dive && typ.Key().Kind() == reflect.String {
+			return reflect.Invalid, false
+		}
+	}
+
+	return typ.Kind(), true
+}
+
+// unreachType records the tagged string fields of the struct type behind a nil pointer
+func (t *TransformerImpl) unreachType(st *state, loc location, typ reflect.Type, visited map[reflect.Type]bool) {
+	if visited[typ] || t.skipType(typ) {
+		return
+	}
+
+	visited[typ] = true
+	defer delete(visited, typ)
+
+	for i := 0; i < typ.NumField(); i++ {
+		ft := typ.Field(i)
+
+		tag := ft.Tag.Get(t.TagName)
+		if tag == "-" || (!ft.IsExported() && !t.promoted(ft)) || t.skipType(ft.Type) {
+			continue
+		}
+
+		tag = t.fieldTag(ft)
+
+		ftyp := ft.Type
+		if ftyp.Kind() == reflect.Ptr {
+			ftyp = ftyp.Elem()
+		}
+
+		// nolint:exhaustive
+		switch ftyp.Kind() {
+		case reflect.String:
+			if tag != "" {
+				st.unreach(t.locate(loc, ft, i), "nil pointer")
+			}
+		case reflect.Struct:
+			t.unreachType(st, t.locate(loc, ft, i), ftyp, visited)
+		}
+	}
+}
+
+// unreach records the field at the location as unreached
+func (st *state) unreach(loc location, reason string) {
+	st.unreached = append(st.unreached, Unreached{Path: loc.path, Pointer: loc.pointer, Reason: reason})
+}
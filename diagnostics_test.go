@@ -0,0 +1,58 @@
+package transform_test
+
+import (
+	"testing"
+
+	"github.com/zeiss/go-transform"
+
+	"github.com/stretchr/testify/require"
+)
+
+type diagnosticsAddress struct {
+	City    string  `json:"city" transform:"trim"`
+	Zip     *string `transform:"trim"`
+	Country string
+	Geo     *diagnosticsAddress
+}
+
+type diagnosticsUser struct {
+	Name     string `transform:"trim"`
+	Home     *diagnosticsAddress
+	Tags     []string            `transform:"lowercase"`
+	internal string              `transform:"trim"`
+	Ignored  *string             `transform:"-"`
+	Address  *diagnosticsAddress `json:"address"`
+	Nick     *string             `json:"nick" transform:"trim"`
+	Age      int                 `transform:"trim"`
+}
+
+func TestWithDiagnostics(t *testing.T) {
+	zip := " 07745 "
+
+	u := &diagnosticsUser{
+		Name: " name ",
+		Home: &diagnosticsAddress{City: " Jena ", Zip: &zip},
+	}
+
+	r := transform.New(transform.WithDiagnostics()).TransformWithReport(u)
+	require.True(t, r.Ok())
+	require.Equal(t, "name", u.Name)
+	require.Equal(t, "Jena", u.Home.City)
+
+	require.Equal(t, []transform.Unreached{
+		{Path: "Tags", Pointer: "/Tags", Reason: "unsupported kind slice"},
+		{Path: "internal", Pointer: "/internal", Reason: "unexported field"},
+		{Path: "Address.City", Pointer: "/address/city", Reason: "nil pointer"},
+		{Path: "Address.Zip", Pointer: "/address/Zip", Reason: "nil pointer"},
+		{Path: "Nick", Pointer: "/nick", Reason: "nil pointer"},
+		{Path: "Age", Pointer: "/Age", Reason: "unsupported kind int"},
+		{Path: "Home.Geo.City", Pointer: "/Home/Geo/city", Reason: "nil pointer"},
+		{Path: "Home.Geo.Zip", Pointer: "/Home/Geo/Zip", Reason: "nil pointer"},
+	}, r.Unreached)
+}
+
+func TestWithoutDiagnostics(t *testing.T) {
+	r := transform.New().TransformWithReport(&diagnosticsUser{})
+	require.True(t, r.Ok())
+	require.Empty(t, r.Unreached)
+}
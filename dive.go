@@ -0,0 +1,185 @@
+package transform
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ErrKeyCollision is returned if the transformed keys of a map collide
+var ErrKeyCollision = newError("transformer: transformed map keys collide")
+
+const (
+	// diveTag is the directive applying the following functions to the elements of a slice, array or map
+	// (e.g. transform:"dive,trim,lowercase")
+	diveTag = "dive"
+	// keysTag starts the functions of the keys of a map, following dive (e.g. transform:"dive,keys,lowercase,endkeys,trim")
+	keysTag = "keys"
+	// endKeysTag ends the functions of the keys of a map
+	endKeysTag = "endkeys"
+)
+
+// diveFuncs returns the functions of the elements if the tag starts with dive
+func diveFuncs(tag string) (string, bool) {
+	name, funcs, _ := strings.Cut(tag, ",")
+
+	return funcs, name == diveTag
+}
+
+// transformDive applies the functions following dive to the string elements of a slice or array
+func (t *TransformerImpl) transformDive(st *state, field FieldLevel, v reflect.Value) error {
+	funcs, ok := diveFuncs(field.GetTag())
+	if !ok || funcs == "" || !field.Field().CanSet() || !st.include(field) {
+		return nil
+	}
+
+	fl, ok := field.(fieldLevel)
+	if !ok {
+		return nil
+	}
+
+	return t.eachElement(v, fl.loc, func(i int, loc location) error {
+		el := fl
+		el.val = v.Index(i)
+		el.tag, el.funcs = funcs, nil
+		el.loc = loc
+
+		return t.transformString(st, el)
+	})
+}
+
+// transformMap applies the functions following dive to the string values of a map,
+// the functions between keys and endkeys are applied to the keys.
+// The map is only modified if the functions of all entries succeed.
+func (t *TransformerImpl) transformMap(st *state, field FieldLevel) error {
+	funcs, ok := diveFuncs(field.GetTag())
+	if !ok || !field.Field().CanSet() || !st.include(field) {
+		return nil
+	}
+
+	fl, ok := field.(fieldLevel)
+	if !ok {
+		return nil
+	}
+
+	v := reflect.Indirect(field.Field())
+	if v.Len() == 0 || v.Type().Key().Kind() != reflect.String {
+		return nil
+	}
+
+	if err := t.descend(st, fl.loc); err != nil {
+		return err
+	}
+	defer st.ascend()
+
+	et := v.Type().Elem()
+	if et.Kind() != reflect.String && (et.Kind() != reflect.Ptr || et.Elem().Kind() != reflect.String) {
+		return nil
+	}
+
+	keyFuncs, valueFuncs, err := mapFuncs(funcs)
+	if err != nil {
+		return t.fieldError(field, "", fmt.Errorf("%w: %s", ErrInvalidParam, err))
+	}
+
+	type entry struct {
+		old, key, value reflect.Value
+	}
+
+	keys := v.MapKeys()
+	sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+
+	entries := make([]entry, 0, len(keys))
+
+	// the entries are transformed on copies, as the keys and values of a map are not addressable
+	for _, k := range keys {
+		e := entry{old: k, key: k, value: v.MapIndex(k)}
+
+		el := fl
+		el.loc = fl.loc.key(k.String())
+
+		renamed := -1 // the index of the change renaming the key
+
+		if keyFuncs != "" {
+			el.val, el.tag, el.funcs = reflect.New(k.Type()).Elem(), keyFuncs, nil
+			el.val.Set(k)
+
+			n := len(st.changes)
+
+			if err := t.transformString(st, el); err != nil {
+				return err
+			}
+
+			e.key = el.val
+
+			if len(st.changes) > n {
+				from := el.loc
+				renamed = n
+				st.changes[n].from = &from
+				st.changes[n].loc = fl.loc.key(e.key.String())
+			}
+		}
+
+		if valueFuncs != "" && (e.value.Kind() != reflect.Ptr || !e.value.IsNil()) {
+			el.val, el.tag, el.funcs = reflect.New(et).Elem(), valueFuncs, nil
+			el.val.Set(e.value)
+
+			n := len(st.changes)
+
+			if err := t.transformString(st, el); err != nil {
+				return err
+			}
+
+			e.value = el.val
+
+			if len(st.changes) > n && renamed >= 0 {
+				st.changes[n].loc = st.changes[renamed].loc // the value moves with its key
+			}
+		}
+
+		if renamed >= 0 {
+			st.changes[renamed].value = fieldString(e.value)
+		}
+
+		entries = append(entries, e)
+	}
+
+	seen := make(map[string]string, len(entries))
+
+	for _, e := range entries {
+		if old, ok := seen[e.key.String()]; ok {
+			return t.fieldError(field, "", fmt.Errorf("%w: %q and %q", ErrKeyCollision, old, e.old.String()))
+		}
+
+		seen[e.key.String()] = e.old.String()
+	}
+
+	for _, e := range entries {
+		if e.key.String() != e.old.String() {
+			v.SetMapIndex(e.old, reflect.Value{})
+		}
+	}
+
+	for _, e := range entries {
+		v.SetMapIndex(e.key, e.value)
+	}
+
+	return nil
+}
+
+// mapFuncs splits the functions following dive into the functions of the keys and the values
+func mapFuncs(funcs string) (string, string, error) {
+	parts := splitTag(funcs)
+	if parts[0] != keysTag {
+		return "", funcs, nil
+	}
+
+	for i, p := range parts {
+		if p == endKeysTag {
+			return strings.Join(parts[1:i], ","), strings.Join(parts[i+1:], ","), nil
+		}
+	}
+
+	return "", "", fmt.Errorf("%s without %s", keysTag, endKeysTag)
+}
@@ -0,0 +1,190 @@
+package transform_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/zeiss/go-transform"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDive(t *testing.T) {
+	a, b := " A ", " B "
+
+	type testStruct struct {
+		Tags     []string  `transform:"dive,trim,lowercase"`
+		Codes    [2]string `transform:"dive,uppercase"`
+		Refs     []*string `transform:"dive,trim"`
+		Ptr      *[]string `transform:"dive,trim"`
+		Plain    []string  `transform:"trim"`
+		Empty    []string  `transform:"dive"`
+		Nil      []string  `transform:"dive,trim"`
+		internal []string  `transform:"dive,trim"`
+		Last     string    `transform:"trim"`
+	}
+
+	ptr := []string{" x "}
+
+	s := &testStruct{
+		Tags:     []string{" Foo ", "BAR"},
+		Codes:    [2]string{"de", "at"},
+		Refs:     []*string{&a, nil, &b},
+		Ptr:      &ptr,
+		Plain:    []string{" plain "},
+		Empty:    []string{" empty "},
+		internal: []string{" internal "},
+		Last:     " last ",
+	}
+
+	err := transform.New().Transform(s)
+	require.NoError(t, err)
+	require.Equal(t, []string{"foo", "bar"}, s.Tags)
+	require.Equal(t, [2]string{"DE", "AT"}, s.Codes)
+	require.Equal(t, "A", *s.Refs[0])
+	require.Nil(t, s.Refs[1])
+	require.Equal(t, "B", *s.Refs[2])
+	require.Equal(t, []string{"x"}, *s.Ptr)
+	require.Equal(t, []string{" plain "}, s.Plain)
+	require.Equal(t, []string{" empty "}, s.Empty)
+	require.Nil(t, s.Nil)
+	require.Equal(t, []string{" internal "}, s.internal)
+	require.Equal(t, "last", s.Last)
+}
+
+func TestDiveReport(t *testing.T) {
+	type testStruct struct {
+		Tags []string `json:"tags" transform:"dive,trim,unit=x"`
+	}
+
+	r := transform.New().TransformWithReport(&testStruct{Tags: []string{" a ", " b "}})
+	require.False(t, r.Ok())
+
+	var ferr *transform.FieldError
+	require.ErrorAs(t, r.Err(), &ferr)
+	require.Equal(t, "Tags[0]", ferr.Path)
+	require.Equal(t, "/tags/0", ferr.Pointer)
+	require.Empty(t, r.Changes)
+
+	r = transform.New().TransformWithReport(&struct {
+		Tags []string `json:"tags" transform:"dive,trim"`
+	}{Tags: []string{" a ", "b"}})
+	require.True(t, r.Ok())
+	require.Equal(t, []transform.Change{{Path: "Tags[0]", Pointer: "/tags/0", Old: " a ", New: "a", Funcs: []string{"trim"}}}, r.Changes)
+}
+
+func TestDiveRules(t *testing.T) {
+	type testStruct struct {
+		Tags []string `json:"tags" transform:"dive,trim,lowercase"`
+	}
+
+	rules, err := transform.New().Rules(testStruct{})
+	require.NoError(t, err)
+	require.Equal(t, []transform.Rule{{Path: "Tags[]", JSONPath: "tags[]", Type: "[]string", Funcs: []string{"trim", "lowercase"}}}, rules)
+}
+
+func TestDiveMap(t *testing.T) {
+	v := " Value "
+
+	type testStruct struct {
+		Labels   map[string]string  `transform:"dive,trim,lowercase"`
+		Refs     map[string]*string `transform:"dive,trim"`
+		Keys     map[string]string  `transform:"dive,keys,trim,uppercase,endkeys,trim"`
+		OnlyKeys map[string]string  `transform:"dive,keys,lowercase,endkeys"`
+		Plain    map[string]string  `transform:"trim"`
+		Ints     map[int]string     `transform:"dive,trim"`
+		Last     string             `transform:"trim"`
+	}
+
+	s := &testStruct{
+		Labels:   map[string]string{"a": " A ", "b": "B"},
+		Refs:     map[string]*string{"a": &v, "nil": nil},
+		Keys:     map[string]string{" env ": " prod ", "TEAM": " x "},
+		OnlyKeys: map[string]string{"App": " App "},
+		Plain:    map[string]string{"a": " a "},
+		Ints:     map[int]string{1: " a "},
+		Last:     " last ",
+	}
+
+	err := transform.New().Transform(s)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"a": "a", "b": "b"}, s.Labels)
+	require.Equal(t, "Value", *s.Refs["a"])
+	require.Equal(t, " Value ", v)
+	require.Nil(t, s.Refs["nil"])
+	require.Equal(t, map[string]string{"ENV": "prod", "TEAM": "x"}, s.Keys)
+	require.Equal(t, map[string]string{"app": " App "}, s.OnlyKeys)
+	require.Equal(t, map[string]string{"a": " a "}, s.Plain)
+	require.Equal(t, map[int]string{1: " a "}, s.Ints)
+	require.Equal(t, "last", s.Last)
+}
+
+func TestDiveMapErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		in   interface{}
+		err  error
+		path string
+		out  interface{}
+	}{
+		{
+			name: "collision",
+			in: &struct {
+				Labels map[string]string `transform:"dive,keys,lowercase,endkeys,trim"`
+			}{Labels: map[string]string{"A": " 1 ", "a": " 2 "}},
+			err:  transform.ErrKeyCollision,
+			path: "Labels",
+			out:  map[string]string{"A": " 1 ", "a": " 2 "},
+		},
+		{
+			name: "endkeys",
+			in: &struct {
+				Labels map[string]string `transform:"dive,keys,lowercase"`
+			}{Labels: map[string]string{"A": " 1 "}},
+			err:  transform.ErrInvalidParam,
+			path: "Labels",
+			out:  map[string]string{"A": " 1 "},
+		},
+		{
+			name: "function",
+			in: &struct {
+				Labels map[string]string `json:"labels" transform:"dive,trim,float=x"`
+			}{Labels: map[string]string{"a": " 1 ", "b": " 2 "}},
+			err:  transform.ErrInvalidParam,
+			path: "Labels[a]",
+			out:  map[string]string{"a": " 1 ", "b": " 2 "},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := transform.New().Transform(tc.in)
+			require.ErrorIs(t, err, tc.err)
+			require.Equal(t, tc.out, reflect.ValueOf(tc.in).Elem().Field(0).Interface())
+
+			var ferr *transform.FieldError
+			require.ErrorAs(t, err, &ferr)
+			require.Equal(t, tc.path, ferr.Path)
+
+			err = transform.New(transform.WithCollectErrors()).Transform(tc.in)
+
+			var errs transform.TransformErrors
+			require.ErrorAs(t, err, &errs)
+			require.Len(t, errs, 1)
+			require.ErrorIs(t, errs[0], tc.err)
+		})
+	}
+}
+
+func TestDiveMapReport(t *testing.T) {
+	type testStruct struct {
+		Labels map[string]string `json:"labels" transform:"dive,keys,lowercase,endkeys,trim"`
+	}
+
+	r := transform.New().TransformWithReport(&testStruct{Labels: map[string]string{"A/B": " x ", "c": "y"}})
+	require.True(t, r.Ok())
+	require.Equal(t, []transform.Change{
+		{Path: "Labels[a/b]", Pointer: "/labels/a~1b", Old: "A/B", New: "a/b", Funcs: []string{"lowercase"}, From: "/labels/A~1B"},
+		{Path: "Labels[a/b]", Pointer: "/labels/a~1b", Old: " x ", New: "x", Funcs: []string{"trim"}},
+	}, r.Changes)
+}
@@ -0,0 +1,21 @@
+// Package transform transforms the string fields of structs according to their struct tags.
+//
+//	type user struct {
+//		Email string `transform:"trim,lowercase"`
+//	}
+//
+//	t := transform.New()
+//	err := t.Transform(&u)
+//
+// # Compatibility
+//
+// The package follows semantic versioning from v1 on. Exported identifiers are not removed or changed
+// in a minor version, replaced identifiers are marked as deprecated and kept until the next major version.
+// FieldLevel is implemented by the package only and may gain methods, the other interfaces
+// (e.g. Transformer, Metrics or Vault) are not extended. The set of built-in functions may grow,
+// WithStrictMode reports tags using functions that are not known to a transformer.
+//
+// Deprecated identifiers:
+//
+//	NewTransformer  use New
+package transform
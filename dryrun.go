@@ -0,0 +1,46 @@
+package transform
+
+// Changes are the changes of a transformation in the order of their transformation
+type Changes []Change
+
+// Changed returns true if the field at the Go path (e.g. Email or Addresses[0].City) would change
+func (c Changes) Changed(path string) bool {
+	for _, ch := range c {
+		if ch.Path == path {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Plan returns the changes a transformation of the struct would make with their old and new values
+// and the functions changing them, without modifying the struct (e.g. to preview a normalization).
+// The transformation runs on a deep copy, functions with side effects (e.g. sequence or tokenize)
+// are still called.
+func (t *TransformerImpl) Plan(s interface{}) (Changes, error) {
+	cp, err := Clone(s)
+	if err != nil {
+		return nil, err
+	}
+
+	ifv, err := structValue(cp)
+	if err != nil || !ifv.IsValid() {
+		return nil, err
+	}
+
+	st := newState()
+	st.track = true
+	st.guard = true
+
+	if err := t.transform(st, ifv); err != nil {
+		return nil, err
+	}
+
+	changes := make(Changes, 0, len(st.changes))
+	for _, c := range st.changes {
+		changes = append(changes, c.public())
+	}
+
+	return changes, nil
+}
@@ -0,0 +1,71 @@
+package transform_test
+
+import (
+	"testing"
+
+	"github.com/zeiss/go-transform"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlan(t *testing.T) {
+	type address struct {
+		City string `json:"city" transform:"trim,uppercase"`
+	}
+
+	type testStruct struct {
+		Email   string   `json:"email" transform:"trim,lowercase,truncate=20"`
+		Name    *string  `json:"name" transform:"trim"`
+		Zip     string   `json:"zip" transform:"trim,generalize_zip=3"`
+		Tags    []string `json:"tags" transform:"dive,uppercase"`
+		Address address  `json:"address"`
+	}
+
+	name := "John"
+	in := &testStruct{
+		Email:   " Foo@Example.com ",
+		Name:    &name,
+		Zip:     "07743",
+		Tags:    []string{"a", "B"},
+		Address: address{City: " jena"},
+	}
+
+	changes, err := transform.New().Plan(in)
+	require.NoError(t, err)
+
+	require.Equal(t, transform.Changes{
+		{Path: "Email", Pointer: "/email", Old: " Foo@Example.com ", New: "foo@example.com", Funcs: []string{"trim", "lowercase"}},
+		{Path: "Zip", Pointer: "/zip", Old: "07743", New: "077", Funcs: []string{"generalize_zip"}},
+		{Path: "Tags[0]", Pointer: "/tags/0", Old: "a", New: "A", Funcs: []string{"uppercase"}},
+		{Path: "Address.City", Pointer: "/address/city", Old: " jena", New: "JENA", Funcs: []string{"trim", "uppercase"}},
+	}, changes)
+
+	require.True(t, changes.Changed("Address.City"))
+	require.False(t, changes.Changed("Name"))
+
+	require.Equal(t, &testStruct{
+		Email:   " Foo@Example.com ",
+		Name:    &name,
+		Zip:     "07743",
+		Tags:    []string{"a", "B"},
+		Address: address{City: " jena"},
+	}, in)
+	require.Equal(t, "John", name)
+}
+
+func TestPlanErrors(t *testing.T) {
+	_, err := transform.New().Plan("no pointer")
+	require.ErrorIs(t, err, transform.ErrNoPointer)
+
+	changes, err := transform.New().Plan((*struct{})(nil))
+	require.NoError(t, err)
+	require.Empty(t, changes)
+
+	in := &struct {
+		Payload string `transform:"trim,canonicaljson"`
+	}{Payload: " {"}
+
+	_, err = transform.New().Plan(in)
+	require.Error(t, err)
+	require.Equal(t, " {", in.Payload)
+}
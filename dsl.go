@@ -0,0 +1,504 @@
+package transform
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var (
+	// ErrSyntax is returned if the source of a program is invalid
+	ErrSyntax = newError("transformer: syntax error")
+	// ErrUnknownField is returned if a program references a field the struct does not have
+	ErrUnknownField = newError("transformer: unknown field")
+)
+
+// Program is a compiled list of conditional transformations.
+// A program is run after the tags of the struct have been applied.
+//
+// The source has one statement per line (or separated by ;),
+// a # outside of a string starts a comment.
+//
+//	# german zip codes are kept at region level
+//	when field("Country") == "DE" then apply("Zip", "trim,generalize_zip=2")
+//	when field("Country") != "" and field("Zip") == "" then apply("City", "uppercase")
+//	apply("Email", "trim,lowercase")
+//
+// Fields are referenced by their Go path (e.g. Address.City), conditions
+// compare the string value of a field and may be combined with and / or,
+// with and binding tighter than or.
+type Program struct {
+	stmts []statement
+}
+
+// statement applies the functions to the field if the condition holds
+type statement struct {
+	cond  condition
+	path  string
+	funcs string
+}
+
+// condition is a boolean expression over the fields of a struct
+type condition interface {
+	eval(v reflect.Value) (bool, error)
+}
+
+// compare compares the value of a field with a string literal
+type compare struct {
+	path  string
+	value string
+	not   bool
+}
+
+func (c compare) eval(v reflect.Value) (bool, error) {
+	f, _, _, err := resolveField(nil, v, c.path)
+	if err != nil {
+		return false, err
+	}
+
+	return (fieldString(f) == c.value) != c.not, nil
+}
+
+// binary combines two conditions with and / or
+type binary struct {
+	and         bool
+	left, right condition
+}
+
+func (b binary) eval(v reflect.Value) (bool, error) {
+	ok, err := b.left.eval(v)
+	if err != nil || ok != b.and {
+		return ok, err // short circuit
+	}
+
+	return b.right.eval(v)
+}
+
+// CompileRules compiles the source of a program
+func CompileRules(src string) (*Program, error) {
+	p := &Program{}
+
+	for i, line := range strings.Split(src, "\n") {
+		for _, s := range splitStatements(line) {
+			s = strings.TrimSpace(s)
+			if s == "" {
+				continue
+			}
+
+			toks, err := tokenize(s)
+			if err != nil {
+				return nil, fmt.Errorf("%w: line %d: %s", ErrSyntax, i+1, err)
+			}
+
+			ps := &parser{toks: toks}
+
+			stmt, err := ps.statement()
+			if err != nil {
+				return nil, fmt.Errorf("%w: line %d: %s", ErrSyntax, i+1, err)
+			}
+
+			p.stmts = append(p.stmts, stmt)
+		}
+	}
+
+	return p, nil
+}
+
+// MustCompileRules is like CompileRules but panics if the source is invalid
+func MustCompileRules(src string) *Program {
+	p, err := CompileRules(src)
+	if err != nil {
+		panic(err)
+	}
+
+	return p
+}
+
+// WithProgram runs the program after the tags of the struct have been applied
+func WithProgram(p *Program) TransformerOpt {
+	return func(o *TransformerImpl) {
+		o.program = p
+	}
+}
+
+// run runs the statements of the program on the struct
+func (p *Program) run(t *TransformerImpl, st *state, v reflect.Value) error {
+	for _, s := range p.stmts {
+		if s.cond != nil {
+			ok, err := s.cond.eval(v)
+			if err != nil {
+				return err
+			}
+
+			if !ok {
+				continue
+			}
+		}
+
+		f, ft, loc, err := resolveField(t, v, s.path)
+		if err != nil {
+			return err
+		}
+
+		if !f.IsValid() {
+			continue // a nil pointer on the path
+		}
+
+		k := f.Kind()
+		if k == reflect.Ptr {
+			if f.IsNil() {
+				continue
+			}
+
+			k = f.Elem().Kind()
+		}
+
+		if k != reflect.String {
+			return fmt.Errorf("%w: %s must be a string", ErrInvalidParam, s.path)
+		}
+
+		// the functions of the statement replace the tag of the field
+		fl := fieldLevel{
+			field:   ft,
+			val:     f,
+			json:    ft.Tag.Get("json") != "",
+			tagName: t.TagName,
+			tag:     s.funcs,
+			loc:     loc,
+			parent:  parentOf(v, s.path),
+		}
+
+		if !st.include(fl) {
+			continue
+		}
+
+		if err := t.transformString(st, fl); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// resolveField returns the field of the struct at the Go path, its location is named by the transformer
+func resolveField(t *TransformerImpl, v reflect.Value, path string) (reflect.Value, reflect.StructField, location, error) {
+	var (
+		ft  reflect.StructField
+		loc location
+	)
+
+	for _, name := range strings.Split(path, ".") {
+		if v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}, ft, loc, nil
+			}
+
+			v = v.Elem()
+		}
+
+		if v.Kind() != reflect.Struct {
+			return reflect.Value{}, ft, loc, fmt.Errorf("%w: %s", ErrUnknownField, path)
+		}
+
+		f, ok := v.Type().FieldByName(name)
+		if !ok || !f.IsExported() {
+			return reflect.Value{}, ft, loc, fmt.Errorf("%w: %s", ErrUnknownField, path)
+		}
+
+		fv, err := v.FieldByIndexErr(f.Index)
+		if err != nil {
+			return reflect.Value{}, ft, loc, nil // a nil embedded pointer on the path
+		}
+
+		ft = f
+		loc = t.locate(loc, f, f.Index[len(f.Index)-1])
+		v = fv
+	}
+
+	return v, ft, loc, nil
+}
+
+// parentOf returns the struct containing the field at the Go path
+func parentOf(v reflect.Value, path string) reflect.Value {
+	i := strings.LastIndex(path, ".")
+	if i < 0 {
+		return v
+	}
+
+	p, _, _, err := resolveField(nil, v, path[:i])
+	if err != nil {
+		return reflect.Value{}
+	}
+
+	return reflect.Indirect(p)
+}
+
+// fieldString returns the value of the field as string, nil values are empty
+func fieldString(v reflect.Value) string {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return ""
+		}
+
+		v = v.Elem()
+	}
+
+	if !v.IsValid() {
+		return ""
+	}
+
+	if v.Kind() == reflect.String {
+		return v.String()
+	}
+
+	return fmt.Sprint(v.Interface())
+}
+
+// splitStatements splits the line at semicolons outside of string literals
+// and strips a trailing comment
+func splitStatements(line string) []string {
+	var stmts []string
+
+	quoted, escaped, start := false, false, 0
+
+	for i, r := range line {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\' && quoted:
+			escaped = true
+		case r == '"':
+			quoted = !quoted
+		case r == ';' && !quoted:
+			stmts = append(stmts, line[start:i])
+			start = i + 1
+		case r == '#' && !quoted:
+			return append(stmts, line[start:i])
+		}
+	}
+
+	return append(stmts, line[start:])
+}
+
+// token is a lexical token of a statement
+type token struct {
+	kind  tokenKind
+	value string
+}
+
+type tokenKind int
+
+const (
+	tokenIdent tokenKind = iota
+	tokenString
+	tokenPunct
+)
+
+// tokenize splits the statement into tokens
+func tokenize(s string) ([]token, error) {
+	var toks []token
+
+	for i := 0; i < len(s); {
+		c := s[i]
+
+		switch {
+		case c == ' ' || c == '\t' || c == '\r':
+			i++
+		case c == '(' || c == ')' || c == ',':
+			toks = append(toks, token{tokenPunct, string(c)})
+			i++
+		case (c == '=' || c == '!') && i+1 < len(s) && s[i+1] == '=':
+			toks = append(toks, token{tokenPunct, s[i : i+2]})
+			i += 2
+		case c == '"':
+			j := i + 1
+			for ; j < len(s) && s[j] != '"'; j++ {
+				if s[j] == '\\' {
+					j++
+				}
+			}
+
+			if j >= len(s) {
+				return nil, errors.New("unterminated string")
+			}
+
+			v, err := strconv.Unquote(s[i : j+1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid string %s", s[i:j+1])
+			}
+
+			toks = append(toks, token{tokenString, v})
+			i = j + 1
+		case unicode.IsLetter(rune(c)):
+			j := i
+			for j < len(s) && (unicode.IsLetter(rune(s[j])) || unicode.IsDigit(rune(s[j])) || s[j] == '_') {
+				j++
+			}
+
+			toks = append(toks, token{tokenIdent, s[i:j]})
+			i = j
+		default:
+			return nil, fmt.Errorf("unexpected %q", c)
+		}
+	}
+
+	return toks, nil
+}
+
+// parser is a recursive descent parser of a statement
+type parser struct {
+	toks []token
+	pos  int
+}
+
+// statement = [ "when" or "then" ] apply
+func (p *parser) statement() (statement, error) {
+	var stmt statement
+
+	if p.accept(tokenIdent, "when") {
+		cond, err := p.or()
+		if err != nil {
+			return stmt, err
+		}
+
+		if err := p.expect(tokenIdent, "then"); err != nil {
+			return stmt, err
+		}
+
+		stmt.cond = cond
+	}
+
+	args, err := p.call("apply", 2)
+	if err != nil {
+		return stmt, err
+	}
+
+	stmt.path, stmt.funcs = args[0], args[1]
+
+	if p.pos < len(p.toks) {
+		return stmt, fmt.Errorf("unexpected %q", p.toks[p.pos].value)
+	}
+
+	return stmt, nil
+}
+
+// or = and { "or" and }
+func (p *parser) or() (condition, error) {
+	left, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.accept(tokenIdent, "or") {
+		right, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+
+		left = binary{and: false, left: left, right: right}
+	}
+
+	return left, nil
+}
+
+// and = compare { "and" compare }
+func (p *parser) and() (condition, error) {
+	left, err := p.compare()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.accept(tokenIdent, "and") {
+		right, err := p.compare()
+		if err != nil {
+			return nil, err
+		}
+
+		left = binary{and: true, left: left, right: right}
+	}
+
+	return left, nil
+}
+
+// compare = field ( "==" | "!=" ) string
+func (p *parser) compare() (condition, error) {
+	args, err := p.call("field", 1)
+	if err != nil {
+		return nil, err
+	}
+
+	c := compare{path: args[0]}
+
+	switch {
+	case p.accept(tokenPunct, "=="):
+	case p.accept(tokenPunct, "!="):
+		c.not = true
+	default:
+		return nil, errors.New("expected == or !=")
+	}
+
+	if p.pos >= len(p.toks) || p.toks[p.pos].kind != tokenString {
+		return nil, errors.New("expected string")
+	}
+
+	c.value = p.toks[p.pos].value
+	p.pos++
+
+	return c, nil
+}
+
+// call parses a call of the named function with n string arguments
+func (p *parser) call(name string, n int) ([]string, error) {
+	if err := p.expect(tokenIdent, name); err != nil {
+		return nil, err
+	}
+
+	if err := p.expect(tokenPunct, "("); err != nil {
+		return nil, err
+	}
+
+	args := make([]string, 0, n)
+
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			if err := p.expect(tokenPunct, ","); err != nil {
+				return nil, err
+			}
+		}
+
+		if p.pos >= len(p.toks) || p.toks[p.pos].kind != tokenString {
+			return nil, fmt.Errorf("expected string argument of %s", name)
+		}
+
+		args = append(args, p.toks[p.pos].value)
+		p.pos++
+	}
+
+	if err := p.expect(tokenPunct, ")"); err != nil {
+		return nil, err
+	}
+
+	return args, nil
+}
+
+// accept consumes the token if it matches
+func (p *parser) accept(kind tokenKind, value string) bool {
+	if p.pos < len(p.toks) && p.toks[p.pos].kind == kind && p.toks[p.pos].value == value {
+		p.pos++
+		return true
+	}
+
+	return false
+}
+
+// expect consumes the token or returns an error
+func (p *parser) expect(kind tokenKind, value string) error {
+	if !p.accept(kind, value) {
+		return fmt.Errorf("expected %s", value)
+	}
+
+	return nil
+}
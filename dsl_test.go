@@ -0,0 +1,137 @@
+package transform_test
+
+import (
+	"testing"
+
+	"github.com/zeiss/go-transform"
+
+	"github.com/stretchr/testify/require"
+)
+
+type dslAddress struct {
+	City string
+	Zip  string `json:"zip"`
+}
+
+type dslContact struct {
+	Country string
+	Email   string `transform:"trim"`
+	Age     int
+	Address *dslAddress
+}
+
+func TestProgram(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		in   *dslContact
+		out  *dslContact
+	}{
+		{
+			name: "unconditional",
+			src:  `apply("Email", "lowercase")`,
+			in:   &dslContact{Email: " Foo@Example.COM "},
+			out:  &dslContact{Email: "foo@example.com"},
+		},
+		{
+			name: "condition holds",
+			src:  `when field("Country") == "DE" then apply("Address.Zip", "trim,generalize_zip=2")`,
+			in:   &dslContact{Country: "DE", Address: &dslAddress{Zip: " 80331"}},
+			out:  &dslContact{Country: "DE", Address: &dslAddress{Zip: "80"}},
+		},
+		{
+			name: "condition fails",
+			src:  `when field("Country") == "DE" then apply("Address.Zip", "generalize_zip=2")`,
+			in:   &dslContact{Country: "US", Address: &dslAddress{Zip: "10001"}},
+			out:  &dslContact{Country: "US", Address: &dslAddress{Zip: "10001"}},
+		},
+		{
+			name: "and binds tighter than or",
+			src:  `when field("Country") == "FR" or field("Country") == "DE" and field("Age") == "42" then apply("Address.City", "uppercase")`,
+			in:   &dslContact{Country: "DE", Age: 42, Address: &dslAddress{City: "Jena"}},
+			out:  &dslContact{Country: "DE", Age: 42, Address: &dslAddress{City: "JENA"}},
+		},
+		{
+			name: "not equal",
+			src:  `when field("Country") != "" then apply("Country", "lowercase")`,
+			in:   &dslContact{Country: "DE"},
+			out:  &dslContact{Country: "de"},
+		},
+		{
+			name: "nil pointer on path",
+			src:  `when field("Address.City") == "" then apply("Address.City", "uppercase")`,
+			in:   &dslContact{Country: "DE"},
+			out:  &dslContact{Country: "DE"},
+		},
+		{
+			name: "statements and comments",
+			src: `# normalize
+apply("Country", "uppercase"); apply("Email", "uppercase") # trailing`,
+			in:  &dslContact{Country: "de", Email: "a@b.de"},
+			out: &dslContact{Country: "DE", Email: "A@B.DE"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := transform.CompileRules(tt.src)
+			require.NoError(t, err)
+
+			trans := transform.New(transform.WithProgram(p))
+
+			err = trans.Transform(tt.in)
+			require.NoError(t, err)
+			require.Equal(t, tt.out, tt.in)
+		})
+	}
+}
+
+type dslInner struct {
+	Name string
+}
+
+type dslOuter struct {
+	*dslInner
+	Country string
+}
+
+func TestProgramNilEmbedded(t *testing.T) {
+	p := transform.MustCompileRules(`when field("Name") != "x" then apply("Name", "uppercase"); apply("Country", "uppercase")`)
+	trans := transform.New(transform.WithProgram(p))
+
+	in := &dslOuter{Country: "de"}
+	require.NoError(t, trans.Transform(in))
+	require.Nil(t, in.dslInner)
+	require.Equal(t, "DE", in.Country)
+
+	in = &dslOuter{dslInner: &dslInner{Name: "jena"}}
+	require.NoError(t, trans.Transform(in))
+	require.Equal(t, "JENA", in.Name)
+}
+
+func TestCompileRulesError(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+	}{
+		{name: "missing then", src: `when field("A") == "B" apply("C", "trim")`},
+		{name: "unterminated string", src: `apply("C, "trim")`},
+		{name: "missing operator", src: `when field("A") "B" then apply("C", "trim")`},
+		{name: "trailing tokens", src: `apply("C", "trim") apply("D", "trim")`},
+		{name: "unknown character", src: `apply("C", "trim") && x`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := transform.CompileRules(tt.src)
+			require.ErrorIs(t, err, transform.ErrSyntax)
+		})
+	}
+}
+
+func TestProgramUnknownField(t *testing.T) {
+	trans := transform.New(transform.WithProgram(transform.MustCompileRules(`apply("Phone", "trim")`)))
+
+	err := trans.Transform(&dslContact{})
+	require.ErrorIs(t, err, transform.ErrUnknownField)
+}
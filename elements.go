@@ -0,0 +1,141 @@
+package transform
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ElementError is the error of a single slice or array element
+type ElementError struct {
+	// Index is the index of the element
+	Index int
+	// Path is the Go path of the element (e.g. Items[3]), it is empty for top-level slices
+	Path string
+	// Err is the error of the element
+	Err error
+}
+
+// Error implements the error interface
+func (e *ElementError) Error() string {
+	if e.Path == "" {
+		return fmt.Sprintf("element %d: %v", e.Index, e.Err)
+	}
+
+	return fmt.Sprintf("%s: %v", e.Path, e.Err)
+}
+
+// Unwrap returns the error of the element
+func (e *ElementError) Unwrap() error {
+	return e.Err
+}
+
+// ElementErrors are the errors of the failed elements of a slice or array, ordered by index
+type ElementErrors []*ElementError
+
+// Error implements the error interface
+func (e ElementErrors) Error() string {
+	s := make([]string, len(e))
+	for i, err := range e {
+		s[i] = err.Error()
+	}
+
+	return strings.Join(s, "; ")
+}
+
+// Unwrap returns the errors of the elements
+func (e ElementErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+
+	return errs
+}
+
+// WithIsolatedElements transforms all elements of a slice or array, even if some of them fail.
+// The failed elements are returned as ElementErrors.
+func WithIsolatedElements() TransformerOpt {
+	return func(o *TransformerImpl) {
+		o.isolateElements = true
+	}
+}
+
+// TransformSlice transforms the structs of a slice or a pointer to a slice or an array,
+// the elements may be structs or pointers to structs.
+func TransformSlice(s interface{}) error {
+	return New().TransformSlice(s)
+}
+
+// TransformSlice transforms the structs of a slice or a pointer to a slice or an array,
+// the elements may be structs or pointers to structs.
+func (t *TransformerImpl) TransformSlice(s interface{}) error {
+	v := reflect.ValueOf(s)
+
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil // bail out of if this nil
+		}
+
+		v = v.Elem()
+	}
+
+	if v.Kind() == reflect.Array && !v.CanAddr() {
+		return ErrNoAddressable
+	}
+
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return &KindError{Kind: v.Kind(), Err: ErrNoStruct}
+	}
+
+	et := v.Type().Elem()
+	if et.Kind() == reflect.Ptr {
+		et = et.Elem()
+	}
+
+	if et.Kind() != reflect.Struct {
+		return &KindError{Kind: et.Kind(), Err: ErrNoStruct}
+	}
+
+	st := newState()
+	st.guard = true
+
+	return t.eachElement(v, location{}, func(i int, _ location) error {
+		return t.transform(st, reflect.Indirect(v.Index(i)))
+	})
+}
+
+// eachElement calls fn for every non-nil element, collecting the errors
+// of the elements if they are isolated
+func (t *TransformerImpl) eachElement(v reflect.Value, loc location, fn func(i int, loc location) error) error {
+	var errs ElementErrors
+
+	for i := 0; i < v.Len(); i++ {
+		if e := v.Index(i); e.Kind() == reflect.Ptr && e.IsNil() {
+			continue
+		}
+
+		el := loc.element(i)
+
+		err := fn(i, el)
+		if err == nil {
+			continue
+		}
+
+		if !t.isolateElements {
+			return err
+		}
+
+		if loc.path == "" {
+			el.path = ""
+		}
+
+		errs = append(errs, &ElementError{Index: i, Path: el.path, Err: err})
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
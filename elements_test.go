@@ -0,0 +1,80 @@
+package transform_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/zeiss/go-transform"
+
+	"github.com/stretchr/testify/require"
+)
+
+type elementDoc struct {
+	Name    string `transform:"trim"`
+	Payload string `transform:"canonicaljson"`
+}
+
+type elementBatch struct {
+	Docs []*elementDoc
+}
+
+func TestTransformSlice(t *testing.T) {
+	in := []elementDoc{
+		{Name: " a ", Payload: `{"b":1,"a":2}`},
+		{Name: " b ", Payload: `{`},
+		{Name: " c ", Payload: `{}`},
+	}
+
+	err := transform.TransformSlice(in)
+	require.Error(t, err)
+	require.Equal(t, " c ", in[2].Name, "fails fast without isolation")
+
+	trans := transform.New(transform.WithIsolatedElements())
+
+	err = trans.TransformSlice(&in)
+	require.Error(t, err)
+
+	var errs transform.ElementErrors
+	require.True(t, errors.As(err, &errs))
+	require.Len(t, errs, 1)
+	require.Equal(t, 1, errs[0].Index)
+	require.Empty(t, errs[0].Path)
+
+	require.Equal(t, []elementDoc{
+		{Name: "a", Payload: `{"a":2,"b":1}`},
+		{Name: "b", Payload: `{`},
+		{Name: "c", Payload: `{}`},
+	}, in)
+}
+
+func TestTransformSliceNested(t *testing.T) {
+	trans := transform.New(transform.WithIsolatedElements())
+
+	in := &elementBatch{Docs: []*elementDoc{
+		{Payload: `[`},
+		nil,
+		{Name: " ok "},
+		{Payload: `]`},
+	}}
+
+	err := trans.Transform(in)
+
+	var errs transform.ElementErrors
+	require.True(t, errors.As(err, &errs))
+	require.Len(t, errs, 2)
+	require.Equal(t, 0, errs[0].Index)
+	require.Equal(t, "Docs[0]", errs[0].Path)
+	require.Equal(t, 3, errs[1].Index)
+	require.Equal(t, "ok", in.Docs[2].Name)
+}
+
+func TestTransformSliceInvalid(t *testing.T) {
+	err := transform.TransformSlice([]string{"a"})
+	require.ErrorIs(t, err, transform.ErrNoStruct)
+
+	err = transform.TransformSlice([1]elementDoc{})
+	require.ErrorIs(t, err, transform.ErrNoAddressable)
+
+	err = transform.TransformSlice((*[]elementDoc)(nil))
+	require.NoError(t, err)
+}
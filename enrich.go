@@ -0,0 +1,68 @@
+package transform
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Enricher looks up a value by a key (e.g. the country of an IP address or the region of a postcode),
+// the data source is supplied by the application
+type Enricher interface {
+	// Enrich returns the value of the key, an empty value keeps the field unchanged
+	Enrich(key string) (string, error)
+}
+
+// EnricherFunc is a function used as Enricher
+type EnricherFunc func(key string) (string, error)
+
+// Enrich calls the function
+func (f EnricherFunc) Enrich(key string) (string, error) {
+	return f(key)
+}
+
+// WithEnricher adds a named enricher for enrich=name
+func WithEnricher(name string, e Enricher) TransformerOpt {
+	return func(o *TransformerImpl) {
+		if o.enrichers == nil {
+			o.enrichers = make(map[string]Enricher)
+		}
+
+		o.enrichers[name] = e
+	}
+}
+
+// enrichFunc sets the value looked up by the named enricher, the key is the value of the field (enrich=geoip)
+// or of another field of the struct (enrich=geoip:IP), which is transformed first
+func (t *TransformerImpl) enrichFunc(fl FieldLevel) error {
+	name, src, hasSrc := strings.Cut(fl.Param(), ":")
+
+	e, ok := t.enrichers[name]
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownKey, name)
+	}
+
+	key := fl.String()
+	if hasSrc {
+		s, err := siblingString(fl, src)
+		if err != nil {
+			return err
+		}
+
+		key = s
+	}
+
+	if key == "" {
+		return nil
+	}
+
+	s, err := e.Enrich(key)
+	if err != nil {
+		return err
+	}
+
+	if s != "" {
+		SetString(fl, s)
+	}
+
+	return nil
+}
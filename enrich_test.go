@@ -0,0 +1,102 @@
+package transform_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/zeiss/go-transform"
+
+	"github.com/stretchr/testify/require"
+)
+
+var errLookup = errors.New("lookup failed")
+
+// geoIP is an enricher returning the country of an IP address
+var geoIP = transform.EnricherFunc(func(ip string) (string, error) {
+	switch ip {
+	case "192.0.2.1":
+		return "DE", nil
+	case "192.0.2.99":
+		return "", errLookup
+	}
+
+	return "", nil
+})
+
+// regions is an enricher returning the region of a postcode
+var regions = transform.EnricherFunc(func(postcode string) (string, error) {
+	if postcode == "07743" {
+		return "Thuringia", nil
+	}
+
+	return "", nil
+})
+
+func TestEnrich(t *testing.T) {
+	type request struct {
+		Country string `transform:"enrich=geoip:IP"`
+		IP      string `transform:"trim"`
+		Region  string `transform:"trim,enrich=regions"`
+	}
+
+	trans := transform.New(transform.WithEnricher("geoip", geoIP), transform.WithEnricher("regions", regions))
+
+	tests := []struct {
+		name string
+		in   request
+		out  request
+		err  error
+	}{
+		{
+			name: "source field",
+			in:   request{IP: " 192.0.2.1 "},
+			out:  request{Country: "DE", IP: "192.0.2.1"},
+		},
+		{
+			name: "own value",
+			in:   request{Region: " 07743 "},
+			out:  request{Region: "Thuringia"},
+		},
+		{
+			name: "unknown key",
+			in:   request{Country: "FR", IP: "192.0.2.2", Region: "99999"},
+			out:  request{Country: "FR", IP: "192.0.2.2", Region: "99999"},
+		},
+		{
+			name: "error",
+			in:   request{IP: "192.0.2.99"},
+			err:  errLookup,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			in := tc.in
+
+			err := trans.Transform(&in)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.out, in)
+		})
+	}
+}
+
+func TestEnrichErrors(t *testing.T) {
+	type unknownEnricher struct {
+		Country string `transform:"enrich=geoip"`
+	}
+
+	type unknownField struct {
+		Country string `transform:"enrich=geoip:Address"`
+	}
+
+	err := transform.New().Transform(&unknownEnricher{Country: "x"})
+	require.ErrorIs(t, err, transform.ErrUnknownKey)
+
+	err = transform.New(transform.WithEnricher("geoip", geoIP)).Transform(&unknownField{})
+	require.ErrorIs(t, err, transform.ErrUnknownField)
+}
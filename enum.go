@@ -0,0 +1,126 @@
+package transform
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+)
+
+// ErrInvalidEnum is returned if the transformed string form of an enum can't be parsed
+var ErrInvalidEnum = newError("transformer: invalid enum value")
+
+var (
+	stringerType        = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// enumCodec converts an enum to its string form and back
+type enumCodec struct {
+	// format returns the string form of the enum
+	format func(v reflect.Value) string
+	// parse sets the enum to the value of the string form
+	parse func(s string, v reflect.Value) error
+}
+
+// WithEnum transforms the fields of the enum type T (e.g. type Color int) through its string form,
+// the functions of the tag are applied to the result of String and the result is parsed back by parse
+// (e.g. ParseColor). Enums whose pointer implements encoding.TextUnmarshaler are detected without this option.
+func WithEnum[T fmt.Stringer](parse func(string) (T, error)) TransformerOpt {
+	return func(o *TransformerImpl) {
+		if o.enums == nil {
+			o.enums = make(map[reflect.Type]enumCodec)
+		}
+
+		o.enums[reflect.TypeOf((*T)(nil)).Elem()] = enumCodec{
+			format: formatEnum,
+			parse: func(s string, v reflect.Value) error {
+				e, err := parse(s)
+				if err != nil {
+					return err
+				}
+
+				v.Set(reflect.ValueOf(e))
+
+				return nil
+			},
+		}
+	}
+}
+
+// enum returns the codec of the enum type or of the enum pointed to, strings and structs are no enums
+func (t *TransformerImpl) enum(typ reflect.Type) (enumCodec, bool) {
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	if c, ok := t.enums[typ]; ok {
+		return c, true
+	}
+
+	// nolint:exhaustive
+	switch typ.Kind() {
+	case reflect.String, reflect.Struct, reflect.Interface, reflect.Slice, reflect.Array, reflect.Map:
+		return enumCodec{}, false
+	}
+
+	if !typ.Implements(stringerType) || !reflect.PointerTo(typ).Implements(textUnmarshalerType) {
+		return enumCodec{}, false
+	}
+
+	return enumCodec{format: formatEnum, parse: unmarshalEnum}, true
+}
+
+// transformEnum applies the functions of the tag to the string form of the enum field
+func (t *TransformerImpl) transformEnum(st *state, field FieldLevel, c enumCodec) error {
+	if !field.Field().CanSet() || !st.include(field) {
+		return nil
+	}
+
+	fl, ok := field.(fieldLevel)
+	if !ok {
+		return nil
+	}
+
+	v := reflect.Indirect(field.Field())
+	if !v.IsValid() {
+		return nil // nil pointers are not transformed
+	}
+
+	s := c.format(v)
+
+	el := fl
+	el.val = reflect.New(reflect.TypeOf(s)).Elem()
+	el.val.SetString(s)
+
+	if err := t.transformString(st, el); err != nil {
+		return err
+	}
+
+	if el.val.String() == s {
+		return nil
+	}
+
+	if err := c.parse(el.val.String(), v); err != nil {
+		return t.fieldError(field, "", fmt.Errorf("%w %q: %w", ErrInvalidEnum, el.val.String(), err))
+	}
+
+	return nil
+}
+
+// formatEnum returns the string form of an enum implementing fmt.Stringer
+func formatEnum(v reflect.Value) string {
+	return v.Interface().(fmt.Stringer).String()
+}
+
+// unmarshalEnum sets an enum implementing encoding.TextUnmarshaler
+func unmarshalEnum(s string, v reflect.Value) error {
+	p := reflect.New(v.Type())
+
+	if err := p.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s)); err != nil {
+		return err
+	}
+
+	v.Set(p.Elem())
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package transform_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/zeiss/go-transform"
+
+	"github.com/stretchr/testify/require"
+)
+
+type color int
+
+const (
+	red color = iota
+	green
+	blue
+)
+
+var colors = []string{"red", "green", "blue"}
+
+func (c color) String() string {
+	return colors[c]
+}
+
+func parseColor(s string) (color, error) {
+	for i, name := range colors {
+		if name == s {
+			return color(i), nil
+		}
+	}
+
+	return 0, fmt.Errorf("unknown color %q", s)
+}
+
+type level int
+
+func (l level) String() string {
+	return [...]string{"debug", "info"}[l]
+}
+
+func (l *level) UnmarshalText(b []byte) error {
+	switch strings.ToLower(string(b)) {
+	case "debug":
+		*l = 0
+	case "info":
+		*l = 1
+	default:
+		return fmt.Errorf("unknown level %q", b)
+	}
+
+	return nil
+}
+
+func TestWithEnum(t *testing.T) {
+	type testStruct struct {
+		Color   color  `transform:"replace=red:blue"`
+		Pointer *color `transform:"replace=green:red"`
+		Nil     *color `transform:"replace=green:red"`
+		Plain   color
+		Name    string `transform:"trim"`
+	}
+
+	trans := transform.New(transform.WithEnum(parseColor))
+
+	g := green
+	s := testStruct{Color: red, Pointer: &g, Plain: red, Name: " John "}
+
+	err := trans.Transform(&s)
+	require.NoError(t, err)
+	require.Equal(t, blue, s.Color)
+	require.Equal(t, red, *s.Pointer)
+	require.Nil(t, s.Nil)
+	require.Equal(t, red, s.Plain)
+	require.Equal(t, "John", s.Name)
+
+	r := trans.TransformWithReport(&testStruct{Color: red})
+	require.True(t, r.Ok())
+	require.Equal(t, []transform.Change{{Path: "Color", Pointer: "/Color", Old: "red", New: "blue", Funcs: []string{"replace"}}}, r.Changes)
+}
+
+func TestWithEnumInvalid(t *testing.T) {
+	type testStruct struct {
+		Color color `json:"color" transform:"uppercase"`
+	}
+
+	err := transform.New(transform.WithEnum(parseColor)).Transform(&testStruct{Color: green})
+	require.ErrorIs(t, err, transform.ErrInvalidEnum)
+
+	var ferr *transform.FieldError
+	require.ErrorAs(t, err, &ferr)
+	require.Equal(t, "Color", ferr.Path)
+	require.Equal(t, "color", ferr.JSON)
+	require.EqualError(t, err, `Color: transformer: invalid enum value "GREEN": unknown color "GREEN"`)
+}
+
+func TestEnumTextUnmarshaler(t *testing.T) {
+	type testStruct struct {
+		Level   level  `transform:"uppercase"`
+		Pointer *level `transform:"replace=debug:info"`
+	}
+
+	debug := level(0)
+	s := testStruct{Level: 1, Pointer: &debug}
+
+	err := transform.New(transform.WithErrorOnUnsupportedKind()).Transform(&s)
+	require.NoError(t, err)
+	require.Equal(t, level(1), s.Level)
+	require.Equal(t, level(1), *s.Pointer)
+
+	err = transform.New().Transform(&struct {
+		Level level `transform:"replace=info:verbose"`
+	}{Level: 1})
+	require.ErrorIs(t, err, transform.ErrInvalidEnum)
+
+	rules, err := transform.New().Rules(testStruct{})
+	require.NoError(t, err)
+	require.Len(t, rules, 2)
+}
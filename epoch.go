@@ -0,0 +1,69 @@
+package transform
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// epochUnits are the durations of the units of epoch timestamps
+var epochUnits = map[string]time.Duration{
+	"s":  time.Second,
+	"ms": time.Millisecond,
+	"us": time.Microsecond,
+	"ns": time.Nanosecond,
+}
+
+// epochRFC3339 is the unit of epoch for RFC 3339 times
+const epochRFC3339 = "rfc3339"
+
+// epochFunc converts a timestamp between epoch seconds, milliseconds, microseconds or nanoseconds
+// and RFC 3339 (epoch=ms:rfc3339, epoch=rfc3339:s or epoch=s:ms). RFC 3339 times are written in UTC
+// with the fraction of the second if it is not zero, conversions to coarser units truncate toward the past.
+func epochFunc(fl FieldLevel) error {
+	from, to, _ := strings.Cut(fl.Param(), ":")
+
+	_, okFrom := epochUnits[from]
+	_, okTo := epochUnits[to]
+
+	if (!okFrom && from != epochRFC3339) || (!okTo && to != epochRFC3339) || from == to {
+		return fmt.Errorf("%w: epoch=%s", ErrInvalidParam, fl.Param())
+	}
+
+	s := strings.TrimSpace(fl.String())
+	if s == "" {
+		return nil
+	}
+
+	var ts time.Time
+
+	if from == epochRFC3339 {
+		d, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			return fmt.Errorf("epoch: invalid time %q", s)
+		}
+
+		ts = d
+	} else {
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return fmt.Errorf("epoch: invalid timestamp %q", s)
+		}
+
+		unit := int64(epochUnits[from])
+		perSecond := int64(time.Second) / unit
+		ts = time.Unix(n/perSecond, n%perSecond*unit)
+	}
+
+	if to == epochRFC3339 {
+		SetString(fl, ts.UTC().Format(time.RFC3339Nano))
+		return nil
+	}
+
+	unit := int64(epochUnits[to])
+	perSecond := int64(time.Second) / unit
+	SetString(fl, strconv.FormatInt(ts.Unix()*perSecond+int64(ts.Nanosecond())/unit, 10))
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package transform_test
+
+import (
+	"testing"
+
+	"github.com/zeiss/go-transform"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEpoch(t *testing.T) {
+	tests := []struct {
+		tag string
+		in  string
+		out string
+	}{
+		{tag: "epoch=s:rfc3339", in: "", out: ""},
+		{tag: "epoch=s:rfc3339", in: "1136214245", out: "2006-01-02T15:04:05Z"},
+		{tag: "epoch=ms:rfc3339", in: "1136214245123", out: "2006-01-02T15:04:05.123Z"},
+		{tag: "epoch=ms:rfc3339", in: " 1136214245000 ", out: "2006-01-02T15:04:05Z"},
+		{tag: "epoch=ms:rfc3339", in: "-1500", out: "1969-12-31T23:59:58.5Z"},
+		{tag: "epoch=us:rfc3339", in: "1136214245000001", out: "2006-01-02T15:04:05.000001Z"},
+		{tag: "epoch=ns:rfc3339", in: "1136214245000000001", out: "2006-01-02T15:04:05.000000001Z"},
+		{tag: "epoch=rfc3339:s", in: "2006-01-02T15:04:05+07:00", out: "1136189045"},
+		{tag: "epoch=rfc3339:ms", in: "2006-01-02T15:04:05.123456Z", out: "1136214245123"},
+		{tag: "epoch=rfc3339:s", in: "1969-12-31T23:59:58.5Z", out: "-2"},
+		{tag: "epoch=s:ms", in: "1136214245", out: "1136214245000"},
+		{tag: "epoch=ms:s", in: "1136214245999", out: "1136214245"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag+"/"+tt.in, func(t *testing.T) {
+			v := taggedStruct(tt.tag, tt.in)
+			require.NoError(t, transform.Transform(v.Interface()))
+			require.Equal(t, tt.out, v.Elem().Field(0).String())
+		})
+	}
+
+	invalid := []struct {
+		tag string
+		in  string
+		err error
+	}{
+		{tag: "epoch=ms", in: "1", err: transform.ErrInvalidParam},
+		{tag: "epoch=min:rfc3339", in: "1", err: transform.ErrInvalidParam},
+		{tag: "epoch=ms:ms", in: "1", err: transform.ErrInvalidParam},
+		{tag: "epoch=ms:rfc3339", in: "1.5", err: transform.ErrTransform},
+		{tag: "epoch=rfc3339:s", in: "2006-01-02", err: transform.ErrTransform},
+	}
+
+	for _, tt := range invalid {
+		v := taggedStruct(tt.tag, tt.in)
+		require.ErrorIs(t, transform.Transform(v.Interface()), tt.err)
+		require.Equal(t, tt.in, v.Elem().Field(0).String())
+	}
+}
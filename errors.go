@@ -0,0 +1,123 @@
+package transform
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ErrTransform is the root of the errors of the transformer,
+// all errors returned by the package match it with errors.Is.
+// The errors of the transform functions are wrapped in a FieldError.
+var ErrTransform error = &transformError{msg: "transformer: transformation failed"}
+
+// transformError is a sentinel error of the transformer
+type transformError struct {
+	msg string
+}
+
+// newError returns a sentinel error matching ErrTransform
+func newError(msg string) error {
+	return &transformError{msg: msg}
+}
+
+// Error implements the error interface
+func (e *transformError) Error() string {
+	return e.msg
+}
+
+// Is reports whether the error is the root of the errors of the transformer
+func (e *transformError) Is(target error) bool {
+	return target == ErrTransform
+}
+
+// FieldError is the error of a transform function, an interface handler or the enum codec of a field
+type FieldError struct {
+	// Path is the Go path of the field (e.g. Address.City)
+	Path string
+	// Pointer is the JSON pointer of the field (e.g. /address/city)
+	Pointer string
+	// JSON is the name of the field in its json tag (e.g. city) or the tag set by WithNameTag, or the field name
+	JSON string
+	// Func is the name of the failed function, it is empty for an interface handler or an invalid enum value
+	Func string
+	// Err is the error of the function
+	Err error
+}
+
+// Error implements the error interface
+func (e *FieldError) Error() string {
+	if e.Path == "" {
+		return fmt.Sprintf("%s: %v", e.Func, e.Err)
+	}
+
+	if e.Func == "" {
+		return fmt.Sprintf("%s: %v", e.Path, e.Err)
+	}
+
+	return fmt.Sprintf("%s: %s: %v", e.Path, e.Func, e.Err)
+}
+
+// Unwrap returns the error of the function
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether the target is ErrTransform
+func (e *FieldError) Is(target error) bool {
+	return target == ErrTransform
+}
+
+// fieldError wraps the error of the function of the field, errors of nested fields are returned as is
+func (t *TransformerImpl) fieldError(fl FieldLevel, name string, err error) error {
+	var ferr *FieldError
+	if errors.As(err, &ferr) {
+		return err
+	}
+
+	return &FieldError{Path: fl.Path(), Pointer: locationOf(fl).pointer, JSON: t.nameOfField(fl), Func: name, Err: err}
+}
+
+// UnknownFuncError is returned if a tag references an unknown function,
+// it matches ErrUnknownFunc.
+type UnknownFuncError struct {
+	// Path is the Go path of the field
+	Path string
+	// Name is the name of the function
+	Name string
+}
+
+// Error implements the error interface
+func (e *UnknownFuncError) Error() string {
+	return fmt.Sprintf("%v: %s: %q", ErrUnknownFunc, e.Path, e.Name)
+}
+
+// Unwrap returns ErrUnknownFunc
+func (e *UnknownFuncError) Unwrap() error {
+	return ErrUnknownFunc
+}
+
+// KindError is returned if a value has a kind the transformer can not handle,
+// it matches the sentinel error describing the expected kind (e.g. ErrNoPointer).
+type KindError struct {
+	// Path is the Go path of the value, it is empty for the value passed to the transformer
+	Path string
+	// Kind is the kind of the value
+	Kind reflect.Kind
+	// Err is the sentinel error of the expected kind
+	Err error
+}
+
+// Error implements the error interface
+func (e *KindError) Error() string {
+	if e.Path == "" {
+		return fmt.Sprintf("%v, got %s", e.Err, e.Kind)
+	}
+
+	return fmt.Sprintf("%s: %v, got %s", e.Path, e.Err, e.Kind)
+}
+
+// Unwrap returns the sentinel error of the expected kind
+func (e *KindError) Unwrap() error {
+	return e.Err
+}
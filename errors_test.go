@@ -0,0 +1,150 @@
+package transform_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/zeiss/go-transform"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorHierarchy(t *testing.T) {
+	errFunc := errors.New("failed")
+
+	trans := transform.New(
+		transform.WithErrorOnUnknownFunc(),
+		transform.WithTransformation("fail", func(fl transform.FieldLevel) error { return errFunc }),
+	)
+
+	type nested struct {
+		City string `json:"city,omitempty" transform:"trim,fail"`
+	}
+
+	tests := []struct {
+		name  string
+		in    interface{}
+		check func(t *testing.T, err error)
+	}{
+		{
+			name: "no pointer",
+			in:   struct{}{},
+			check: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, transform.ErrNoPointer)
+
+				var kerr *transform.KindError
+				require.ErrorAs(t, err, &kerr)
+				require.Equal(t, reflect.Struct, kerr.Kind)
+			},
+		},
+		{
+			name: "no struct",
+			in:   new(string),
+			check: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, transform.ErrNoStruct)
+
+				var kerr *transform.KindError
+				require.ErrorAs(t, err, &kerr)
+				require.Equal(t, reflect.String, kerr.Kind)
+			},
+		},
+		{
+			name: "unknown function",
+			in: &struct {
+				Name string `transform:"trim,unknown"`
+			}{},
+			check: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, transform.ErrUnknownFunc)
+
+				var uerr *transform.UnknownFuncError
+				require.ErrorAs(t, err, &uerr)
+				require.Equal(t, "Name", uerr.Path)
+				require.Equal(t, "unknown", uerr.Name)
+			},
+		},
+		{
+			name: "failed function",
+			in: &struct {
+				Address nested
+			}{},
+			check: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, errFunc)
+
+				var ferr *transform.FieldError
+				require.ErrorAs(t, err, &ferr)
+				require.Equal(t, "Address.City", ferr.Path)
+				require.Equal(t, "/Address/city", ferr.Pointer)
+				require.Equal(t, "city", ferr.JSON)
+				require.Equal(t, "fail", ferr.Func)
+				require.EqualError(t, err, "Address.City: fail: failed")
+			},
+		},
+		{
+			name: "invalid parameter",
+			in: &struct {
+				V string `transform:"float=x"`
+			}{V: "1"},
+			check: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, transform.ErrInvalidParam)
+
+				var ferr *transform.FieldError
+				require.ErrorAs(t, err, &ferr)
+				require.Equal(t, "float", ferr.Func)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := trans.Transform(tc.in)
+			require.ErrorIs(t, err, transform.ErrTransform)
+			tc.check(t, err)
+		})
+	}
+}
+
+func TestFieldErrorInterfaceHandler(t *testing.T) {
+	errHandler := errors.New("unsupported value")
+
+	trans := transform.New()
+	err := trans.RegisterInterfaceHandler(reflect.TypeOf((*fmt.Stringer)(nil)).Elem(), func(fl transform.FieldLevel) error {
+		return errHandler
+	})
+	require.NoError(t, err)
+
+	type testStruct struct {
+		Name  string
+		Value fmt.Stringer
+	}
+
+	err = trans.Transform(&testStruct{Value: time.Second})
+	require.ErrorIs(t, err, errHandler)
+	require.ErrorIs(t, err, transform.ErrTransform)
+
+	var ferr *transform.FieldError
+	require.ErrorAs(t, err, &ferr)
+	require.Equal(t, "Value", ferr.Path)
+	require.Equal(t, "Value", ferr.JSON)
+	require.Empty(t, ferr.Func)
+	require.EqualError(t, err, "Value: unsupported value")
+}
+
+func TestErrorHierarchySentinels(t *testing.T) {
+	for _, err := range []error{
+		transform.ErrNoPointer,
+		transform.ErrInvalidParam,
+		transform.ErrUnknownFunc,
+		transform.ErrDependencyCycle,
+		transform.ErrSyntax,
+		transform.ErrFrozen,
+		transform.ErrDuplicateFunc,
+		transform.ErrUnsupportedKind,
+	} {
+		require.ErrorIs(t, err, transform.ErrTransform, err.Error())
+	}
+
+	require.NotErrorIs(t, transform.ErrNoPointer, transform.ErrNoStruct)
+}
@@ -0,0 +1,3 @@
+// Package examples is a cookbook of runnable examples for the transform package and its presets.
+// It has no API, the examples are in the tests and are shown by go doc.
+package examples
@@ -0,0 +1,141 @@
+package examples_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/zeiss/go-transform"
+	"github.com/zeiss/go-transform/preset"
+	"github.com/zeiss/go-transform/transformproblem"
+)
+
+// Custom functions are added to a transformer by name.
+func Example_customFunc() {
+	type article struct {
+		Slug string `transform:"trim,lowercase,slug"`
+	}
+
+	t := transform.New(transform.WithTransformation("slug", func(fl transform.FieldLevel) error {
+		transform.SetString(fl, strings.Join(strings.Fields(fl.String()), "-"))
+		return nil
+	}))
+
+	a := article{Slug: "  Hello Transform World "}
+
+	if err := t.Transform(&a); err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(a.Slug)
+	// Output: hello-transform-world
+}
+
+// Nested structs, pointers to structs and slices of structs are transformed recursively.
+func Example_nestedStructs() {
+	type address struct {
+		City    string `transform:"squish"`
+		Country string `transform:"trim,uppercase"`
+	}
+
+	type customer struct {
+		Name      string `transform:"squish"`
+		Billing   *address
+		Shipments []address
+	}
+
+	c := customer{
+		Name:      "  Jane   Doe ",
+		Billing:   &address{City: " berlin ", Country: "de"},
+		Shipments: []address{{City: "munich", Country: " de "}, {City: "paris ", Country: "fr"}},
+	}
+
+	if err := transform.New().Transform(&c); err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("%q %+v %+v\n", c.Name, *c.Billing, c.Shipments)
+	// Output: "Jane Doe" {City:berlin Country:DE} [{City:munich Country:DE} {City:paris Country:FR}]
+}
+
+// Functions take a parameter after an equals sign, commas in parameters are escaped by a backslash.
+func Example_parameters() {
+	type order struct {
+		ID     string `transform:"pad_left=0:8"`
+		Amount string `transform:"float=2"`
+		Note   string `transform:"replace=\\,:;,truncate=12"`
+	}
+
+	o := order{ID: "4711", Amount: "12.3456", Note: "fragile, keep upright"}
+
+	if err := transform.New().Transform(&o); err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("%+v\n", o)
+	// Output: {ID:00004711 Amount:12.35 Note:fragile; kee}
+}
+
+// Config structs are normalized after they are loaded, strings without a tag are trimmed.
+func Example_configPreset() {
+	type config struct {
+		Host    string `transform:"hostname"`
+		Timeout string `transform:"duration"`
+		Cache   string `transform:"bytesize"`
+		Debug   string `transform:"bool"`
+		Region  string
+	}
+
+	c := config{Host: "API.Example.com.", Timeout: "90s", Cache: "1MiB", Debug: "yes", Region: " eu-west-1 "}
+
+	if err := preset.Config().Transform(&c); err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("%+v\n", c)
+	// Output: {Host:api.example.com Timeout:1m30s Cache:1048576 Debug:true Region:eu-west-1}
+}
+
+// Request bodies are sanitized with the API preset before they are validated,
+// failed fields are reported to the client as problem details.
+func Example_httpHandler() {
+	type product struct {
+		Name  string `json:"name"`
+		SKU   string `json:"sku" transform:"trim,uppercase"`
+		Price string `json:"price" transform:"float=2"`
+	}
+
+	t := preset.API()
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req product
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if err := t.Transform(&req); err != nil {
+			_ = transformproblem.Write(w, err)
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(req)
+	})
+
+	for _, body := range []string{
+		`{"name": " Desk \t Lamp ", "sku": " dl-42 ", "price": "19.9"}`,
+		`{"name": "Chair", "sku": "ch-7", "price": "ten"}`,
+	} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/products", bytes.NewBufferString(body)))
+
+		fmt.Print(rec.Code, " ", rec.Body.String())
+	}
+	// Output:
+	// 200 {"name":"Desk Lamp","sku":"DL-42","price":"19.90"}
+	// 422 {"type":"about:blank","title":"Unprocessable Entity","status":422,"detail":"the request contains invalid fields","errors":[{"pointer":"#/price","path":"Price","func":"float","detail":"float: invalid number \"ten\""}]}
+}
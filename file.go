@@ -0,0 +1,67 @@
+package transform
+
+import (
+	"mime"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// maxFilenameLength is the maximum length of a file name in bytes
+	maxFilenameLength = 255
+	// defaultMimeType is used for invalid content types
+	defaultMimeType = "application/octet-stream"
+)
+
+func safeFilenameFunc(fl FieldLevel) error {
+	SetString(fl, safeFilename(fl.String()))
+
+	return nil
+}
+
+func mimeTypeFunc(fl FieldLevel) error {
+	SetString(fl, mimeType(fl.String()))
+
+	return nil
+}
+
+// safeFilename strips directories, control and reserved characters from a file name
+func safeFilename(s string) string {
+	if i := strings.LastIndexAny(s, `/\`); i >= 0 {
+		s = s[i+1:]
+	}
+
+	s = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || strings.ContainsRune(`<>:"|?*`, r) || r == utf8.RuneError {
+			return -1
+		}
+
+		return r
+	}, s)
+
+	s = strings.TrimLeft(s, ". ")
+	s = strings.TrimRight(s, ". ")
+
+	for len(s) > maxFilenameLength {
+		_, size := utf8.DecodeLastRuneInString(s)
+		s = s[:len(s)-size]
+	}
+
+	return s
+}
+
+// mimeType returns the canonical form of a content type,
+// invalid content types are replaced by application/octet-stream
+func mimeType(s string) string {
+	if strings.TrimSpace(s) == "" {
+		return ""
+	}
+
+	mt, params, err := mime.ParseMediaType(s)
+	if err != nil || !strings.Contains(mt, "/") {
+		return defaultMimeType
+	}
+
+	return mime.FormatMediaType(mt, params)
+}
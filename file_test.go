@@ -0,0 +1,73 @@
+package transform_test
+
+import (
+	"testing"
+
+	"github.com/zeiss/go-transform"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSafeFilename(t *testing.T) {
+	trans := transform.New()
+
+	type testStruct struct {
+		Name string `transform:"safefilename"`
+	}
+
+	tests := []struct {
+		name string
+		in   string
+		out  string
+	}{
+		{name: "empty", in: "", out: ""},
+		{name: "plain", in: "report.pdf", out: "report.pdf"},
+		{name: "unix path", in: "../../etc/passwd", out: "passwd"},
+		{name: "windows path", in: `C:\Users\john\report.pdf`, out: "report.pdf"},
+		{name: "hidden", in: ".htaccess", out: "htaccess"},
+		{name: "reserved", in: "re<po>rt?.pdf", out: "report.pdf"},
+		{name: "control", in: "report\x00\n.pdf", out: "report.pdf"},
+		{name: "trailing dots", in: "report.pdf. . ", out: "report.pdf"},
+		{name: "dots only", in: "..", out: ""},
+		{name: "unicode", in: "Größe.pdf", out: "Größe.pdf"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := &testStruct{Name: tt.in}
+			err := trans.Transform(in)
+			require.NoError(t, err)
+			require.Equal(t, tt.out, in.Name)
+		})
+	}
+}
+
+func TestMimeType(t *testing.T) {
+	trans := transform.New()
+
+	type testStruct struct {
+		ContentType string `transform:"mimetype"`
+	}
+
+	tests := []struct {
+		name string
+		in   string
+		out  string
+	}{
+		{name: "empty", in: "", out: ""},
+		{name: "plain", in: "image/png", out: "image/png"},
+		{name: "case", in: "Image/PNG", out: "image/png"},
+		{name: "params", in: "text/html; Charset=UTF-8", out: "text/html; charset=UTF-8"},
+		{name: "invalid", in: "not a mime type", out: "application/octet-stream"},
+		{name: "no subtype", in: "text", out: "application/octet-stream"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := &testStruct{ContentType: tt.in}
+			err := trans.Transform(in)
+			require.NoError(t, err)
+			require.Equal(t, tt.out, in.ContentType)
+		})
+	}
+}
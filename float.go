@@ -0,0 +1,42 @@
+package transform
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// floatFunc parses a number in Go syntax (e.g. 1e-5, 0x1p-2, 1_000.5) and formats it
+// with the precision of the parameter and without exponent (float=3 turns 1e-5 into 0.000)
+func floatFunc(fl FieldLevel) error {
+	prec, err := strconv.Atoi(fl.Param())
+	if err != nil || prec < 0 {
+		return fmt.Errorf("%w: float=%s", ErrInvalidParam, fl.Param())
+	}
+
+	s := strings.TrimSpace(fl.String())
+	if s == "" {
+		return nil
+	}
+
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsInf(v, 0) || math.IsNaN(v) {
+		return fmt.Errorf("float: invalid number %q", s)
+	}
+
+	SetString(fl, formatFixed(v, prec))
+
+	return nil
+}
+
+// formatFixed formats the value with the precision, values rounded to zero have no sign
+func formatFixed(v float64, prec int) string {
+	s := strconv.FormatFloat(v, 'f', prec, 64)
+
+	if strings.HasPrefix(s, "-") && strings.Trim(s, "-0.") == "" {
+		return s[1:]
+	}
+
+	return s
+}
@@ -0,0 +1,57 @@
+package transform_test
+
+import (
+	"testing"
+
+	"github.com/zeiss/go-transform"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFloat(t *testing.T) {
+	type testStruct struct {
+		Value string `transform:"float=3"`
+	}
+
+	tests := []struct {
+		in  string
+		out string
+		err bool
+	}{
+		{in: "", out: ""},
+		{in: "1e-5", out: "0.000"},
+		{in: " 1.234567E2 ", out: "123.457"},
+		{in: "-0.0001", out: "0.000"},
+		{in: "-2.5", out: "-2.500"},
+		{in: "0x1p-2", out: "0.250"},
+		{in: "1_000.5", out: "1000.500"},
+		{in: "12", out: "12.000"},
+		{in: "Inf", err: true},
+		{in: "NaN", err: true},
+		{in: "1,5", err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			in := &testStruct{Value: tt.in}
+
+			err := transform.Transform(in)
+			if tt.err {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.out, in.Value)
+		})
+	}
+}
+
+func TestFloatInvalidParam(t *testing.T) {
+	type testStruct struct {
+		Value string `transform:"float=x"`
+	}
+
+	err := transform.Transform(&testStruct{Value: "1"})
+	require.ErrorIs(t, err, transform.ErrInvalidParam)
+}
@@ -0,0 +1,50 @@
+package transform
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"text/template"
+)
+
+// templates caches the parsed templates of format by their text
+var templates sync.Map
+
+// formatFunc renders the text/template in the parameter with the struct
+// containing the field (format={{.FirstName}} {{.LastName}})
+func formatFunc(fl FieldLevel) error {
+	tmpl, err := parseTemplate(fl.Param())
+	if err != nil {
+		return err
+	}
+
+	parent := fl.Parent()
+	if !parent.IsValid() || !parent.CanInterface() {
+		return fmt.Errorf("format: no struct for %s", fl.Path())
+	}
+
+	var b strings.Builder
+	if err := tmpl.Execute(&b, parent.Interface()); err != nil {
+		return fmt.Errorf("format: %w", err)
+	}
+
+	SetString(fl, b.String())
+
+	return nil
+}
+
+// parseTemplate returns the parsed template of the text
+func parseTemplate(text string) (*template.Template, error) {
+	if tmpl, ok := templates.Load(text); ok {
+		return tmpl.(*template.Template), nil
+	}
+
+	tmpl, err := template.New("format").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("%w: format=%s: %s", ErrInvalidParam, text, err)
+	}
+
+	templates.Store(text, tmpl)
+
+	return tmpl, nil
+}
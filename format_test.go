@@ -0,0 +1,62 @@
+package transform_test
+
+import (
+	"testing"
+
+	"github.com/zeiss/go-transform"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormat(t *testing.T) {
+	type person struct {
+		FullName  string `transform:"format={{.FirstName}} {{.LastName}}"`
+		Initials  string `transform:"format={{slice .FirstName 0 1}}{{slice .LastName 0 1}},uppercase"`
+		FirstName string `transform:"trim"`
+		LastName  string `transform:"trim"`
+	}
+
+	type testStruct struct {
+		Owner  person
+		People []person
+	}
+
+	in := &testStruct{
+		Owner:  person{FirstName: " john ", LastName: "doe"},
+		People: []person{{FirstName: "jane", LastName: "roe"}},
+	}
+
+	err := transform.Transform(in)
+	require.NoError(t, err)
+	require.Equal(t, "john doe", in.Owner.FullName, "the referenced fields are transformed first")
+	require.Equal(t, "JD", in.Owner.Initials)
+	require.Equal(t, "jane roe", in.People[0].FullName)
+}
+
+func TestFormatSelf(t *testing.T) {
+	type testStruct struct {
+		Name string `transform:"trim,format=Dr. {{.Name}}"`
+	}
+
+	in := &testStruct{Name: " Who "}
+
+	err := transform.Transform(in)
+	require.NoError(t, err)
+	require.Equal(t, "Dr. Who", in.Name)
+}
+
+func TestFormatErrors(t *testing.T) {
+	type invalid struct {
+		Name string `transform:"format={{.Name"`
+	}
+
+	err := transform.Transform(&invalid{})
+	require.ErrorIs(t, err, transform.ErrInvalidParam)
+
+	type missing struct {
+		Name string `transform:"format={{.Missing}}"`
+	}
+
+	err = transform.Transform(&missing{})
+	require.Error(t, err)
+}
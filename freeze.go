@@ -0,0 +1,49 @@
+package transform
+
+// ErrFrozen is returned if a frozen transformer is modified
+var ErrFrozen = newError("transformer: transformer is frozen")
+
+// Freeze finishes the setup of the transformer, it resolves all functions
+// including the functions of the default registry registered so far into a
+// single immutable lookup, so calls don't take the lock of the default registry.
+// Modifications of a frozen transformer return ErrFrozen.
+func (t *TransformerImpl) Freeze() *TransformerImpl {
+	if t.frozen {
+		return t
+	}
+
+	funcs := make(map[string]Func)
+
+	if !t.withoutDefaults {
+		registryMu.RLock()
+		for name, fn := range registry {
+			funcs[name] = fn
+		}
+		registryMu.RUnlock()
+	}
+
+	for name, fn := range boundTransformers {
+		funcs[name] = func(fl FieldLevel) error { return fn(t, fl) }
+	}
+
+	for name, fn := range internalTransformers {
+		funcs[name] = fn
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for name, fn := range t.funcs {
+		funcs[name] = fn
+	}
+
+	t.frozenFuncs = funcs
+	t.frozen = true
+
+	return t
+}
+
+// Frozen returns true if the transformer is frozen
+func (t *TransformerImpl) Frozen() bool {
+	return t.frozen
+}
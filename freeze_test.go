@@ -0,0 +1,40 @@
+package transform_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/zeiss/go-transform"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFreeze(t *testing.T) {
+	type testStruct struct {
+		Name    string `transform:"trim,freeze_exclaim"`
+		Payload string `transform:"canonicaljson"`
+		Alias   string `transform:"pseudonym=k"`
+	}
+
+	transform.Register("freeze_exclaim", func(fl transform.FieldLevel) error {
+		transform.SetString(fl, fl.String()+"!")
+		return nil
+	})
+
+	trans := transform.New(transform.WithHMACKey("k", []byte("secret"))).Freeze()
+	require.True(t, trans.Frozen())
+
+	in := &testStruct{Name: " hi ", Payload: `{"b":1,"a":2}`, Alias: "john"}
+
+	err := trans.Transform(in)
+	require.NoError(t, err)
+	require.Equal(t, "hi!", in.Name)
+	require.Equal(t, `{"a":2,"b":1}`, in.Payload)
+	require.NotEqual(t, "john", in.Alias)
+
+	err = trans.SkipType(reflect.TypeOf(""))
+	require.ErrorIs(t, err, transform.ErrFrozen)
+
+	err = trans.RegisterInterfaceHandler(reflect.TypeOf((*error)(nil)).Elem(), func(transform.FieldLevel) error { return nil })
+	require.ErrorIs(t, err, transform.ErrFrozen)
+}
@@ -0,0 +1,83 @@
+package transform
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// dateLayouts are the accepted layouts of generalize_date
+var dateLayouts = []string{time.RFC3339Nano, time.DateTime, time.DateOnly}
+
+// generalizeZipFunc keeps the first n characters of a postal code (generalize_zip=3)
+func generalizeZipFunc(fl FieldLevel) error {
+	n, err := strconv.Atoi(fl.Param())
+	if err != nil || n < 0 {
+		return fmt.Errorf("%w: generalize_zip=%s", ErrInvalidParam, fl.Param())
+	}
+
+	r := []rune(strings.TrimSpace(fl.String()))
+	if len(r) > n {
+		r = r[:n]
+	}
+
+	SetString(fl, string(r))
+
+	return nil
+}
+
+// generalizeAgeFunc replaces an age by its bucket (generalize_age=bucket:10 turns 37 into 30-39)
+func generalizeAgeFunc(fl FieldLevel) error {
+	kind, size, _ := strings.Cut(fl.Param(), ":")
+
+	n, err := strconv.Atoi(size)
+	if kind != "bucket" || err != nil || n <= 0 {
+		return fmt.Errorf("%w: generalize_age=%s", ErrInvalidParam, fl.Param())
+	}
+
+	s := strings.TrimSpace(fl.String())
+	if s == "" {
+		return nil
+	}
+
+	age, err := strconv.Atoi(s)
+	if err != nil || age < 0 {
+		return fmt.Errorf("generalize_age: invalid age %q", s)
+	}
+
+	lower := age / n * n
+	SetString(fl, strconv.Itoa(lower)+"-"+strconv.Itoa(lower+n-1))
+
+	return nil
+}
+
+// generalizeDateFunc truncates a date to the year, month or day (generalize_date=month)
+func generalizeDateFunc(fl FieldLevel) error {
+	var layout string
+
+	switch fl.Param() {
+	case "year":
+		layout = "2006"
+	case "month":
+		layout = "2006-01"
+	case "day":
+		layout = time.DateOnly
+	default:
+		return fmt.Errorf("%w: generalize_date=%s", ErrInvalidParam, fl.Param())
+	}
+
+	s := strings.TrimSpace(fl.String())
+	if s == "" {
+		return nil
+	}
+
+	for _, l := range dateLayouts {
+		if d, err := time.Parse(l, s); err == nil {
+			SetString(fl, d.Format(layout))
+			return nil
+		}
+	}
+
+	return fmt.Errorf("generalize_date: invalid date %q", s)
+}
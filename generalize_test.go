@@ -0,0 +1,91 @@
+package transform_test
+
+import (
+	"testing"
+
+	"github.com/zeiss/go-transform"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeneralize(t *testing.T) {
+	trans := transform.New()
+
+	type testStruct struct {
+		Zip   string `transform:"generalize_zip=3"`
+		Age   string `transform:"generalize_age=bucket:10"`
+		Month string `transform:"generalize_date=month"`
+		Year  string `transform:"generalize_date=year"`
+		Day   string `transform:"generalize_date=day"`
+	}
+
+	tests := []struct {
+		name string
+		in   *testStruct
+		out  *testStruct
+		err  bool
+	}{
+		{
+			name: "empty",
+			in:   &testStruct{},
+			out:  &testStruct{},
+		},
+		{
+			name: "values",
+			in: &testStruct{
+				Zip:   " 07745 ",
+				Age:   "37",
+				Month: "2024-03-15",
+				Year:  "2024-03-15T10:00:00Z",
+				Day:   "2024-03-15 10:00:00",
+			},
+			out: &testStruct{
+				Zip:   "077",
+				Age:   "30-39",
+				Month: "2024-03",
+				Year:  "2024",
+				Day:   "2024-03-15",
+			},
+		},
+		{
+			name: "short zip",
+			in:   &testStruct{Zip: "07", Age: "0"},
+			out:  &testStruct{Zip: "07", Age: "0-9"},
+		},
+		{
+			name: "invalid age",
+			in:   &testStruct{Age: "old"},
+			err:  true,
+		},
+		{
+			name: "invalid date",
+			in:   &testStruct{Month: "15.03.2024"},
+			err:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := trans.Transform(tt.in)
+
+			if tt.err {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.out, tt.in)
+		})
+	}
+}
+
+func TestGeneralizeInvalidParam(t *testing.T) {
+	trans := transform.New()
+
+	type testStruct struct {
+		Age string `transform:"generalize_age=10"`
+	}
+
+	err := trans.Transform(&testStruct{Age: "37"})
+	require.ErrorIs(t, err, transform.ErrInvalidParam)
+}
@@ -0,0 +1,68 @@
+package transform
+
+import (
+	"reflect"
+)
+
+// TransformT transforms the struct v points to with a transformer configured by the options,
+// the compiler ensures v is a pointer. T must be a struct type, otherwise ErrNoStruct is returned.
+func TransformT[T any](v *T, opts ...TransformerOpt) error {
+	return New(opts...).Transform(v)
+}
+
+// TransformValue returns a transformed deep copy of the struct v with a transformer configured by the options,
+// v is not modified. T must be a struct type, otherwise ErrNoStruct is returned.
+func TransformValue[T any](v T, opts ...TransformerOpt) (T, error) {
+	cp, err := New(opts...).TransformCopy(&v)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	return *cp.(*T), nil
+}
+
+// TypedTransformer transforms values of the struct type T, the type is checked once by Typed
+type TypedTransformer[T any] struct {
+	c *CompiledTransformer
+}
+
+// Typed returns a transformer of the struct type T using the configuration and functions of t,
+// it returns a KindError matching ErrNoStruct if T is not a struct type. The fields and functions
+// are resolved once as by Compile, functions added to t afterwards are not used.
+func Typed[T any](t *TransformerImpl) (*TypedTransformer[T], error) {
+	c, err := t.Compile((*T)(nil))
+	if err != nil {
+		return nil, err
+	}
+
+	return &TypedTransformer[T]{c: c}, nil
+}
+
+// Transform transforms the struct v points to
+func (tt *TypedTransformer[T]) Transform(v *T) error {
+	return tt.c.Transform(v)
+}
+
+// Value returns a transformed deep copy of the struct v, v is not modified
+func (tt *TypedTransformer[T]) Value(v T) (T, error) {
+	cp := newCopier().copy(reflect.ValueOf(&v)).Interface().(*T)
+
+	if err := tt.c.Transform(cp); err != nil {
+		var zero T
+		return zero, err
+	}
+
+	return *cp, nil
+}
+
+// TransformAll transforms the structs in order and stops at the first error
+func (tt *TypedTransformer[T]) TransformAll(vs []T) error {
+	for i := range vs {
+		if err := tt.c.Transform(&vs[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package transform_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/zeiss/go-transform"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransformT(t *testing.T) {
+	type testStruct struct {
+		Name string `transform:"trim"`
+		Mod  string `mod:"uppercase"`
+	}
+
+	s := testStruct{Name: " John ", Mod: "doe"}
+	require.NoError(t, transform.TransformT(&s))
+	require.Equal(t, testStruct{Name: "John", Mod: "doe"}, s)
+
+	require.NoError(t, transform.TransformT(&s, transform.WithTagName("mod")))
+	require.Equal(t, "DOE", s.Mod)
+
+	require.NoError(t, transform.TransformT((*testStruct)(nil)))
+
+	str := "x"
+	err := transform.TransformT(&str)
+	require.ErrorIs(t, err, transform.ErrNoStruct)
+}
+
+func TestTyped(t *testing.T) {
+	type testStruct struct {
+		Name string `transform:"trim,lowercase"`
+	}
+
+	tt, err := transform.Typed[testStruct](transform.New())
+	require.NoError(t, err)
+
+	s := testStruct{Name: " JOHN "}
+	require.NoError(t, tt.Transform(&s))
+	require.Equal(t, "john", s.Name)
+
+	all := []testStruct{{Name: " A "}, {Name: " B "}}
+	require.NoError(t, tt.TransformAll(all))
+	require.Equal(t, []testStruct{{Name: "a"}, {Name: "b"}}, all)
+
+	_, err = transform.Typed[string](transform.New())
+	require.ErrorIs(t, err, transform.ErrNoStruct)
+
+	var kerr *transform.KindError
+	require.ErrorAs(t, err, &kerr)
+	require.Equal(t, reflect.String, kerr.Kind)
+}
+
+func TestTransformValue(t *testing.T) {
+	type testStruct struct {
+		Name string   `transform:"trim"`
+		Tags []string `transform:"dive,uppercase"`
+	}
+
+	in := testStruct{Name: " John ", Tags: []string{"a"}}
+
+	out, err := transform.TransformValue(in)
+	require.NoError(t, err)
+	require.Equal(t, testStruct{Name: "John", Tags: []string{"A"}}, out)
+	require.Equal(t, testStruct{Name: " John ", Tags: []string{"a"}}, in)
+
+	_, err = transform.TransformValue("x")
+	require.ErrorIs(t, err, transform.ErrNoStruct)
+
+	tt, err := transform.Typed[testStruct](transform.New())
+	require.NoError(t, err)
+
+	out, err = tt.Value(in)
+	require.NoError(t, err)
+	require.Equal(t, testStruct{Name: "John", Tags: []string{"A"}}, out)
+	require.Equal(t, []string{"a"}, in.Tags)
+}
@@ -0,0 +1,51 @@
+package transform
+
+import "reflect"
+
+// Marker stamps a struct as transformed for WithIdempotencyGuard, it is embedded into the struct:
+//
+//	type Request struct {
+//		transform.Marker
+//		Email string `transform:"trim,lowercase,hashof=Email"`
+//	}
+//
+// The marker has no exported fields, so it is not encoded.
+type Marker struct {
+	stamped bool
+}
+
+// Stamped returns true if the struct has been transformed
+func (m *Marker) Stamped() bool {
+	return m.stamped
+}
+
+// Reset removes the stamp, so the struct is transformed again (e.g. after it has been modified)
+func (m *Marker) Reset() {
+	m.stamped = false
+}
+
+// markerType is the type of the marker
+var markerType = reflect.TypeOf(Marker{})
+
+// WithIdempotencyGuard stamps the Marker of a struct after it has been transformed and makes
+// the following transformations of the stamped struct no-ops. This prevents double masking or
+// hashing if several layers (e.g. middlewares) transform the same struct.
+// The Marker must be an exported or embedded field of the struct, structs without a Marker are
+// always transformed. MergeInto and TransformMasked transform parts of a struct, they neither
+// check nor stamp the Marker, Reverse resets it.
+func WithIdempotencyGuard() TransformerOpt {
+	return func(o *TransformerImpl) {
+		o.idempotent = true
+	}
+}
+
+// marker returns the marker of the struct, or nil if the struct has none
+func marker(v reflect.Value) *Marker {
+	for i := 0; i < v.NumField(); i++ {
+		if ft := v.Type().Field(i); ft.Type == markerType && ft.IsExported() {
+			return v.Field(i).Addr().Interface().(*Marker)
+		}
+	}
+
+	return nil
+}
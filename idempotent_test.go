@@ -0,0 +1,89 @@
+package transform_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/zeiss/go-transform"
+
+	"github.com/stretchr/testify/require"
+)
+
+type guardedUser struct {
+	transform.Marker
+	Name string `json:"name" transform:"trim,b64enc"`
+}
+
+func TestWithIdempotencyGuard(t *testing.T) {
+	trans := transform.New(transform.WithIdempotencyGuard())
+
+	in := &guardedUser{Name: " john "}
+
+	err := trans.Transform(in)
+	require.NoError(t, err)
+	require.Equal(t, "am9obg==", in.Name)
+	require.True(t, in.Stamped())
+
+	// the following calls are no-ops
+	err = trans.Transform(in)
+	require.NoError(t, err)
+
+	r := trans.TransformWithReport(in)
+	require.True(t, r.Ok())
+	require.Empty(t, r.Changes)
+
+	err = trans.TransformMany(in)
+	require.NoError(t, err)
+
+	err = trans.TransformSlice([]*guardedUser{in})
+	require.NoError(t, err)
+	require.Equal(t, "am9obg==", in.Name)
+
+	// the marker is not encoded
+	b, err := json.Marshal(in)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"name":"am9obg=="}`, string(b))
+
+	// reverse resets the marker
+	err = trans.Reverse(in)
+	require.NoError(t, err)
+	require.Equal(t, "john", in.Name)
+	require.False(t, in.Stamped())
+
+	err = trans.Transform(in)
+	require.NoError(t, err)
+	require.Equal(t, "am9obg==", in.Name)
+
+	// a reset struct is transformed again
+	in.Reset()
+	in.Name = " jane "
+
+	err = trans.Transform(in)
+	require.NoError(t, err)
+	require.Equal(t, "amFuZQ==", in.Name)
+}
+
+func TestWithIdempotencyGuardFailed(t *testing.T) {
+	trans := transform.New(transform.WithIdempotencyGuard())
+
+	in := &struct {
+		transform.Marker
+		Name string `transform:"b64dec"`
+	}{Name: "%"}
+
+	err := trans.Transform(in)
+	require.Error(t, err)
+	require.False(t, in.Stamped())
+}
+
+func TestWithoutIdempotencyGuard(t *testing.T) {
+	in := &guardedUser{Name: "john"}
+
+	err := transform.Transform(in)
+	require.NoError(t, err)
+
+	err = transform.Transform(in)
+	require.NoError(t, err)
+	require.Equal(t, "YW05b2JnPT0=", in.Name)
+	require.False(t, in.Stamped())
+}
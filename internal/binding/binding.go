@@ -0,0 +1,156 @@
+// Package binding decodes string values (e.g. query parameters or form values) into structs.
+package binding
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ErrNoStructPointer is returned when the value is not a pointer to a struct
+var ErrNoStructPointer = errors.New("binding: value must be a pointer to a struct")
+
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// Decode decodes the values into the struct v points to.
+// The key of a field is the name in the first of the given tags that is set,
+// or the field name. Fields tagged with "-" are skipped.
+func Decode(values map[string][]string, v interface{}, tags ...string) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return ErrNoStructPointer
+	}
+
+	return decodeStruct(values, rv.Elem(), tags)
+}
+
+func decodeStruct(values map[string][]string, rv reflect.Value, tags []string) error {
+	rt := rv.Type()
+
+	for i := 0; i < rt.NumField(); i++ {
+		ft := rt.Field(i)
+		fv := rv.Field(i)
+
+		key := FieldKey(ft, tags...)
+		if key == "-" {
+			continue
+		}
+
+		// promoted fields of embedded structs are decoded as if they were declared in the outer struct
+		if ft.Anonymous && ft.Type.Kind() == reflect.Struct && !implementsText(ft.Type) {
+			if err := decodeStruct(values, fv, tags); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if !fv.CanSet() {
+			continue
+		}
+
+		vals, ok := values[key]
+		if !ok || len(vals) == 0 {
+			continue
+		}
+
+		if err := setValue(fv, vals); err != nil {
+			return fmt.Errorf("binding: %s: %w", key, err)
+		}
+	}
+
+	return nil
+}
+
+// FieldKey returns the name of the field in the first of the tags naming it or the field name
+func FieldKey(ft reflect.StructField, tags ...string) string {
+	for _, tag := range tags {
+		name, _, _ := strings.Cut(ft.Tag.Get(tag), ",")
+		if name != "" {
+			return name
+		}
+	}
+
+	return ft.Name
+}
+
+func implementsText(t reflect.Type) bool {
+	return reflect.PointerTo(t).Implements(textUnmarshalerType)
+}
+
+// setValue sets the field from the values, slices take all values and other kinds the first
+func setValue(fv reflect.Value, vals []string) error {
+	if fv.Kind() == reflect.Slice && !implementsText(fv.Type()) {
+		s := reflect.MakeSlice(fv.Type(), len(vals), len(vals))
+		for i, val := range vals {
+			if err := setString(s.Index(i), val); err != nil {
+				return err
+			}
+		}
+
+		fv.Set(s)
+
+		return nil
+	}
+
+	return setString(fv, vals[0])
+}
+
+// nolint:gocyclo
+func setString(fv reflect.Value, val string) error {
+	if fv.Kind() == reflect.Ptr {
+		v := reflect.New(fv.Type().Elem())
+		if err := setString(v.Elem(), val); err != nil {
+			return err
+		}
+
+		fv.Set(v)
+
+		return nil
+	}
+
+	if implementsText(fv.Type()) {
+		return fv.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(val))
+	}
+
+	// nolint:exhaustive
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(val)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(val)
+		if err != nil {
+			return err
+		}
+
+		fv.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(val, 10, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		fv.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(val, 10, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		fv.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(val, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		fv.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported kind %s", fv.Kind())
+	}
+
+	return nil
+}
@@ -0,0 +1,157 @@
+package transform
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+)
+
+// ErrDecrypt is returned if a value can not be decrypted
+var ErrDecrypt = newError("transformer: decryption failed")
+
+// Vault replaces values by tokens and resolves the tokens (e.g. the client of a tokenization service)
+type Vault interface {
+	// Tokenize stores the value and returns its token
+	Tokenize(value string) (string, error)
+	// Detokenize returns the value of the token
+	Detokenize(token string) (string, error)
+}
+
+// WithEncryptionKey adds a named AES key (16, 24 or 32 bytes) for encrypt=name and decrypt=name
+func WithEncryptionKey(name string, key []byte) TransformerOpt {
+	return func(o *TransformerImpl) {
+		if o.encryptionKeys == nil {
+			o.encryptionKeys = make(map[string][]byte)
+		}
+
+		o.encryptionKeys[name] = key
+	}
+}
+
+// WithVault adds a named vault for tokenize=name and detokenize=name
+func WithVault(name string, v Vault) TransformerOpt {
+	return func(o *TransformerImpl) {
+		if o.vaults == nil {
+			o.vaults = make(map[string]Vault)
+		}
+
+		o.vaults[name] = v
+	}
+}
+
+// base64EncodeFunc replaces the value by its standard base64 encoding
+func base64EncodeFunc(fl FieldLevel) error {
+	SetString(fl, base64.StdEncoding.EncodeToString([]byte(fl.String())))
+
+	return nil
+}
+
+// base64DecodeFunc replaces the standard base64 encoded value by the decoded value
+func base64DecodeFunc(fl FieldLevel) error {
+	b, err := base64.StdEncoding.DecodeString(fl.String())
+	if err != nil {
+		return err
+	}
+
+	SetString(fl, string(b))
+
+	return nil
+}
+
+// encryptFunc replaces the value by its base64 encoded AES-GCM ciphertext, keyed by the key named in the parameter
+func (t *TransformerImpl) encryptFunc(fl FieldLevel) error {
+	s := fl.String()
+	if s == "" {
+		return nil
+	}
+
+	aead, err := t.aead(fl.Param())
+	if err != nil {
+		return err
+	}
+
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(s)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+
+	SetString(fl, base64.StdEncoding.EncodeToString(aead.Seal(nonce, nonce, []byte(s), nil)))
+
+	return nil
+}
+
+// decryptFunc replaces the ciphertext of encrypt by the plaintext
+func (t *TransformerImpl) decryptFunc(fl FieldLevel) error {
+	s := fl.String()
+	if s == "" {
+		return nil
+	}
+
+	aead, err := t.aead(fl.Param())
+	if err != nil {
+		return err
+	}
+
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil || len(b) < aead.NonceSize() {
+		return ErrDecrypt
+	}
+
+	plain, err := aead.Open(nil, b[:aead.NonceSize()], b[aead.NonceSize():], nil)
+	if err != nil {
+		return ErrDecrypt
+	}
+
+	SetString(fl, string(plain))
+
+	return nil
+}
+
+// aead returns the AES-GCM cipher of the named key
+func (t *TransformerImpl) aead(name string) (cipher.AEAD, error) {
+	key, ok := t.encryptionKeys[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownKey, name)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// tokenizeFunc replaces the value by the token of the vault named in the parameter
+func (t *TransformerImpl) tokenizeFunc(fl FieldLevel) error {
+	return t.vaultFunc(fl, Vault.Tokenize)
+}
+
+// detokenizeFunc replaces the token by the value of the vault named in the parameter
+func (t *TransformerImpl) detokenizeFunc(fl FieldLevel) error {
+	return t.vaultFunc(fl, Vault.Detokenize)
+}
+
+// vaultFunc replaces the non-empty value by the result of the method of the named vault
+func (t *TransformerImpl) vaultFunc(fl FieldLevel, fn func(v Vault, s string) (string, error)) error {
+	v, ok := t.vaults[fl.Param()]
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownKey, fl.Param())
+	}
+
+	s := fl.String()
+	if s == "" {
+		return nil
+	}
+
+	s, err := fn(v, s)
+	if err != nil {
+		return err
+	}
+
+	SetString(fl, s)
+
+	return nil
+}
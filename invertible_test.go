@@ -0,0 +1,103 @@
+package transform_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/zeiss/go-transform"
+
+	"github.com/stretchr/testify/require"
+)
+
+// mapVault is a vault keeping the tokens in memory
+type mapVault struct {
+	tokens map[string]string
+}
+
+func (v *mapVault) Tokenize(value string) (string, error) {
+	token := "tok_" + strings.ToLower(value)
+	v.tokens[token] = value
+
+	return token, nil
+}
+
+func (v *mapVault) Detokenize(token string) (string, error) {
+	value, ok := v.tokens[token]
+	if !ok {
+		return "", errors.New("unknown token")
+	}
+
+	return value, nil
+}
+
+func TestBase64(t *testing.T) {
+	type testStruct struct {
+		Enc string `transform:"b64enc"`
+		Dec string `transform:"b64dec"`
+	}
+
+	in := &testStruct{Enc: "hello", Dec: "d29ybGQ="}
+
+	err := transform.Transform(in)
+	require.NoError(t, err)
+	require.Equal(t, &testStruct{Enc: "aGVsbG8=", Dec: "world"}, in)
+
+	err = transform.Transform(&testStruct{Dec: "%"})
+	require.Error(t, err)
+}
+
+func TestEncrypt(t *testing.T) {
+	key := []byte("0123456789abcdef")
+
+	type testStruct struct {
+		Secret string `transform:"encrypt=k"`
+		Empty  string `transform:"encrypt=k"`
+	}
+
+	trans := transform.New(transform.WithEncryptionKey("k", key))
+
+	in := &testStruct{Secret: "secret"}
+
+	err := trans.Transform(in)
+	require.NoError(t, err)
+	require.NotEqual(t, "secret", in.Secret)
+	require.Empty(t, in.Empty)
+
+	dec := &struct {
+		Secret string `transform:"decrypt=k"`
+	}{Secret: in.Secret}
+
+	err = trans.Transform(dec)
+	require.NoError(t, err)
+	require.Equal(t, "secret", dec.Secret)
+
+	// a different key can not decrypt the value
+	dec.Secret = in.Secret
+
+	err = transform.New(transform.WithEncryptionKey("k", []byte("fedcba9876543210"))).Transform(dec)
+	require.ErrorIs(t, err, transform.ErrDecrypt)
+
+	err = transform.New().Transform(&testStruct{Secret: "secret"})
+	require.ErrorIs(t, err, transform.ErrUnknownKey)
+}
+
+func TestTokenize(t *testing.T) {
+	v := &mapVault{tokens: map[string]string{}}
+
+	trans := transform.New(transform.WithVault("cards", v))
+
+	type testStruct struct {
+		Card string `transform:"tokenize=cards"`
+	}
+
+	in := &testStruct{Card: "4111"}
+
+	err := trans.Transform(in)
+	require.NoError(t, err)
+	require.Equal(t, "tok_4111", in.Card)
+	require.Equal(t, "4111", v.tokens["tok_4111"])
+
+	err = transform.New().Transform(in)
+	require.ErrorIs(t, err, transform.ErrUnknownKey)
+}
@@ -0,0 +1,78 @@
+package transform
+
+import (
+	"strconv"
+	"strings"
+)
+
+// location is the position of a struct or field within the transformed value
+type location struct {
+	// path is the Go path (e.g. Addresses[0].City)
+	path string
+	// pointer is the JSON pointer using the names of the json tags (e.g. /addresses/0/city)
+	pointer string
+	// index is the index sequence, relative to the nearest slice or array element
+	index []int
+}
+
+// member returns the location of the i-th field of the struct with the name and the name of its json tag
+func (l location) member(name, json string, i int) location {
+	return location{
+		path:    joinPath(l.path, name),
+		pointer: l.pointer + "/" + escapePointer(json),
+		index:   append(append(make([]int, 0, len(l.index)+1), l.index...), i),
+	}
+}
+
+// embed returns the location of the i-th field of the struct, an embedded struct with the name whose
+// fields are promoted, their JSON pointers don't name the embedded struct
+func (l location) embed(name string, i int) location {
+	return location{
+		path:    joinPath(l.path, name),
+		pointer: l.pointer,
+		index:   append(append(make([]int, 0, len(l.index)+1), l.index...), i),
+	}
+}
+
+// element returns the location of the i-th element of the slice or array at the location
+func (l location) element(i int) location {
+	return location{
+		path:    l.path + "[" + strconv.Itoa(i) + "]",
+		pointer: l.pointer + "/" + strconv.Itoa(i),
+	}
+}
+
+// key returns the location of the entry of the map at the location
+func (l location) key(k string) location {
+	return location{
+		path:    l.path + "[" + k + "]",
+		pointer: l.pointer + "/" + escapePointer(k),
+	}
+}
+
+// locationOf returns the location of the field
+func locationOf(fl FieldLevel) location {
+	if f, ok := fl.(fieldLevel); ok {
+		return f.loc
+	}
+
+	return location{path: fl.Path(), index: fl.Index()}
+}
+
+// joinPath joins the path of a struct with the name of a field
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+
+	return path + "." + name
+}
+
+// escapePointer escapes a reference token of a JSON pointer (RFC 6901)
+func escapePointer(s string) string {
+	if !strings.ContainsAny(s, "~/") {
+		return s
+	}
+
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(s)
+}
@@ -0,0 +1,29 @@
+package transform
+
+// TransformMany transforms several independent struct pointers in one call,
+// e.g. the path, query and body of a request. Pointers shared between the values
+// are transformed once. All values are transformed, the failed ones are returned
+// as ElementErrors with the index of the value.
+func (t *TransformerImpl) TransformMany(values ...interface{}) error {
+	st := newState()
+	st.guard = true
+
+	var errs ElementErrors
+
+	for i, s := range values {
+		ifv, err := structValue(s)
+		if err == nil && ifv.IsValid() {
+			err = t.transform(st, ifv)
+		}
+
+		if err != nil {
+			errs = append(errs, &ElementError{Index: i, Err: err})
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package transform_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/zeiss/go-transform"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransformMany(t *testing.T) {
+	type path struct {
+		ID string `transform:"trim,uppercase"`
+	}
+
+	type query struct {
+		Search string `transform:"trim,lowercase"`
+	}
+
+	type body struct {
+		Payload string `transform:"canonicaljson"`
+	}
+
+	trans := transform.New()
+
+	p, q, b := &path{ID: " ab1 "}, &query{Search: " FOO "}, &body{Payload: `{"b":1,"a":2}`}
+
+	err := trans.TransformMany(p, nil, q, b)
+	require.NoError(t, err)
+	require.Equal(t, "AB1", p.ID)
+	require.Equal(t, "foo", q.Search)
+	require.Equal(t, `{"a":2,"b":1}`, b.Payload)
+
+	q = &query{Search: " FOO "}
+
+	err = trans.TransformMany(&body{Payload: "{"}, q, query{})
+
+	var errs transform.ElementErrors
+	require.True(t, errors.As(err, &errs))
+	require.Len(t, errs, 2)
+	require.Equal(t, 0, errs[0].Index)
+	require.Equal(t, 2, errs[1].Index)
+	require.ErrorIs(t, errs[1], transform.ErrNoPointer)
+	require.Equal(t, "foo", q.Search, "the other values are transformed")
+}
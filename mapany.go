@@ -0,0 +1,187 @@
+package transform
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MapRules map the paths of the strings of a decoded JSON document to the functions applied to them
+// (e.g. "user.email": "trim,lowercase"). A path is a dot-separated sequence of keys, * matches any key
+// or element of an array (e.g. "items.*.name") and a leading **. matches at any depth (e.g. "**.email").
+// A string matched by several rules is transformed by the rule with the fewest wildcards.
+type MapRules map[string]string
+
+// mapRule is a compiled rule of MapRules
+type mapRule struct {
+	pattern   string
+	segments  []string
+	anyDepth  bool
+	wildcards int
+	tag       string
+}
+
+// match returns true if the rule matches the keys of a value
+func (r mapRule) match(keys []string) bool {
+	if r.anyDepth && len(keys) >= len(r.segments) {
+		keys = keys[len(keys)-len(r.segments):]
+	}
+
+	if len(keys) != len(r.segments) {
+		return false
+	}
+
+	for i, s := range r.segments {
+		if s != "*" && s != keys[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// compileMapRules returns the rules ordered by their specificity
+func compileMapRules(rules MapRules) []mapRule {
+	compiled := make([]mapRule, 0, len(rules))
+
+	for pattern, tag := range rules {
+		r := mapRule{pattern: pattern, tag: tag}
+
+		path, anyDepth := strings.CutPrefix(pattern, "**.")
+		r.segments, r.anyDepth = strings.Split(path, "."), anyDepth
+
+		for _, s := range r.segments {
+			if s == "*" {
+				r.wildcards++
+			}
+		}
+
+		compiled = append(compiled, r)
+	}
+
+	sort.Slice(compiled, func(i, j int) bool {
+		a, b := compiled[i], compiled[j]
+		if a.anyDepth != b.anyDepth {
+			return !a.anyDepth
+		}
+
+		if a.wildcards != b.wildcards {
+			return a.wildcards < b.wildcards
+		}
+
+		return a.pattern < b.pattern
+	})
+
+	return compiled
+}
+
+// TransformMapAny transforms the strings of a decoded JSON document with a transformer without options
+func TransformMapAny(m map[string]interface{}, rules MapRules) error {
+	return New().TransformMapAny(m, rules)
+}
+
+// TransformMapAny transforms the strings of a decoded JSON document (e.g. by json.Unmarshal into
+// map[string]interface{}) matched by the rules in place, for gateways that can't bind to struct types.
+// The paths of errors join the keys by dots and denote the elements of arrays by [i] (e.g. items[0].name).
+func (t *TransformerImpl) TransformMapAny(m map[string]interface{}, rules MapRules) error {
+	if m == nil || len(rules) == 0 {
+		return nil
+	}
+
+	st := newState()
+
+	if err := t.transformAny(st, m, nil, location{}, compileMapRules(rules)); err != nil {
+		return err
+	}
+
+	if len(st.errs) > 0 {
+		return st.errs
+	}
+
+	return nil
+}
+
+// transformAny transforms the strings of the value with the keys at the location,
+// strings in maps and arrays are replaced by the result of their transformation
+func (t *TransformerImpl) transformAny(st *state, v interface{}, keys []string, loc location, rules []mapRule) error {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		names := make([]string, 0, len(v))
+		for k := range v {
+			names = append(names, k)
+		}
+
+		sort.Strings(names)
+
+		for _, k := range names {
+			kl := location{path: joinPath(loc.path, k), pointer: loc.pointer + "/" + escapePointer(k)}
+
+			s, err := t.transformAnyValue(st, v[k], append(keys, k), kl, rules)
+			if err != nil {
+				return err
+			}
+
+			v[k] = s
+		}
+	case []interface{}:
+		for i := range v {
+			s, err := t.transformAnyValue(st, v[i], append(keys, strconv.Itoa(i)), loc.element(i), rules)
+			if err != nil {
+				return err
+			}
+
+			v[i] = s
+		}
+	}
+
+	return nil
+}
+
+// transformAnyValue returns the transformed string or transforms the nested map or array,
+// errors are collected with WithCollectErrors
+func (t *TransformerImpl) transformAnyValue(st *state, v interface{}, keys []string, loc location, rules []mapRule) (interface{}, error) {
+	switch v.(type) {
+	case map[string]interface{}, []interface{}:
+		if err := t.descend(st, loc); err != nil {
+			return v, err
+		}
+		defer st.ascend()
+
+		return v, t.transformAny(st, v, keys, loc, rules)
+	}
+
+	s, ok := v.(string)
+	if !ok {
+		return v, nil
+	}
+
+	for _, r := range rules {
+		if !r.match(keys) {
+			continue
+		}
+
+		fl := fieldLevel{
+			field:   reflect.StructField{Name: keys[len(keys)-1]},
+			val:     reflect.New(reflect.TypeOf(s)).Elem(),
+			tagName: t.TagName,
+			tag:     r.tag,
+			loc:     loc,
+		}
+		fl.val.SetString(s)
+
+		err := t.transformString(st, fl)
+		if err != nil && t.collectErrors {
+			st.errs = append(st.errs, err)
+			return s, nil
+		}
+
+		if err != nil {
+			return s, err
+		}
+
+		return fl.val.String(), nil
+	}
+
+	return s, nil
+}
@@ -0,0 +1,71 @@
+package transform_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/zeiss/go-transform"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransformMapAny(t *testing.T) {
+	doc := `{
+		"user": {"name": "  Jane  Doe ", "email": " Jane@Example.com ", "age": 42},
+		"items": [{"name": " desk ", "sku": " dl-1 "}, {"name": " lamp "}, "  other "],
+		"contact": {"email": " INFO@Example.com ", "phones": [" 123 "]},
+		"comment": "  keep  "
+	}`
+
+	var m map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(doc), &m))
+
+	err := transform.TransformMapAny(m, transform.MapRules{
+		"user.name":    "squish",
+		"items.*.name": "trim,uppercase",
+		"items.*.sku":  "trim,uppercase",
+		"items.*":      "trim",
+		"**.email":     "trim,lowercase",
+		"contact.*.*":  "trim",
+		"user.email":   "trim",
+	})
+	require.NoError(t, err)
+
+	var want map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"user": {"name": "Jane Doe", "email": "Jane@Example.com", "age": 42},
+		"items": [{"name": "DESK", "sku": "DL-1"}, {"name": "LAMP"}, "other"],
+		"contact": {"email": "info@example.com", "phones": ["123"]},
+		"comment": "  keep  "
+	}`), &want))
+	require.Equal(t, want, m)
+}
+
+func TestTransformMapAnyErrors(t *testing.T) {
+	m := map[string]interface{}{
+		"prices": []interface{}{"1", "ten", "x"},
+		"name":   " John ",
+	}
+
+	rules := transform.MapRules{"prices.*": "float=2", "name": "trim"}
+
+	err := transform.TransformMapAny(m, rules)
+
+	var ferr *transform.FieldError
+	require.ErrorAs(t, err, &ferr)
+	require.Equal(t, "prices[1]", ferr.Path)
+	require.Equal(t, "/prices/1", ferr.Pointer)
+
+	m = map[string]interface{}{
+		"prices": []interface{}{"1", "ten", "x"},
+		"name":   " John ",
+	}
+
+	err = transform.New(transform.WithCollectErrors()).TransformMapAny(m, rules)
+
+	var errs transform.TransformErrors
+	require.ErrorAs(t, err, &errs)
+	require.Len(t, errs, 2)
+	require.Equal(t, []interface{}{"1.00", "ten", "x"}, m["prices"])
+	require.Equal(t, "John", m["name"])
+}
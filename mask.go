@@ -0,0 +1,83 @@
+package transform
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// FieldMask selects fields by their paths, it is implemented by fieldmaskpb.FieldMask
+// so the mask of a gRPC update request can be passed to TransformMasked as is.
+type FieldMask interface {
+	GetPaths() []string
+}
+
+// Paths is a field mask of dot separated paths (e.g. address.city)
+type Paths []string
+
+// GetPaths returns the paths of the mask
+func (p Paths) GetPaths() []string {
+	return p
+}
+
+// TransformMasked transforms the fields selected by the mask only.
+// A path selects a field and all its nested fields, its segments are the names of the json tags
+// (which are the names of the proto fields for generated messages) or the names of the Go fields.
+// Following the update semantics of gRPC, an empty mask or the path * selects all fields.
+// Rules programs only change the selected fields, TransformStruct is only called for selected structs.
+func (t *TransformerImpl) TransformMasked(s interface{}, mask FieldMask) error {
+	ifv, err := structValue(s)
+	if err != nil {
+		return err
+	}
+
+	if !ifv.IsValid() {
+		return nil // bail out of if this nil
+	}
+
+	st := newState()
+
+	var paths []string
+	if mask != nil {
+		paths = mask.GetPaths()
+	}
+
+	if len(paths) > 0 && !slices.Contains(paths, "*") {
+		st.filter = func(fl FieldLevel) bool {
+			path, names := maskPaths(locationOf(fl))
+
+			return masked(paths, path) || masked(paths, names)
+		}
+	}
+
+	return t.transform(st, ifv)
+}
+
+// indexes matches the index of an element or the key of an entry in a Go path
+var indexes = regexp.MustCompile(`\[[^\]]*\]`)
+
+// maskPaths returns the dot separated Go path and path of json names of the location,
+// without the indexes of elements, as the paths of a mask do not select single elements
+func maskPaths(loc location) (string, string) {
+	var names []string
+
+	for _, s := range strings.Split(strings.TrimPrefix(loc.pointer, "/"), "/") {
+		if _, err := strconv.Atoi(s); err != nil {
+			names = append(names, strings.NewReplacer("~1", "/", "~0", "~").Replace(s))
+		}
+	}
+
+	return indexes.ReplaceAllString(loc.path, ""), strings.Join(names, ".")
+}
+
+// masked returns true if the path or one of its parents is selected
+func masked(paths []string, path string) bool {
+	for _, p := range paths {
+		if path == p || strings.HasPrefix(path, p+".") {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,144 @@
+package transform_test
+
+import (
+	"testing"
+
+	"github.com/zeiss/go-transform"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+type maskAddress struct {
+	City string `json:"city" transform:"trim"`
+	Zip  string `json:"zip_code" transform:"trim"`
+}
+
+type maskUser struct {
+	DisplayName string        `json:"display_name" transform:"trim"`
+	Email       string        `json:"email" transform:"trim"`
+	Address     *maskAddress  `json:"address"`
+	Addresses   []maskAddress `json:"addresses"`
+}
+
+func newMaskUser() *maskUser {
+	return &maskUser{
+		DisplayName: " name ",
+		Email:       " email ",
+		Address:     &maskAddress{City: " city ", Zip: " zip "},
+		Addresses:   []maskAddress{{City: " city ", Zip: " zip "}},
+	}
+}
+
+func TestTransformMasked(t *testing.T) {
+	tests := []struct {
+		name string
+		mask transform.FieldMask
+		out  *maskUser
+	}{
+		{
+			name: "json names",
+			mask: transform.Paths{"display_name", "address.zip_code"},
+			out: &maskUser{
+				DisplayName: "name",
+				Email:       " email ",
+				Address:     &maskAddress{City: " city ", Zip: "zip"},
+				Addresses:   []maskAddress{{City: " city ", Zip: " zip "}},
+			},
+		},
+		{
+			name: "go names",
+			mask: transform.Paths{"Email", "Addresses.City"},
+			out: &maskUser{
+				DisplayName: " name ",
+				Email:       "email",
+				Address:     &maskAddress{City: " city ", Zip: " zip "},
+				Addresses:   []maskAddress{{City: "city", Zip: " zip "}},
+			},
+		},
+		{
+			name: "nested",
+			mask: &fieldmaskpb.FieldMask{Paths: []string{"address", "addresses"}},
+			out: &maskUser{
+				DisplayName: " name ",
+				Email:       " email ",
+				Address:     &maskAddress{City: "city", Zip: "zip"},
+				Addresses:   []maskAddress{{City: "city", Zip: "zip"}},
+			},
+		},
+		{
+			name: "wildcard",
+			mask: transform.Paths{"*"},
+			out: &maskUser{
+				DisplayName: "name",
+				Email:       "email",
+				Address:     &maskAddress{City: "city", Zip: "zip"},
+				Addresses:   []maskAddress{{City: "city", Zip: "zip"}},
+			},
+		},
+		{
+			name: "empty",
+			mask: &fieldmaskpb.FieldMask{},
+			out: &maskUser{
+				DisplayName: "name",
+				Email:       "email",
+				Address:     &maskAddress{City: "city", Zip: "zip"},
+				Addresses:   []maskAddress{{City: "city", Zip: "zip"}},
+			},
+		},
+		{
+			name: "unknown",
+			mask: transform.Paths{"unknown"},
+			out:  newMaskUser(),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			in := newMaskUser()
+
+			err := transform.New().TransformMasked(in, tc.mask)
+			require.NoError(t, err)
+			require.Equal(t, tc.out, in)
+		})
+	}
+}
+
+func TestTransformMaskedProgramAndStructLevel(t *testing.T) {
+	trans := transform.New(transform.WithProgram(transform.MustCompileRules(`apply("DisplayName", "uppercase"); apply("Email", "uppercase")`)))
+
+	in := newMaskUser()
+
+	err := trans.TransformMasked(in, transform.Paths{"email"})
+	require.NoError(t, err)
+	require.Equal(t, " name ", in.DisplayName)
+	require.Equal(t, "EMAIL", in.Email)
+
+	// the program only changes the merged fields
+	dst := &maskUser{DisplayName: "name", Email: "email"}
+
+	err = trans.MergeInto(dst, &maskUser{Email: " new "})
+	require.NoError(t, err)
+	require.Equal(t, "name", dst.DisplayName)
+	require.Equal(t, "NEW", dst.Email)
+
+	type featured struct {
+		Featured *article `json:"featured"`
+		Other    *article `json:"other"`
+	}
+
+	f := &featured{Featured: &article{Title: "Hello  World"}, Other: &article{Title: "Other  Post"}}
+
+	err = transform.New().TransformMasked(f, transform.Paths{"featured"})
+	require.NoError(t, err)
+	require.Equal(t, &featured{
+		Featured: &article{Title: "Hello World", Slug: "hello-world"},
+		Other:    &article{Title: "Other  Post"},
+	}, f)
+
+	a := &article{Title: "Hello  World"}
+
+	err = transform.New().TransformMasked(a, transform.Paths{"Title"})
+	require.NoError(t, err)
+	require.Equal(t, &article{Title: "Hello World"}, a)
+}
@@ -0,0 +1,95 @@
+package transform
+
+import (
+	"reflect"
+	"strings"
+)
+
+// MergeInto merges the patch into dst with a transformer without options
+func MergeInto(dst, patch interface{}) error {
+	t := New()
+
+	return t.MergeInto(dst, patch)
+}
+
+// MergeInto copies the non-zero fields of patch into dst and transforms
+// the copied fields only. Nested structs with exported fields are merged field by field,
+// all other values (including pointers and opaque structs like time.Time) are replaced by a deep copy.
+// Rules programs only change the copied fields, TransformStruct is only called for copied structs.
+func (t *TransformerImpl) MergeInto(dst, patch interface{}) error {
+	dv, err := structValue(dst)
+	if err != nil {
+		return err
+	}
+
+	pv, err := structValue(patch)
+	if err != nil {
+		return err
+	}
+
+	if !dv.IsValid() || !pv.IsValid() {
+		return nil // nothing to merge
+	}
+
+	if dv.Type() != pv.Type() {
+		return ErrTypeMismatch
+	}
+
+	touched := []string{}
+	merge(newCopier(), dv, pv, "", &touched)
+
+	st := newState()
+	st.filter = func(fl FieldLevel) bool {
+		return isTouched(touched, fl.Path())
+	}
+
+	return t.transform(st, dv)
+}
+
+// merge copies the non-zero fields and records the paths of the copied fields
+func merge(c *copier, dst, patch reflect.Value, path string, touched *[]string) {
+	for i := 0; i < dst.NumField(); i++ {
+		df := dst.Field(i)
+		if !df.CanSet() {
+			continue
+		}
+
+		pf := patch.Field(i)
+		fp := joinPath(path, dst.Type().Field(i).Name)
+
+		if pf.Kind() == reflect.Struct && hasExportedFields(pf.Type()) {
+			merge(c, df, pf, fp, touched)
+			continue
+		}
+
+		if pf.IsZero() {
+			continue
+		}
+
+		df.Set(c.copy(pf))
+		*touched = append(*touched, fp)
+	}
+}
+
+// hasExportedFields returns true if the struct type has an exported field,
+// the fields of other structs (e.g. time.Time) can not be merged one by one
+func hasExportedFields(typ reflect.Type) bool {
+	for i := 0; i < typ.NumField(); i++ {
+		if typ.Field(i).IsExported() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isTouched returns true if the path or one of its parents has been copied
+func isTouched(touched []string, path string) bool {
+	for _, p := range touched {
+		if path == p || strings.HasPrefix(path, p+".") || strings.HasPrefix(path, p+"[") {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,100 @@
+package transform_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/zeiss/go-transform"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeInto(t *testing.T) {
+	type address struct {
+		City   string `transform:"trim"`
+		Street string `transform:"trim"`
+	}
+
+	type testStruct struct {
+		Name     string `transform:"trim"`
+		Email    string `transform:"trim,lowercase"`
+		Address  address
+		Billing  *address
+		Tags     []string
+		Disabled bool
+		Updated  time.Time
+	}
+
+	updated := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		dst   *testStruct
+		patch *testStruct
+		out   *testStruct
+	}{
+		{
+			name:  "nil",
+			dst:   &testStruct{Name: "  test  "},
+			patch: nil,
+			out:   &testStruct{Name: "  test  "},
+		},
+		{
+			name:  "empty",
+			dst:   &testStruct{Name: "  test  "},
+			patch: &testStruct{},
+			out:   &testStruct{Name: "  test  "},
+		},
+		{
+			name: "patch",
+			dst: &testStruct{
+				Name:    "  test  ",
+				Email:   "  KEEP  ",
+				Address: address{City: "  jena  ", Street: "  main  "},
+			},
+			patch: &testStruct{
+				Name:     "  john  ",
+				Address:  address{City: "  berlin  "},
+				Billing:  &address{City: "  munich  "},
+				Tags:     []string{"a"},
+				Disabled: true,
+			},
+			out: &testStruct{
+				Name:     "john",
+				Email:    "  KEEP  ",
+				Address:  address{City: "berlin", Street: "  main  "},
+				Billing:  &address{City: "munich"},
+				Tags:     []string{"a"},
+				Disabled: true,
+			},
+		},
+		{
+			name:  "time",
+			dst:   &testStruct{Name: "  test  ", Updated: updated.Add(-time.Hour)},
+			patch: &testStruct{Updated: updated},
+			out:   &testStruct{Name: "  test  ", Updated: updated},
+		},
+		{
+			name:  "zero time",
+			dst:   &testStruct{Updated: updated},
+			patch: &testStruct{Name: "  john  "},
+			out:   &testStruct{Name: "john", Updated: updated},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := transform.MergeInto(tt.dst, tt.patch)
+			require.NoError(t, err)
+			require.Equal(t, tt.out, tt.dst)
+		})
+	}
+}
+
+func TestMergeIntoTypeMismatch(t *testing.T) {
+	type first struct{ Name string }
+	type second struct{ Name string }
+
+	err := transform.MergeInto(&first{}, &second{})
+	require.ErrorIs(t, err, transform.ErrTypeMismatch)
+}
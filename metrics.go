@@ -0,0 +1,39 @@
+package transform
+
+import "unicode/utf8"
+
+// metricTag is the name of the tag labeling the metrics of a field
+const metricTag = "metric"
+
+// Metrics receives the metrics of the transformed fields labeled by their metric tag
+// (e.g. `metric:"email_normalization"`), fields without the tag are not reported.
+// Adapters forward them to e.g. Prometheus counters. It must be safe for concurrent use.
+// Metrics implementing LengthMetrics also receive the lengths of the fields.
+type Metrics interface {
+	// Transformed is called for every transformation of a labeled field
+	Transformed(label string, changed bool)
+}
+
+// WithMetrics reports the transformations of labeled fields to the metrics
+func WithMetrics(m Metrics) TransformerOpt {
+	return func(o *TransformerImpl) {
+		o.metrics = m
+	}
+}
+
+// LengthMetrics is implemented by metrics recording the lengths of labeled fields,
+// e.g. as histograms to size database columns and detect truncation risks
+type LengthMetrics interface {
+	// ObserveLength is called for every transformation of a labeled field
+	// with the length in characters before and after the transformation
+	ObserveLength(label string, before, after int)
+}
+
+// observe reports the transformation of a labeled field
+func observe(m Metrics, label, old, v string) {
+	m.Transformed(label, v != old)
+
+	if lm, ok := m.(LengthMetrics); ok {
+		lm.ObserveLength(label, utf8.RuneCountInString(old), utf8.RuneCountInString(v))
+	}
+}
@@ -0,0 +1,87 @@
+package transform_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/zeiss/go-transform"
+
+	"github.com/stretchr/testify/require"
+)
+
+type counters struct {
+	mu          sync.Mutex
+	transformed map[string]int
+	changed     map[string]int
+}
+
+type histograms struct {
+	*counters
+	before, after []int
+}
+
+func (h *histograms) ObserveLength(_ string, before, after int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.before = append(h.before, before)
+	h.after = append(h.after, after)
+}
+
+func newCounters() *counters {
+	return &counters{transformed: map[string]int{}, changed: map[string]int{}}
+}
+
+func (c *counters) Transformed(label string, changed bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.transformed[label]++
+
+	if changed {
+		c.changed[label]++
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	type contact struct {
+		Email string `transform:"trim,lowercase" metric:"email_normalization"`
+		Name  string `transform:"trim"`
+	}
+
+	type testStruct struct {
+		Owner    contact
+		Contacts []contact
+	}
+
+	c := newCounters()
+	trans := transform.New(transform.WithMetrics(c))
+
+	in := &testStruct{
+		Owner:    contact{Email: "a@b.de", Name: " x "},
+		Contacts: []contact{{Email: " A@b.de"}, {Email: "C@b.de"}},
+	}
+
+	err := trans.Transform(in)
+	require.NoError(t, err)
+
+	require.Equal(t, map[string]int{"email_normalization": 3}, c.transformed)
+	require.Equal(t, map[string]int{"email_normalization": 2}, c.changed)
+}
+
+func TestLengthMetrics(t *testing.T) {
+	type testStruct struct {
+		Name string `transform:"trim" metric:"name"`
+		City string `transform:"trim"`
+	}
+
+	h := &histograms{counters: newCounters()}
+	trans := transform.New(transform.WithMetrics(h))
+
+	err := trans.Transform(&testStruct{Name: " Jörg ", City: " Jena "})
+	require.NoError(t, err)
+
+	require.Equal(t, []int{6}, h.before)
+	require.Equal(t, []int{4}, h.after)
+	require.Equal(t, map[string]int{"name": 1}, h.transformed)
+}
@@ -0,0 +1,25 @@
+package transform
+
+// Middleware wraps the calls of transform functions, e.g. for logging, timing or skipping calls.
+// The name is the name of the called function and next calls the function or the next middleware,
+// a middleware skips the function by returning without calling next.
+type Middleware func(name string, next Func) Func
+
+// WithMiddleware wraps the calls of all transform functions with the middleware, the first
+// middleware is the outermost. The calls include the retries, the circuit breaker and the rate limiter
+// of the function. Consecutive built-in string functions (e.g. trim,lowercase) are called one by one
+// instead of sharing a buffer.
+func WithMiddleware(mw ...Middleware) TransformerOpt {
+	return func(o *TransformerImpl) {
+		o.middleware = append(o.middleware, mw...)
+	}
+}
+
+// wrap returns the function wrapped by the middleware of the transformer
+func (t *TransformerImpl) wrap(name string, fn Func) Func {
+	for i := len(t.middleware) - 1; i >= 0; i-- {
+		fn = t.middleware[i](name, fn)
+	}
+
+	return fn
+}
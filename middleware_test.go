@@ -0,0 +1,56 @@
+package transform_test
+
+import (
+	"testing"
+
+	"github.com/zeiss/go-transform"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMiddleware(t *testing.T) {
+	type testStruct struct {
+		Name  string `transform:"trim,lowercase"`
+		Email string `transform:"trim,truncate=5"`
+	}
+
+	calls := []string{}
+
+	trace := func(prefix string) transform.Middleware {
+		return func(name string, next transform.Func) transform.Func {
+			return func(fl transform.FieldLevel) error {
+				calls = append(calls, prefix+fl.Path()+":"+name)
+				return next(fl)
+			}
+		}
+	}
+
+	skip := func(name string, next transform.Func) transform.Func {
+		if name == "truncate" {
+			return transform.PassThrough
+		}
+
+		return next
+	}
+
+	trans := transform.New(transform.WithMiddleware(trace("outer "), trace("inner ")), transform.WithMiddleware(skip))
+
+	in := &testStruct{Name: " John ", Email: " john@example.com "}
+	require.NoError(t, trans.Transform(in))
+	require.Equal(t, &testStruct{Name: "john", Email: "john@example.com"}, in)
+	require.Equal(t, []string{
+		"outer Name:trim", "inner Name:trim",
+		"outer Name:lowercase", "inner Name:lowercase",
+		"outer Email:trim", "inner Email:trim",
+		"outer Email:truncate", "inner Email:truncate",
+	}, calls)
+
+	c, err := trans.Compile(&testStruct{})
+	require.NoError(t, err)
+
+	calls = calls[:0]
+	in = &testStruct{Name: " Jane "}
+	require.NoError(t, c.Transform(in))
+	require.Equal(t, "jane", in.Name)
+	require.Len(t, calls, 8)
+}
@@ -0,0 +1,76 @@
+package transform_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/zeiss/go-transform"
+
+	"github.com/stretchr/testify/require"
+)
+
+// migrationCase is a regression case of the functions replacing a modifier of mold or conform,
+// the output is the output of go-transform, it is not generated by running mold or conform
+type migrationCase struct {
+	mold    string
+	conform string
+	funcs   string
+	in      string
+	out     string
+}
+
+// migrationCases are the functions listed in the migration guide of the README
+var migrationCases = []migrationCase{
+	{mold: "trim", conform: "trim", funcs: "trim", in: " \t a b \n", out: "a b"},
+	{mold: "lcase", conform: "lower", funcs: "lowercase", in: "ÄBC Def", out: "äbc def"},
+	{mold: "ucase", conform: "upper", funcs: "uppercase", in: "äbc Def", out: "ÄBC DEF"},
+	{mold: "ltrim", conform: "ltrim", funcs: "ltrim", in: "  a  ", out: "a  "},
+	{mold: "rtrim", conform: "rtrim", funcs: "rtrim", in: "  a  ", out: "  a"},
+	{mold: "ltrim", conform: "ltrim", funcs: "ltrim", in: "\t a", out: "\t a"},
+	{mold: "rtrim", conform: "rtrim", funcs: "rtrim", in: "a \n", out: "a \n"},
+	{mold: "trim,lcase", conform: "email", funcs: "trim,lowercase", in: " Jane@Example.COM ", out: "jane@example.com"},
+}
+
+// taggedStruct returns a pointer to a struct with a string field tagged with the functions
+func taggedStruct(funcs, in string) reflect.Value {
+	typ := reflect.StructOf([]reflect.StructField{
+		{Name: "Value", Type: reflect.TypeOf(""), Tag: reflect.StructTag(`transform:"` + funcs + `"`)},
+	})
+
+	v := reflect.New(typ)
+	v.Elem().Field(0).SetString(in)
+
+	return v
+}
+
+func TestMigrationFuncs(t *testing.T) {
+	trans := transform.New()
+
+	for _, tc := range migrationCases {
+		t.Run(tc.funcs+"/"+tc.in, func(t *testing.T) {
+			v := taggedStruct(tc.funcs, tc.in)
+			require.NoError(t, trans.Transform(v.Interface()))
+			require.Equal(t, tc.out, v.Elem().Field(0).String())
+		})
+	}
+}
+
+// BenchmarkMigration transforms a struct with a name and an email trimmed and lowercased
+func BenchmarkMigration(b *testing.B) {
+	type user struct {
+		Name  string `transform:"trim,lowercase"`
+		Email string `transform:"trim,lowercase"`
+	}
+
+	trans := transform.New()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		u := user{Name: "  Joey Bloggs  ", Email: " Joey@Example.COM "}
+		if err := trans.Transform(&u); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
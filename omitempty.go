@@ -0,0 +1,25 @@
+package transform
+
+import "reflect"
+
+// omitEmptyTag is the directive skipping the following functions for empty values
+// (e.g. transform:"omitempty,uppercase"), it must be the first function of the tag
+const omitEmptyTag = "omitempty"
+
+// omitEmpty returns the functions following omitempty and true if the functions start with it
+func omitEmpty(funcs []string) ([]string, bool) {
+	if len(funcs) == 0 || funcs[0] != omitEmptyTag {
+		return funcs, false
+	}
+
+	return funcs[1:], true
+}
+
+// isEmpty returns true if the string or the string the field points to is empty
+func isEmpty(fl FieldLevel) bool {
+	if fl.Kind() == reflect.Ptr && fl.Field().IsNil() {
+		return true
+	}
+
+	return fl.String() == ""
+}
@@ -0,0 +1,70 @@
+package transform_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/zeiss/go-transform"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOmitEmpty(t *testing.T) {
+	type inner struct {
+		Name string
+	}
+
+	type testStruct struct {
+		Name     string   `transform:"omitempty,mark"`
+		Nickname *string  `transform:"omitempty,mark"`
+		Code     string   `transform:"mark"`
+		Tags     []string `transform:"dive,omitempty,mark"`
+		Shared   string   `transform:"-"`
+		Inner    inner    `transform:"-"`
+	}
+
+	calls := 0
+	mark := func(fl transform.FieldLevel) error {
+		calls++
+		transform.SetString(fl, fl.String()+"!")
+
+		return nil
+	}
+
+	trans := transform.New(
+		transform.WithTransformation("mark", mark),
+		transform.WithKindDefaults(reflect.String, "mark"),
+	)
+
+	c, err := trans.Compile(&testStruct{})
+	require.NoError(t, err)
+
+	transforms := map[string]func(s interface{}) error{
+		"transform": trans.Transform,
+		"compiled":  c.Transform,
+	}
+
+	for name, fn := range transforms {
+		t.Run(name, func(t *testing.T) {
+			calls = 0
+			empty := ""
+
+			in := &testStruct{Nickname: &empty, Tags: []string{"", "a"}, Shared: "s", Inner: inner{Name: "n"}}
+			require.NoError(t, fn(in))
+			require.Equal(t, &testStruct{Nickname: &empty, Code: "!", Tags: []string{"", "a!"}, Shared: "s", Inner: inner{Name: "n"}}, in)
+			require.Equal(t, 2, calls)
+
+			nick := "nick"
+			in = &testStruct{Name: "name", Nickname: &nick}
+			require.NoError(t, fn(in))
+			require.Equal(t, "name!", in.Name)
+			require.Equal(t, "nick!", *in.Nickname)
+		})
+	}
+
+	err = transform.New(transform.WithStrictMode(), transform.WithTransformation("mark", mark)).Transform(&testStruct{})
+	require.NoError(t, err)
+
+	err = transform.New().RegisterTransformation("omitempty", mark)
+	require.ErrorIs(t, err, transform.ErrInvalidFunc)
+}
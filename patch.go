@@ -0,0 +1,76 @@
+package transform
+
+import "encoding/json"
+
+// PatchOperation is a RFC 6902 JSON Patch operation
+type PatchOperation struct {
+	// Op is the operation, transformations replace values, renamed map keys are removed and added
+	Op string `json:"op"`
+	// Path is the JSON pointer of the changed field
+	Path string `json:"path"`
+	// Value is the new value of the field
+	Value string `json:"value"`
+}
+
+// TransformPatch transforms the struct and returns a JSON Patch describing the changes,
+// so upstream systems can be informed how their payload was normalized.
+// JSON pointers use the names of the json tags.
+func (t *TransformerImpl) TransformPatch(s interface{}) ([]PatchOperation, error) {
+	ifv, err := structValue(s)
+	if err != nil || !ifv.IsValid() {
+		return nil, err
+	}
+
+	st := newState()
+	st.track = true
+	st.guard = true
+
+	if err := t.transform(st, ifv); err != nil {
+		return nil, err
+	}
+
+	return patchOf(st.changes), nil
+}
+
+// patchOf returns the operations of the changes. Renamed map keys are removed first and added with their
+// values afterwards, so keys renamed to each other don't overwrite their values, the values of added keys
+// are not replaced again.
+func patchOf(changes []change) []PatchOperation {
+	patch := make([]PatchOperation, 0, len(changes))
+	added := make(map[string]struct{})
+
+	for _, c := range changes {
+		if c.from != nil {
+			patch = append(patch, PatchOperation{Op: "remove", Path: c.from.pointer})
+		}
+	}
+
+	for _, c := range changes {
+		if c.from != nil {
+			patch = append(patch, PatchOperation{Op: "add", Path: c.loc.pointer, Value: c.value})
+			added[c.loc.pointer] = struct{}{}
+		}
+	}
+
+	for _, c := range changes {
+		if _, ok := added[c.loc.pointer]; !ok && c.from == nil {
+			patch = append(patch, PatchOperation{Op: "replace", Path: c.loc.pointer, Value: c.new})
+		}
+	}
+
+	return patch
+}
+
+// MarshalJSON omits the value of remove operations
+func (op PatchOperation) MarshalJSON() ([]byte, error) {
+	if op.Op == "remove" {
+		return json.Marshal(struct {
+			Op   string `json:"op"`
+			Path string `json:"path"`
+		}{op.Op, op.Path})
+	}
+
+	type operation PatchOperation // without the MarshalJSON method
+
+	return json.Marshal(operation(op))
+}
@@ -0,0 +1,137 @@
+package transform_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/zeiss/go-transform"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransformPatch(t *testing.T) {
+	type address struct {
+		City string `json:"city" transform:"trim"`
+	}
+
+	type testStruct struct {
+		Email     string    `json:"email" transform:"trim,lowercase"`
+		Name      *string   `json:"name" transform:"trim"`
+		Unchanged string    `json:"unchanged" transform:"trim"`
+		Odd       string    `json:"a/b" transform:"trim"`
+		Address   address   `json:"address"`
+		Addresses []address `json:"addresses"`
+	}
+
+	in := &testStruct{
+		Email:     " John@Example.com ",
+		Name:      &[]string{" John "}[0],
+		Unchanged: "test",
+		Odd:       " odd ",
+		Address:   address{City: " Jena "},
+		Addresses: []address{{City: "Berlin"}, {City: " Munich "}},
+	}
+
+	patch, err := transform.New().TransformPatch(in)
+	require.NoError(t, err)
+	require.Equal(t, []transform.PatchOperation{
+		{Op: "replace", Path: "/email", Value: "john@example.com"},
+		{Op: "replace", Path: "/name", Value: "John"},
+		{Op: "replace", Path: "/a~1b", Value: "odd"},
+		{Op: "replace", Path: "/address/city", Value: "Jena"},
+		{Op: "replace", Path: "/addresses/1/city", Value: "Munich"},
+	}, patch)
+	require.Equal(t, "john@example.com", in.Email)
+
+	b, err := json.Marshal(patch[:1])
+	require.NoError(t, err)
+	require.JSONEq(t, `[{"op":"replace","path":"/email","value":"john@example.com"}]`, string(b))
+
+	patch, err = transform.New().TransformPatch(in)
+	require.NoError(t, err)
+	require.Empty(t, patch)
+}
+
+// applyPatch applies the remove, add and replace operations of a patch to a decoded JSON document
+func applyPatch(t *testing.T, doc map[string]interface{}, patch []transform.PatchOperation) {
+	t.Helper()
+
+	for _, op := range patch {
+		keys := strings.Split(op.Path, "/")[1:]
+		for i, k := range keys {
+			keys[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(k)
+		}
+
+		m := doc
+		for _, k := range keys[:len(keys)-1] {
+			m = m[k].(map[string]interface{})
+		}
+
+		last := keys[len(keys)-1]
+
+		switch op.Op {
+		case "remove":
+			require.Contains(t, m, last, op.Path)
+			delete(m, last)
+		case "add":
+			m[last] = op.Value
+		case "replace":
+			require.Contains(t, m, last, op.Path)
+			m[last] = op.Value
+		default:
+			t.Fatalf("unexpected operation %q", op.Op)
+		}
+	}
+}
+
+func TestTransformPatchMapKeys(t *testing.T) {
+	type testStruct struct {
+		Labels map[string]string `json:"labels" transform:"dive,keys,trim,lowercase,endkeys,trim"`
+		Swap   map[string]string `json:"swap" transform:"dive,keys,replace=a:t,replace=b:a,replace=t:b,endkeys"`
+	}
+
+	in := &testStruct{
+		Labels: map[string]string{" Env ": " prod ", "team": " core ", "A/B": "x"},
+		Swap:   map[string]string{"a": "1", "b": "2"},
+	}
+
+	b, err := json.Marshal(in)
+	require.NoError(t, err)
+
+	doc := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(b, &doc))
+
+	patch, err := transform.New().TransformPatch(in)
+	require.NoError(t, err)
+	require.Equal(t, []transform.PatchOperation{
+		{Op: "remove", Path: "/labels/ Env "},
+		{Op: "remove", Path: "/labels/A~1B"},
+		{Op: "remove", Path: "/swap/a"},
+		{Op: "remove", Path: "/swap/b"},
+		{Op: "add", Path: "/labels/env", Value: "prod"},
+		{Op: "add", Path: "/labels/a~1b", Value: "x"},
+		{Op: "add", Path: "/swap/b", Value: "1"},
+		{Op: "add", Path: "/swap/a", Value: "2"},
+		{Op: "replace", Path: "/labels/team", Value: "core"},
+	}, patch)
+
+	applyPatch(t, doc, patch)
+
+	b, err = json.Marshal(in)
+	require.NoError(t, err)
+	require.JSONEq(t, string(b), mustJSON(t, doc))
+
+	b, err = json.Marshal(patch[:1])
+	require.NoError(t, err)
+	require.JSONEq(t, `[{"op":"remove","path":"/labels/ Env "}]`, string(b))
+}
+
+func mustJSON(t *testing.T, v interface{}) string {
+	t.Helper()
+
+	b, err := json.Marshal(v)
+	require.NoError(t, err)
+
+	return string(b)
+}
@@ -0,0 +1,159 @@
+package transform
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// plan is the analysis of a struct type, it is cached per transformer, type and tag name
+// so repeated transformations of the type don't read the tags again
+type plan struct {
+	// fields are the transformed fields in the order of their declaration
+	fields []fieldPlan
+	// order are the positions of the fields in the order of their transformation,
+	// it is nil if the order is the order of declaration
+	order []int
+	// failed is true if the analysis of the type failed, the error is returned with the path of each call
+	failed bool
+	// structLevel is true if the pointer to the struct implements StructTransformer
+	structLevel bool
+}
+
+// planKey is the key of the cached analysis of a struct type, the instantiations
+// of a generic struct are distinct types and cached separately
+type planKey struct {
+	typ     reflect.Type
+	tagName string
+}
+
+// fieldPlan is the analysis of a field of a struct type
+type fieldPlan struct {
+	index int
+	field reflect.StructField
+	name  string
+	tag   string
+	funcs []string
+	json  bool
+	// promoted is true for an embedded struct whose fields are promoted
+	promoted bool
+}
+
+// locate returns the location of the field in the struct at the location
+func (f fieldPlan) locate(loc location) location {
+	if f.promoted {
+		return loc.embed(f.field.Name, f.index)
+	}
+
+	return loc.member(f.field.Name, f.name, f.index)
+}
+
+// planOf returns the plan of the struct type, errors name the fields at the location
+func (t *TransformerImpl) planOf(typ reflect.Type, loc location) (*plan, error) {
+	key := planKey{typ, t.TagName}
+
+	if v, ok := t.plans.Load(key); ok {
+		if p := v.(*plan); !p.failed {
+			return p, nil
+		}
+	}
+
+	p, err := t.analyze(typ, loc)
+	if err != nil {
+		t.plans.Store(key, &plan{failed: true})
+		return nil, err
+	}
+
+	t.plans.Store(key, p)
+
+	return p, nil
+}
+
+// resetPlans removes the cached plans after a change of the configuration
+func (t *TransformerImpl) resetPlans() {
+	t.plans.Range(func(k, _ interface{}) bool {
+		t.plans.Delete(k)
+		return true
+	})
+}
+
+// analyze reads the tags of the struct type and orders the fields by their dependencies
+func (t *TransformerImpl) analyze(typ reflect.Type, loc location) (*plan, error) {
+	p := &plan{structLevel: reflect.PointerTo(typ).Implements(structTransformerType)}
+	levels := []FieldLevel{}
+
+	for i := 0; i < typ.NumField(); i++ {
+		ft := typ.Field(i)
+
+		tag := ft.Tag.Get(t.TagName)
+		if tag == "-" {
+			continue
+		}
+
+		if t.skipType(ft.Type) {
+			continue
+		}
+
+		fl := t.locate(loc, ft, i)
+
+		if t.errorOnUnexported && tag != "" && !ft.IsExported() {
+			return nil, fmt.Errorf("%w: %s", ErrUnexportedField, fl.path)
+		}
+
+		if k, ok := t.unsupportedKind(ft.Type, tag); ok && t.errorOnKind && ft.IsExported() {
+			return nil, &KindError{Path: fl.path, Kind: k, Err: ErrUnsupportedKind}
+		}
+
+		tag = t.fieldTag(ft)
+
+		f := fieldPlan{
+			index: i,
+			field: ft,
+			name:  t.nameOf(ft),
+			tag:   tag,
+			funcs: funcsOf(tag),
+			json:  ft.Tag.Get("json") != "", // detected if this field is json
+
+			promoted: t.promoted(ft),
+		}
+
+		p.fields = append(p.fields, f)
+		levels = append(levels, fieldLevel{field: ft, tagName: t.TagName, tag: tag, funcs: f.funcs, loc: fl})
+	}
+
+	// cross-field functions need their source transformed first
+	sorted, err := sortFields(levels)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, fl := range sorted {
+		if fieldIndex(fl) != fieldIndex(levels[i]) {
+			p.order = positions(sorted, levels)
+			break
+		}
+	}
+
+	return p, nil
+}
+
+// fieldIndex returns the index of the field in its struct
+func fieldIndex(fl FieldLevel) int {
+	index := fl.Index()
+
+	return index[len(index)-1]
+}
+
+// positions returns the positions of the sorted fields in the declared fields
+func positions(sorted, declared []FieldLevel) []int {
+	pos := make(map[int]int, len(declared))
+	for i, fl := range declared {
+		pos[fieldIndex(fl)] = i
+	}
+
+	order := make([]int, len(sorted))
+	for i, fl := range sorted {
+		order[i] = pos[fieldIndex(fl)]
+	}
+
+	return order
+}
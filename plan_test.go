@@ -0,0 +1,96 @@
+package transform_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/zeiss/go-transform"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanCache(t *testing.T) {
+	type secret string
+
+	type testStruct struct {
+		Slug   string `transform:"slugfrom=Title"`
+		Title  string `transform:"trim" mod:"uppercase"`
+		Secret secret `transform:"trim"`
+	}
+
+	trans := transform.New()
+
+	for i := 0; i < 2; i++ {
+		s := testStruct{Title: " Hello World ", Secret: " x "}
+		require.NoError(t, trans.Transform(&s))
+		require.Equal(t, testStruct{Slug: "hello-world", Title: "Hello World", Secret: "x"}, s)
+	}
+
+	err := trans.SkipType(reflect.TypeOf(secret("")))
+	require.NoError(t, err)
+
+	s := testStruct{Title: " Hello ", Secret: " x "}
+	require.NoError(t, trans.Transform(&s))
+	require.Equal(t, secret(" x "), s.Secret)
+
+	trans.TagName = "mod"
+
+	s = testStruct{Title: "hello"}
+	require.NoError(t, trans.Transform(&s))
+	require.Equal(t, "HELLO", s.Title)
+}
+
+func TestPlanCacheErrors(t *testing.T) {
+	type nested struct {
+		name string `transform:"trim"`
+	}
+
+	type testStruct struct {
+		First  nested
+		Second []nested
+	}
+
+	trans := transform.New(transform.WithErrorOnUnexported())
+
+	for i := 0; i < 2; i++ {
+		err := trans.Transform(&testStruct{})
+		require.ErrorIs(t, err, transform.ErrUnexportedField)
+		require.ErrorContains(t, err, "First.name")
+	}
+
+	err := trans.Transform(&struct{ Second []nested }{Second: []nested{{}}})
+	require.ErrorContains(t, err, "Second[0].name")
+}
+
+type planPage[T any] struct {
+	Items []T
+	Query string `transform:"trim" mod:"uppercase,lowercsae"`
+	Note  string
+}
+
+func TestPlanCachePerTransformer(t *testing.T) {
+	trimming := transform.New(transform.WithKindDefaults(reflect.String, "trim"))
+	plain := transform.New()
+
+	for i := 0; i < 2; i++ {
+		p := &planPage[string]{Items: []string{" a "}, Query: " q ", Note: " n "}
+		require.NoError(t, trimming.Transform(p))
+		require.Equal(t, &planPage[string]{Items: []string{" a "}, Query: "q", Note: "n"}, p)
+
+		p = &planPage[string]{Query: " q ", Note: " n "}
+		require.NoError(t, plain.Transform(p))
+		require.Equal(t, &planPage[string]{Query: "q", Note: " n "}, p)
+	}
+
+	// the plans of the tag names are cached side by side
+	strict := transform.New(transform.WithStrictMode())
+
+	for i := 0; i < 2; i++ {
+		strict.TagName = transform.DefaultTagName
+		require.NoError(t, strict.Transform(&planPage[int]{Query: " q "}))
+
+		strict.TagName = "mod"
+		err := strict.Transform(&planPage[int]{Query: " q "})
+		require.ErrorIs(t, err, transform.ErrUnknownFunc)
+	}
+}
@@ -0,0 +1,17 @@
+package preset
+
+import (
+	"reflect"
+
+	"github.com/zeiss/go-transform"
+)
+
+// API returns a transformer for request and response DTOs of APIs.
+// Invalid UTF-8 is replaced, control characters are removed and whitespace is squished
+// in strings without a transform tag, and unknown functions in tags are errors.
+func API(opts ...transform.TransformerOpt) *transform.TransformerImpl {
+	return with([]transform.TransformerOpt{
+		transform.WithKindDefaults(reflect.String, "validutf8,stripctl,squish"),
+		transform.WithErrorOnUnknownFunc(),
+	}, opts)
+}
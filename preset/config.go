@@ -0,0 +1,173 @@
+package preset
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zeiss/go-transform"
+)
+
+// Config returns a transformer for loaded configuration structs.
+// Strings without a transform tag are trimmed, the tags may use
+//
+//	expandenv  expands ${VAR} and $VAR with the environment
+//	duration   normalizes a duration (90s becomes 1m30s)
+//	bytesize   converts a size (10MB, 1.5GiB, 512k) to the number of bytes
+//	bool       normalizes yes/no, on/off, y/n, 1/0 to true/false
+//	hostname   lowercases a hostname and removes the trailing dot
+func Config(opts ...transform.TransformerOpt) *transform.TransformerImpl {
+	return with([]transform.TransformerOpt{
+		transform.WithTransformation("expandenv", expandEnvFunc),
+		transform.WithTransformation("duration", durationFunc),
+		transform.WithTransformation("bytesize", byteSizeFunc),
+		transform.WithTransformation("bool", boolFunc),
+		transform.WithTransformation("hostname", hostnameFunc),
+		transform.WithKindDefaults(reflect.String, "trim"),
+	}, opts)
+}
+
+// expandEnvFunc expands the environment variables of the value
+func expandEnvFunc(fl transform.FieldLevel) error {
+	transform.SetString(fl, os.ExpandEnv(fl.String()))
+	return nil
+}
+
+// durationFunc normalizes a duration
+func durationFunc(fl transform.FieldLevel) error {
+	s := strings.TrimSpace(fl.String())
+	if s == "" {
+		return nil
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return fmt.Errorf("duration: invalid duration %q", s)
+	}
+
+	transform.SetString(fl, d.String())
+
+	return nil
+}
+
+// byteUnits are the multipliers of the size units, single letters are binary units
+var byteUnits = map[string]float64{
+	"":    1,
+	"b":   1,
+	"k":   1 << 10,
+	"m":   1 << 20,
+	"g":   1 << 30,
+	"t":   1 << 40,
+	"kb":  1e3,
+	"mb":  1e6,
+	"gb":  1e9,
+	"tb":  1e12,
+	"kib": 1 << 10,
+	"mib": 1 << 20,
+	"gib": 1 << 30,
+	"tib": 1 << 40,
+}
+
+// byteSizeFunc converts a size to the number of bytes
+func byteSizeFunc(fl transform.FieldLevel) error {
+	s := strings.TrimSpace(fl.String())
+	if s == "" {
+		return nil
+	}
+
+	n, err := byteSize(s)
+	if err != nil {
+		return err
+	}
+
+	transform.SetString(fl, strconv.FormatUint(n, 10))
+
+	return nil
+}
+
+// byteSize returns the number of bytes of the size
+func byteSize(s string) (uint64, error) {
+	i := strings.IndexFunc(s, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if i < 0 {
+		i = len(s)
+	}
+
+	v, err := strconv.ParseFloat(s[:i], 64)
+	unit, ok := byteUnits[strings.ToLower(strings.TrimSpace(s[i:]))]
+
+	if err != nil || !ok || v*unit > math.MaxUint64 {
+		return 0, fmt.Errorf("bytesize: invalid size %q", s)
+	}
+
+	return uint64(v * unit), nil
+}
+
+// boolFunc normalizes a boolean
+func boolFunc(fl transform.FieldLevel) error {
+	s := strings.ToLower(strings.TrimSpace(fl.String()))
+
+	switch s {
+	case "":
+		return nil
+	case "yes", "y", "on":
+		s = "true"
+	case "no", "n", "off":
+		s = "false"
+	default:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return fmt.Errorf("bool: invalid boolean %q", fl.String())
+		}
+
+		s = strconv.FormatBool(b)
+	}
+
+	transform.SetString(fl, s)
+
+	return nil
+}
+
+// hostnameFunc lowercases a hostname and removes the trailing dot, IP addresses are kept
+func hostnameFunc(fl transform.FieldLevel) error {
+	s := strings.TrimSpace(fl.String())
+	if s == "" || net.ParseIP(s) != nil {
+		return nil
+	}
+
+	s = strings.TrimSuffix(strings.ToLower(s), ".")
+	if !validHostname(s) {
+		return fmt.Errorf("hostname: invalid hostname %q", fl.String())
+	}
+
+	transform.SetString(fl, s)
+
+	return nil
+}
+
+// validHostname returns true if the lowercase hostname consists of valid labels (RFC 1123)
+func validHostname(s string) bool {
+	if len(s) > 253 {
+		return false
+	}
+
+	for _, l := range strings.Split(s, ".") {
+		if len(l) == 0 || len(l) > 63 || l[0] == '-' || l[len(l)-1] == '-' {
+			return false
+		}
+
+		for _, r := range l {
+			if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' {
+				return false
+			}
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,132 @@
+package preset
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/zeiss/go-transform"
+)
+
+// FixedWidth returns a transformer for the fields of legacy fixed-width interchange formats (e.g. EDI segments).
+// Unknown functions in tags are errors, the tags may use the built-in functions (e.g. uppercase, truncate, pad_left) and
+//
+//	pad_right=char:width  pads a non-empty value on the right to width characters
+//	asciionly             transliterates Latin letters to ASCII (ä becomes a, ß becomes ss), other characters become ?
+//	fixed=len:align:pad   truncates or pads the value to exactly len characters, aligned left (default) or right
+//	                      and padded with pad (default a space), e.g. fixed=8:right:0 turns 4711 into 00004711
+func FixedWidth(opts ...transform.TransformerOpt) *transform.TransformerImpl {
+	return with([]transform.TransformerOpt{
+		transform.WithTransformation("pad_right", padRightFunc),
+		transform.WithTransformation("asciionly", asciiOnlyFunc),
+		transform.WithTransformation("fixed", fixedFunc),
+		transform.WithErrorOnUnknownFunc(),
+	}, opts)
+}
+
+// padRightFunc pads a non-empty value on the right with a character to a width (pad_right= :10)
+func padRightFunc(fl transform.FieldLevel) error {
+	pad, width := " ", fl.Param()
+	if i := strings.LastIndex(width, ":"); i >= 0 {
+		pad, width = width[:i], width[i+1:]
+	}
+
+	n, err := strconv.Atoi(width)
+	if err != nil || n < 0 || utf8.RuneCountInString(pad) != 1 {
+		return fmt.Errorf("%w: pad_right=%s", transform.ErrInvalidParam, fl.Param())
+	}
+
+	s := fl.String()
+	if s == "" {
+		return nil
+	}
+
+	if l := utf8.RuneCountInString(s); l < n {
+		s += strings.Repeat(pad, n-l)
+	}
+
+	transform.SetString(fl, s)
+
+	return nil
+}
+
+// fixedFunc truncates or pads the value to a fixed width (fixed=10, fixed=8:right:0)
+func fixedFunc(fl transform.FieldLevel) error {
+	width, rest, _ := strings.Cut(fl.Param(), ":")
+	align, pad, _ := strings.Cut(rest, ":")
+
+	if align == "" {
+		align = "left"
+	}
+
+	if pad == "" {
+		pad = " "
+	}
+
+	n, err := strconv.Atoi(width)
+	if err != nil || n < 0 || (align != "left" && align != "right") || utf8.RuneCountInString(pad) != 1 {
+		return fmt.Errorf("%w: fixed=%s", transform.ErrInvalidParam, fl.Param())
+	}
+
+	transform.SetString(fl, fixed(fl.String(), n, align == "right", pad))
+
+	return nil
+}
+
+// fixed returns the value truncated or padded to n characters
+func fixed(s string, n int, right bool, pad string) string {
+	l := 0
+	for i := range s {
+		if l == n {
+			return s[:i]
+		}
+
+		l++
+	}
+
+	if right {
+		return strings.Repeat(pad, n-l) + s
+	}
+
+	return s + strings.Repeat(pad, n-l)
+}
+
+// asciiLetters are the transliterations of the non-ASCII Latin letters
+var asciiLetters = map[rune]string{
+	'À': "A", 'Á': "A", 'Â': "A", 'Ã': "A", 'Ä': "A", 'Å': "A", 'Æ': "AE", 'Ç': "C",
+	'È': "E", 'É': "E", 'Ê': "E", 'Ë': "E", 'Ì': "I", 'Í': "I", 'Î': "I", 'Ï': "I",
+	'Ð': "D", 'Ñ': "N", 'Ò': "O", 'Ó': "O", 'Ô': "O", 'Õ': "O", 'Ö': "O", 'Ø': "O",
+	'Ù': "U", 'Ú': "U", 'Û': "U", 'Ü': "U", 'Ý': "Y", 'Þ': "TH", 'ß': "ss",
+	'à': "a", 'á': "a", 'â': "a", 'ã': "a", 'ä': "a", 'å': "a", 'æ': "ae", 'ç': "c",
+	'è': "e", 'é': "e", 'ê': "e", 'ë': "e", 'ì': "i", 'í': "i", 'î': "i", 'ï': "i",
+	'ð': "d", 'ñ': "n", 'ò': "o", 'ó': "o", 'ô': "o", 'õ': "o", 'ö': "o", 'ø': "o",
+	'ù': "u", 'ú': "u", 'û': "u", 'ü': "u", 'ý': "y", 'þ': "th", 'ÿ': "y",
+	'Č': "C", 'č': "c", 'Ć': "C", 'ć': "c", 'Ł': "L", 'ł': "l", 'Ń': "N", 'ń': "n",
+	'Œ': "OE", 'œ': "oe", 'Ř': "R", 'ř': "r", 'Ś': "S", 'ś': "s", 'Š': "S", 'š': "s",
+	'Ž': "Z", 'ž': "z", 'Ź': "Z", 'ź': "z", 'Ż': "Z", 'ż': "z", 'Ğ': "G", 'ğ': "g",
+	'İ': "I", 'ı': "i", 'Ş': "S", 'ş': "s", 'Ő': "O", 'ő': "o", 'Ű': "U", 'ű': "u",
+}
+
+// asciiOnlyFunc transliterates the value to ASCII
+func asciiOnlyFunc(fl transform.FieldLevel) error {
+	s := fl.String()
+
+	b := strings.Builder{}
+	b.Grow(len(s))
+
+	for _, r := range s {
+		switch {
+		case r < utf8.RuneSelf:
+			b.WriteRune(r)
+		case asciiLetters[r] != "":
+			b.WriteString(asciiLetters[r])
+		default:
+			b.WriteByte('?')
+		}
+	}
+
+	transform.SetString(fl, b.String())
+
+	return nil
+}
@@ -0,0 +1,13 @@
+// Package preset provides transformers pre-configured for common use cases.
+// The options passed to a preset are applied after its own, so they can
+// replace its functions and defaults.
+package preset
+
+import (
+	"github.com/zeiss/go-transform"
+)
+
+// with returns a transformer with the options of the preset followed by the options of the caller
+func with(preset []transform.TransformerOpt, opts []transform.TransformerOpt) *transform.TransformerImpl {
+	return transform.New(append(preset, opts...)...)
+}
@@ -0,0 +1,270 @@
+package preset_test
+
+import (
+	"testing"
+
+	"github.com/zeiss/go-transform"
+	"github.com/zeiss/go-transform/preset"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig(t *testing.T) {
+	t.Setenv("PRESET_DATA", "/var/lib/app")
+
+	type testStruct struct {
+		Name    string
+		Dir     string `transform:"trim,expandenv"`
+		Timeout string `transform:"duration"`
+		Cache   string `transform:"bytesize"`
+		Limit   string `transform:"bytesize"`
+		Debug   string `transform:"bool"`
+		Host    string `transform:"trim,hostname"`
+		Addr    string `transform:"hostname"`
+		Empty   *string
+	}
+
+	in := &testStruct{
+		Name:    " app ",
+		Dir:     " ${PRESET_DATA}/cache ",
+		Timeout: "90s",
+		Cache:   "1.5 GiB",
+		Limit:   "10MB",
+		Debug:   "Yes",
+		Host:    " DB.Example.COM. ",
+		Addr:    "::1",
+	}
+
+	err := preset.Config().Transform(in)
+	require.NoError(t, err)
+
+	require.Equal(t, &testStruct{
+		Name:    "app",
+		Dir:     "/var/lib/app/cache",
+		Timeout: "1m30s",
+		Cache:   "1610612736",
+		Limit:   "10000000",
+		Debug:   "true",
+		Host:    "db.example.com",
+		Addr:    "::1",
+	}, in)
+}
+
+func TestConfigErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		in   interface{}
+	}{
+		{name: "duration", in: &struct {
+			V string `transform:"duration"`
+		}{V: "10 parsecs"}},
+		{name: "bytesize", in: &struct {
+			V string `transform:"bytesize"`
+		}{V: "10XB"}},
+		{name: "bool", in: &struct {
+			V string `transform:"bool"`
+		}{V: "maybe"}},
+		{name: "hostname", in: &struct {
+			V string `transform:"hostname"`
+		}{V: "-invalid_host"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := preset.Config().Transform(tt.in)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestAPI(t *testing.T) {
+	type testStruct struct {
+		Name    string
+		Comment string `transform:"trim"`
+		Tags    []string
+	}
+
+	in := &testStruct{
+		Name:    "  John\x00  \xff Doe ",
+		Comment: " keep  \x00 ",
+	}
+
+	err := preset.API().Transform(in)
+	require.NoError(t, err)
+	require.Equal(t, &testStruct{Name: "John � Doe", Comment: "keep  \x00"}, in)
+
+	type unknown struct {
+		Name string `transform:"trimm"`
+	}
+
+	err = preset.API().Transform(&unknown{})
+	require.ErrorIs(t, err, transform.ErrUnknownFunc)
+}
+
+func TestAPIRules(t *testing.T) {
+	type testStruct struct {
+		Name    string  `json:"name"`
+		Comment string  `json:"comment" transform:"trim"`
+		Email   *string `json:"email"`
+	}
+
+	b, err := preset.API().ExportRules(testStruct{})
+	require.NoError(t, err)
+	require.JSONEq(t, `[
+		{"path":"Name","jsonPath":"name","type":"string","funcs":["validutf8","stripctl","squish"]},
+		{"path":"Comment","jsonPath":"comment","type":"string","funcs":["trim"]},
+		{"path":"Email","jsonPath":"email","type":"*string","funcs":["validutf8","stripctl","squish"]}
+	]`, string(b))
+}
+
+func TestLogScrubber(t *testing.T) {
+	type card struct {
+		Holder string
+		Number string
+	}
+
+	type user struct {
+		ID          int
+		Name        string
+		Email       string
+		APIToken    *string
+		Nickname    string `log:"pii"`
+		SessionID   string `log:"plain"`
+		Credentials card   `log:"secret"`
+		Cards       []card
+		Note        string
+	}
+
+	scrub := preset.LogScrubber("number")
+
+	token := "abc"
+	in := &user{
+		ID:          1,
+		Name:        "John",
+		Email:       "john@example.com",
+		APIToken:    &token,
+		Nickname:    "jd",
+		SessionID:   "s1",
+		Credentials: card{Holder: "John", Number: "4111"},
+		Cards:       []card{{Holder: "John", Number: "4111"}},
+	}
+
+	out := scrub(in)
+	require.Equal(t, &user{
+		ID:        1,
+		Name:      "John",
+		Email:     "[REDACTED]",
+		APIToken:  out.(*user).APIToken,
+		Nickname:  "[REDACTED]",
+		SessionID: "s1",
+		Cards:     []card{{Holder: "John", Number: "[REDACTED]"}},
+	}, out)
+	require.Equal(t, "[REDACTED]", *out.(*user).APIToken)
+
+	// the original value is unchanged
+	require.Equal(t, "john@example.com", in.Email)
+	require.Equal(t, "abc", token)
+	require.Equal(t, "4111", in.Cards[0].Number)
+
+	// struct values are returned as values
+	v := scrub(user{Email: "a@b.de"})
+	require.Equal(t, user{Email: "[REDACTED]"}, v)
+
+	require.Equal(t, "plain", scrub("plain"))
+	require.Nil(t, scrub(nil))
+}
+
+func TestLogScrubberNested(t *testing.T) {
+	type credentials struct {
+		User     string
+		Password string
+	}
+
+	type event struct {
+		Headers map[string]string
+		Users   map[string]credentials
+		Payload interface{}
+		Extra   interface{}
+	}
+
+	scrub := preset.LogScrubber()
+
+	in := &event{
+		Headers: map[string]string{"Authorization": "Bearer abc", "Accept": "text/plain"},
+		Users:   map[string]credentials{"john": {User: "john", Password: "pw"}},
+		Payload: &credentials{User: "jane", Password: "pw"},
+		Extra:   map[string]interface{}{"api_key": "abc", "count": 1, "nested": map[string]interface{}{"token": "t"}},
+	}
+
+	out := scrub(in).(*event)
+	require.Equal(t, &event{
+		Headers: map[string]string{"Authorization": "[REDACTED]", "Accept": "text/plain"},
+		Users:   map[string]credentials{"john": {User: "john", Password: "[REDACTED]"}},
+		Payload: &credentials{User: "jane", Password: "[REDACTED]"},
+		Extra:   map[string]interface{}{"api_key": "[REDACTED]", "count": 1, "nested": map[string]interface{}{"token": "[REDACTED]"}},
+	}, out)
+
+	// the original value is unchanged
+	require.Equal(t, "Bearer abc", in.Headers["Authorization"])
+	require.Equal(t, "pw", in.Users["john"].Password)
+	require.Equal(t, "pw", in.Payload.(*credentials).Password)
+	require.Equal(t, "abc", in.Extra.(map[string]interface{})["api_key"])
+
+	v := scrub(event{Payload: credentials{Password: "pw"}})
+	require.Equal(t, event{Payload: credentials{Password: "[REDACTED]"}}, v)
+}
+
+func TestFixedWidth(t *testing.T) {
+	type segment struct {
+		Name    string `transform:"asciionly,uppercase,fixed=10"`
+		Amount  string `transform:"fixed=8:right:0"`
+		City    string `transform:"asciionly,pad_right=.:6"`
+		Code    string `transform:"trim,pad_left=0:4"`
+		Comment string `transform:"fixed=3"`
+	}
+
+	tests := []struct {
+		name string
+		in   *segment
+		out  *segment
+	}{
+		{
+			name: "empty",
+			in:   &segment{},
+			out:  &segment{Name: "          ", Amount: "00000000", Comment: "   "},
+		},
+		{
+			name: "values",
+			in:   &segment{Name: "Jörg Straße", Amount: "4711", City: "Łódź", Code: " 7 ", Comment: "ab"},
+			out:  &segment{Name: "JORG STRAS", Amount: "00004711", City: "Lodz..", Code: "0007", Comment: "ab "},
+		},
+		{
+			name: "long",
+			in:   &segment{Name: "🙂 long name", Amount: "123456789", City: "Zürich", Comment: "äöüß"},
+			out:  &segment{Name: "? LONG NAM", Amount: "12345678", City: "Zurich", Comment: "äöü"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, preset.FixedWidth().Transform(tt.in))
+			require.Equal(t, tt.out, tt.in)
+		})
+	}
+
+	invalid := []interface{}{
+		&struct {
+			V string `transform:"fixed=x"`
+		}{},
+		&struct {
+			V string `transform:"fixed=4:center"`
+		}{},
+		&struct {
+			V string `transform:"pad_right=ab:4"`
+		}{V: "x"},
+	}
+
+	for _, in := range invalid {
+		require.ErrorIs(t, preset.FixedWidth().Transform(in), transform.ErrInvalidParam)
+	}
+}
@@ -0,0 +1,142 @@
+package preset
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/zeiss/go-transform"
+)
+
+const (
+	// logTag is the name of the tag classifying a field for logging
+	logTag = "log"
+	// redacted replaces the values of masked strings
+	redacted = "[REDACTED]"
+)
+
+// secretNames are the parts of field names that classify a field as secret
+var secretNames = []string{
+	"password", "passwd", "secret", "token", "apikey", "authorization",
+	"cookie", "credential", "privatekey", "creditcard", "cardnumber", "cvv", "iban",
+}
+
+// piiNames are the field names that classify a field as personal data
+var piiNames = map[string]struct{}{
+	"email": {}, "phone": {}, "mobile": {}, "ssn": {}, "birthdate": {}, "dateofbirth": {},
+	"taxid": {}, "passport": {}, "ipaddress": {},
+}
+
+// LogScrubber returns a function returning a masked deep copy of a value for structured logging
+//
+//	scrub := preset.LogScrubber()
+//	logger.Info("request", "req", scrub(req))
+//
+// Fields tagged with log:"secret" or log:"pii" are masked, as well as fields whose names
+// look like secrets (e.g. Password, APIToken) or personal data (e.g. Email, Phone),
+// tag a field with log:"plain" to keep it. The entries of maps are classified by their keys
+// (e.g. map[string]string{"password": "..."}) and values held by interfaces are masked like fields.
+// Non-empty strings are replaced with [REDACTED],
+// other values are zeroed. Values other than structs and pointers to structs are returned
+// unchanged, unexported fields are not masked. Additional names classify fields as secret.
+func LogScrubber(names ...string) func(v interface{}) interface{} {
+	secrets := append(append([]string{}, secretNames...), normalizeNames(names)...)
+
+	return func(v interface{}) interface{} {
+		return scrub(v, secrets)
+	}
+}
+
+// scrub returns a masked deep copy of the struct or pointer to a struct
+func scrub(v interface{}, secrets []string) interface{} {
+	rv := reflect.ValueOf(v)
+
+	isPtr := rv.Kind() == reflect.Ptr
+	if isPtr {
+		if rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+			return v
+		}
+	} else {
+		if rv.Kind() != reflect.Struct {
+			return v
+		}
+
+		p := reflect.New(rv.Type())
+		p.Elem().Set(rv)
+		rv = p
+	}
+
+	cp, err := transform.Clone(rv.Interface())
+	if err != nil {
+		return redacted
+	}
+
+	err = transform.Walk(cp, func(fl transform.FieldLevel) error {
+		if !sensitive(fl, secrets) {
+			return nil
+		}
+
+		mask(fl.Field())
+
+		return transform.SkipNested
+	})
+	if err != nil {
+		return redacted
+	}
+
+	if isPtr {
+		return cp
+	}
+
+	return reflect.ValueOf(cp).Elem().Interface()
+}
+
+// sensitive returns true if the field is classified as secret or personal data
+func sensitive(fl transform.FieldLevel, secrets []string) bool {
+	switch fl.Tag(logTag) {
+	case "secret", "pii":
+		return true
+	case "plain":
+		return false
+	}
+
+	name := normalizeNames([]string{fl.FieldName()})[0]
+
+	if _, ok := piiNames[name]; ok {
+		return true
+	}
+
+	for _, s := range secrets {
+		if strings.Contains(name, s) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// mask replaces non-empty strings and zeroes all other values
+func mask(v reflect.Value) {
+	switch {
+	case v.Kind() == reflect.String && v.Len() > 0:
+		v.SetString(redacted)
+	case v.Kind() == reflect.Ptr && !v.IsNil() && v.Elem().Kind() == reflect.String:
+		s := redacted
+		v.Set(reflect.ValueOf(&s).Convert(v.Type()))
+	case v.Kind() == reflect.Interface && !v.IsNil() && v.Elem().Kind() == reflect.String:
+		v.Set(reflect.ValueOf(redacted).Convert(v.Elem().Type()))
+	default:
+		v.Set(reflect.Zero(v.Type()))
+	}
+}
+
+// normalizeNames lowercases the names and removes underscores and dashes
+func normalizeNames(names []string) []string {
+	r := strings.NewReplacer("_", "", "-", "")
+
+	n := make([]string, len(names))
+	for i, s := range names {
+		n[i] = r.Replace(strings.ToLower(s))
+	}
+
+	return n
+}
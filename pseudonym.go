@@ -0,0 +1,36 @@
+package transform
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+)
+
+// pseudonymLength is the number of bytes of the HMAC used for a pseudonym
+const pseudonymLength = 16
+
+// pseudonymFunc replaces the value with a stable pseudonym keyed by the HMAC key named in the parameter
+func (t *TransformerImpl) pseudonymFunc(fl FieldLevel) error {
+	key, ok := t.hmacKeys[fl.Param()]
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownKey, fl.Param())
+	}
+
+	s := fl.String()
+	if s == "" {
+		return nil
+	}
+
+	SetString(fl, pseudonym(key, s))
+
+	return nil
+}
+
+// pseudonym returns the URL safe base64 encoding of the truncated HMAC-SHA256 of the value
+func pseudonym(key []byte, s string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(s))
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil)[:pseudonymLength])
+}
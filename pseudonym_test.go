@@ -0,0 +1,45 @@
+package transform_test
+
+import (
+	"testing"
+
+	"github.com/zeiss/go-transform"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPseudonym(t *testing.T) {
+	type testStruct struct {
+		CustomerID string  `transform:"trim,pseudonym=customers"`
+		Email      *string `transform:"trim,lowercase,pseudonym=customers"`
+		Empty      string  `transform:"pseudonym=customers"`
+	}
+
+	trans := transform.New(transform.WithHMACKey("customers", []byte("secret")))
+
+	first := &testStruct{CustomerID: " 4711 ", Email: &[]string{" John@Example.com "}[0]}
+	second := &testStruct{CustomerID: "4711", Email: &[]string{"john@example.com"}[0]}
+
+	require.NoError(t, trans.Transform(first))
+	require.NoError(t, trans.Transform(second))
+
+	require.Len(t, first.CustomerID, 22)
+	require.NotEqual(t, "4711", first.CustomerID)
+	require.Equal(t, first, second)
+	require.NotEqual(t, first.CustomerID, *first.Email)
+	require.Empty(t, first.Empty)
+
+	other := transform.New(transform.WithHMACKey("customers", []byte("other")))
+	third := &testStruct{CustomerID: "4711"}
+	require.NoError(t, other.Transform(third))
+	require.NotEqual(t, first.CustomerID, third.CustomerID)
+}
+
+func TestPseudonymUnknownKey(t *testing.T) {
+	type testStruct struct {
+		CustomerID string `transform:"pseudonym=unknown"`
+	}
+
+	err := transform.New().Transform(&testStruct{CustomerID: "4711"})
+	require.ErrorIs(t, err, transform.ErrUnknownKey)
+}
@@ -0,0 +1,86 @@
+package transform
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// randomAlphabet are the characters of a random suffix
+const randomAlphabet = "abcdefghijklmnopqrstuvwxyz0123456789"
+
+// lockedRand is a random source safe for concurrent use
+type lockedRand struct {
+	mu sync.Mutex
+	r  *rand.Rand
+}
+
+// intn returns a random number in [0,n)
+func (l *lockedRand) intn(n int) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.r.Intn(n)
+}
+
+// WithRandSource sets the source of the random functions (e.g. randomsuffix),
+// a fixed seed makes them deterministic in tests. The default is the global source of math/rand.
+func WithRandSource(src rand.Source) TransformerOpt {
+	return func(o *TransformerImpl) {
+		o.random = &lockedRand{r: rand.New(src)} // nolint:gosec
+	}
+}
+
+// intn returns a random number in [0,n) of the source of the transformer
+func (t *TransformerImpl) intn(n int) int {
+	if t.random == nil {
+		return rand.Intn(n) // nolint:gosec
+	}
+
+	return t.random.intn(n)
+}
+
+// randomSuffixFunc appends a dash and n random lowercase letters and digits to a non-empty value (randomsuffix=4)
+func (t *TransformerImpl) randomSuffixFunc(fl FieldLevel) error {
+	n, err := strconv.Atoi(fl.Param())
+	if err != nil || n <= 0 {
+		return fmt.Errorf("%w: randomsuffix=%s", ErrInvalidParam, fl.Param())
+	}
+
+	s := fl.String()
+	if s == "" {
+		return nil
+	}
+
+	b := strings.Builder{}
+	b.Grow(len(s) + n + 1)
+	b.WriteString(s)
+	b.WriteByte('-')
+
+	for i := 0; i < n; i++ {
+		b.WriteByte(randomAlphabet[t.intn(len(randomAlphabet))])
+	}
+
+	SetString(fl, b.String())
+
+	return nil
+}
+
+// jitterSuffixFunc appends a dash and a random number in [0,n) to a non-empty value (jittersuffix=1000)
+func (t *TransformerImpl) jitterSuffixFunc(fl FieldLevel) error {
+	n, err := strconv.Atoi(fl.Param())
+	if err != nil || n <= 0 {
+		return fmt.Errorf("%w: jittersuffix=%s", ErrInvalidParam, fl.Param())
+	}
+
+	s := fl.String()
+	if s == "" {
+		return nil
+	}
+
+	SetString(fl, s+"-"+strconv.Itoa(t.intn(n)))
+
+	return nil
+}
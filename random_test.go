@@ -0,0 +1,61 @@
+package transform_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/zeiss/go-transform"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRandomSuffix(t *testing.T) {
+	type testStruct struct {
+		Slug  string `transform:"trim,randomsuffix=6"`
+		Temp  string `transform:"jittersuffix=1000"`
+		Empty string `transform:"randomsuffix=6"`
+	}
+
+	run := func() testStruct {
+		s := testStruct{Slug: " report ", Temp: "tmp"}
+		require.NoError(t, transform.New(transform.WithRandSource(rand.NewSource(1))).Transform(&s))
+
+		return s
+	}
+
+	first, second := run(), run()
+	require.Equal(t, first, second)
+	require.Regexp(t, `^report-[a-z0-9]{6}$`, first.Slug)
+	require.Regexp(t, `^tmp-[0-9]{1,3}$`, first.Temp)
+	require.Empty(t, first.Empty)
+
+	s := testStruct{Slug: "report"}
+	require.NoError(t, transform.New().Transform(&s))
+	require.Regexp(t, `^report-[a-z0-9]{6}$`, s.Slug)
+}
+
+func TestRandomSuffixInvalidParam(t *testing.T) {
+	tests := []struct {
+		name string
+		in   interface{}
+	}{
+		{
+			name: "randomsuffix",
+			in: &struct {
+				Name string `transform:"randomsuffix=0"`
+			}{Name: "x"},
+		},
+		{
+			name: "jittersuffix",
+			in: &struct {
+				Name string `transform:"jittersuffix=n"`
+			}{Name: "x"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.ErrorIs(t, transform.New().Transform(tc.in), transform.ErrInvalidParam)
+		})
+	}
+}
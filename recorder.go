@@ -0,0 +1,146 @@
+package transform
+
+import (
+	"encoding/json"
+	"io"
+	"math/rand"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// redacted replaces the values of redacted keys
+const redacted = "[REDACTED]"
+
+// Record is a recorded transformation
+type Record struct {
+	// Time is the time of the transformation
+	Time time.Time `json:"time"`
+	// Type is the type of the transformed value
+	Type string `json:"type"`
+	// Input is the value before the transformation
+	Input json.RawMessage `json:"input"`
+	// Output is the value after the transformation
+	Output json.RawMessage `json:"output"`
+	// Error is the error of the transformation
+	Error string `json:"error,omitempty"`
+}
+
+// Recorder writes the input and output of a sampled fraction of transformations
+// as JSON lines, to reproduce normalization bugs reported from production.
+// It is safe for concurrent use.
+type Recorder struct {
+	mu     sync.Mutex
+	w      io.Writer
+	rate   float64
+	redact map[string]struct{}
+	rand   func() float64
+	now    func() time.Time
+}
+
+// RecorderOpt configures a recorder
+type RecorderOpt func(r *Recorder)
+
+// WithRecorderSampleRate sets the fraction of recorded transformations, the default is 1 (all)
+func WithRecorderSampleRate(rate float64) RecorderOpt {
+	return func(r *Recorder) {
+		r.rate = rate
+	}
+}
+
+// WithRecorderRedactKeys replaces the values of the given JSON keys in the records
+func WithRecorderRedactKeys(keys ...string) RecorderOpt {
+	return func(r *Recorder) {
+		for _, k := range keys {
+			r.redact[k] = struct{}{}
+		}
+	}
+}
+
+// NewRecorder returns a recorder writing to w
+func NewRecorder(w io.Writer, opts ...RecorderOpt) *Recorder {
+	r := &Recorder{
+		w:      w,
+		rate:   1,
+		redact: make(map[string]struct{}),
+		rand:   rand.Float64, // nolint:gosec
+		now:    time.Now,
+	}
+
+	for _, o := range opts {
+		o(r)
+	}
+
+	return r
+}
+
+// sample returns true if the transformation should be recorded
+func (r *Recorder) sample() bool {
+	return r.rate >= 1 || (r.rate > 0 && r.rand() < r.rate)
+}
+
+// snapshot returns the redacted JSON of the value
+func (r *Recorder) snapshot(s interface{}) json.RawMessage {
+	b, err := json.Marshal(s)
+	if err != nil {
+		b, _ = json.Marshal(err.Error())
+		return b
+	}
+
+	if len(r.redact) == 0 {
+		return b
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return b
+	}
+
+	b, _ = json.Marshal(r.redactValue(v))
+
+	return b
+}
+
+func (r *Recorder) redactValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, e := range v {
+			if _, ok := r.redact[k]; ok {
+				v[k] = redacted
+				continue
+			}
+
+			v[k] = r.redactValue(e)
+		}
+	case []interface{}:
+		for i, e := range v {
+			v[i] = r.redactValue(e)
+		}
+	}
+
+	return v
+}
+
+// record writes the record of the transformation
+func (r *Recorder) record(s interface{}, in json.RawMessage, err error) {
+	rec := Record{
+		Time:   r.now(),
+		Type:   reflect.TypeOf(s).String(),
+		Input:  in,
+		Output: r.snapshot(s),
+	}
+
+	if err != nil {
+		rec.Error = err.Error()
+	}
+
+	b, mErr := json.Marshal(rec)
+	if mErr != nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, _ = r.w.Write(append(b, '\n'))
+}
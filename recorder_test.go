@@ -0,0 +1,63 @@
+package transform_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/zeiss/go-transform"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecorder(t *testing.T) {
+	type credentials struct {
+		Password string `json:"password"`
+	}
+
+	type testStruct struct {
+		Email       string      `json:"email" transform:"trim,lowercase"`
+		Credentials credentials `json:"credentials"`
+	}
+
+	buf := &bytes.Buffer{}
+	rec := transform.NewRecorder(buf, transform.WithRecorderRedactKeys("password"))
+	trans := transform.New(transform.WithRecorder(rec))
+
+	err := trans.Transform(&testStruct{Email: "  John@Example.com  ", Credentials: credentials{Password: "secret"}})
+	require.NoError(t, err)
+
+	err = trans.Transform(&testStruct{Email: "jane@example.com"})
+	require.NoError(t, err)
+
+	records := []transform.Record{}
+	scanner := bufio.NewScanner(buf)
+	for scanner.Scan() {
+		var r transform.Record
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &r))
+		records = append(records, r)
+	}
+
+	require.Len(t, records, 2)
+	require.Equal(t, "*transform_test.testStruct", records[0].Type)
+	require.JSONEq(t, `{"email":"  John@Example.com  ","credentials":{"password":"[REDACTED]"}}`, string(records[0].Input))
+	require.JSONEq(t, `{"email":"john@example.com","credentials":{"password":"[REDACTED]"}}`, string(records[0].Output))
+	require.NotContains(t, buf.String(), "secret")
+}
+
+func TestRecorderSampleRate(t *testing.T) {
+	type testStruct struct {
+		Name string `transform:"trim"`
+	}
+
+	buf := &bytes.Buffer{}
+	rec := transform.NewRecorder(buf, transform.WithRecorderSampleRate(0))
+	trans := transform.New(transform.WithRecorder(rec))
+
+	in := &testStruct{Name: "  test  "}
+	err := trans.Transform(in)
+	require.NoError(t, err)
+	require.Equal(t, "test", in.Name)
+	require.Empty(t, buf.String())
+}
@@ -0,0 +1,142 @@
+package transform
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+var (
+	// ErrDuplicateFunc is returned if a function is registered with a name that is already taken
+	ErrDuplicateFunc = newError("transformer: function already registered")
+	// ErrInvalidFunc is returned if a registered function is invalid
+	ErrInvalidFunc = newError("transformer: invalid function")
+)
+
+var (
+	registryMu sync.RWMutex
+	// registry is the default registry of transform functions added by plugin packages
+	registry = make(map[string]Func)
+)
+
+// Register adds the transform function to the default registry, so it is available
+// to all transformers not created with WithoutDefaults. Plugin packages call it from
+// their init function, so a blank import makes their functions available:
+//
+//	import _ "github.com/zeiss/go-transform/transformhtml"
+//
+// It panics if the name is registered twice, is the name of a built-in function
+// or is not a valid name as for RegisterTransformation.
+func Register(name string, fn Func) {
+	if err := register(name, fn); err != nil {
+		panic(err)
+	}
+}
+
+// RegisterAll adds the functions of the table to the default registry,
+// no function is added if any of the names is already taken or invalid
+func RegisterAll(table map[string]Func) error {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	for name, fn := range table {
+		if err := available(name, fn); err != nil {
+			return err
+		}
+	}
+
+	for name, fn := range table {
+		registry[name] = fn
+	}
+
+	return nil
+}
+
+// register adds the function to the default registry
+func register(name string, fn Func) error {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if err := available(name, fn); err != nil {
+		return err
+	}
+
+	registry[name] = fn
+
+	return nil
+}
+
+// available returns an error if the function is invalid or the name is taken, the lock must be held
+func available(name string, fn Func) error {
+	if err := validFunc(name, fn); err != nil {
+		return err
+	}
+
+	_, builtin := internalTransformers[name]
+	_, bound := boundTransformers[name]
+	_, taken := registry[name]
+
+	if builtin || bound || taken {
+		return fmt.Errorf("%w: %q", ErrDuplicateFunc, name)
+	}
+
+	return nil
+}
+
+// RegisterTransformation adds the transform function to the transformer, so tags of the
+// structs transformed by it may reference the function by its name. Like WithTransformation
+// it replaces a built-in function of the same name. The name must not be empty, contain a
+// comma or an equals sign or be one of the directives dive, keys and endkeys.
+// It is safe to register functions while the transformer is used,
+// it returns ErrFrozen if the transformer is frozen.
+func (t *TransformerImpl) RegisterTransformation(name string, fn Func) error {
+	if err := validFunc(name, fn); err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.frozen {
+		return ErrFrozen
+	}
+
+	if t.funcs == nil {
+		t.funcs = make(map[string]Func)
+	}
+
+	t.funcs[name] = fn
+
+	return nil
+}
+
+// validFunc returns an error if the function can not be added to a transformer
+func validFunc(name string, fn Func) error {
+	if fn == nil {
+		return fmt.Errorf("%w: function %q is nil", ErrInvalidFunc, name)
+	}
+
+	if name == "" || strings.ContainsAny(name, ",=") || name == diveTag || name == keysTag || name == endKeysTag || name == omitEmptyTag {
+		return fmt.Errorf("%w: invalid name %q", ErrInvalidFunc, name)
+	}
+
+	return nil
+}
+
+// WithoutDefaults ignores the functions of the default registry added by plugin packages,
+// only built-in functions and functions added to the transformer are available.
+func WithoutDefaults() TransformerOpt {
+	return func(o *TransformerImpl) {
+		o.withoutDefaults = true
+	}
+}
+
+// registered returns the function of the default registry
+func registered(name string) (Func, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	fn, ok := registry[name]
+
+	return fn, ok
+}
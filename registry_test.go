@@ -0,0 +1,114 @@
+package transform_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/zeiss/go-transform"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterAll(t *testing.T) {
+	noop := func(transform.FieldLevel) error { return nil }
+	exclaim := func(fl transform.FieldLevel) error {
+		transform.SetString(fl, fl.String()+"!")
+		return nil
+	}
+
+	err := transform.RegisterAll(map[string]transform.Func{"registry_exclaim": exclaim})
+	require.NoError(t, err)
+
+	type testStruct struct {
+		Name string `transform:"registry_exclaim"`
+	}
+
+	in := &testStruct{Name: "hello"}
+
+	err = transform.Transform(in)
+	require.NoError(t, err)
+	require.Equal(t, "hello!", in.Name)
+
+	err = transform.RegisterAll(map[string]transform.Func{"registry_other": noop, "trim": noop})
+	require.ErrorIs(t, err, transform.ErrDuplicateFunc)
+
+	err = transform.RegisterAll(map[string]transform.Func{"registry_nil": nil})
+	require.ErrorIs(t, err, transform.ErrInvalidFunc)
+
+	for _, name := range []string{"", "registry,comma", "registry=equals", "dive", "keys", "endkeys", "omitempty"} {
+		err = transform.RegisterAll(map[string]transform.Func{"registry_other": noop, name: noop})
+		require.ErrorIs(t, err, transform.ErrInvalidFunc, name)
+	}
+
+	require.Panics(t, func() { transform.Register("registry,invalid", noop) })
+
+	// no function of a failed table is registered
+	err = transform.RegisterAll(map[string]transform.Func{"registry_other": noop})
+	require.NoError(t, err)
+}
+
+func TestRegisterTransformation(t *testing.T) {
+	slug := func(fl transform.FieldLevel) error {
+		transform.SetString(fl, strings.ReplaceAll(strings.ToLower(fl.String()), " ", "-"))
+		return nil
+	}
+
+	tests := []struct {
+		name string
+		fn   transform.Func
+		err  error
+	}{
+		{name: "slug", fn: slug},
+		{name: "", fn: slug, err: transform.ErrInvalidFunc},
+		{name: "a,b", fn: slug, err: transform.ErrInvalidFunc},
+		{name: "a=b", fn: slug, err: transform.ErrInvalidFunc},
+		{name: "dive", fn: slug, err: transform.ErrInvalidFunc},
+		{name: "nil", err: transform.ErrInvalidFunc},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			trans := transform.New()
+
+			err := trans.RegisterTransformation(tc.name, tc.fn)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+
+			require.NoError(t, err)
+
+			in := &struct {
+				Name string `transform:"trim"`
+				Slug string `transform:"slug"`
+			}{Name: " Hello World ", Slug: "Hello World"}
+
+			err = trans.Transform(in)
+			require.NoError(t, err)
+			require.Equal(t, "hello-world", in.Slug)
+		})
+	}
+
+	// the function is only available to the transformer it is registered with
+	in := &struct {
+		Name string `transform:"trim"`
+		Slug string `transform:"slug"`
+	}{Name: " Hello World ", Slug: "Hello World"}
+
+	err := transform.New().Transform(in)
+	require.NoError(t, err)
+	require.Equal(t, "Hello World", in.Slug)
+
+	// a built-in function is replaced
+	trans := transform.New()
+
+	err = trans.RegisterTransformation("trim", slug)
+	require.NoError(t, err)
+
+	err = trans.Transform(in)
+	require.NoError(t, err)
+	require.Equal(t, "hello-world", in.Name)
+
+	err = transform.New().Freeze().RegisterTransformation("slug", slug)
+	require.ErrorIs(t, err, transform.ErrFrozen)
+}
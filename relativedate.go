@@ -0,0 +1,140 @@
+package transform
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// weekdays are the weekdays of relativedate by their names
+var weekdays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+// WithClock sets the clock of the functions relative to the current time (e.g. relativedate),
+// a fixed time makes them deterministic in tests. The default is time.Now.
+func WithClock(now func() time.Time) TransformerOpt {
+	return func(o *TransformerImpl) {
+		o.now = now
+	}
+}
+
+// clock returns the current time of the clock of the transformer
+func (t *TransformerImpl) clock() time.Time {
+	if t.now == nil {
+		return time.Now()
+	}
+
+	return t.now()
+}
+
+// relativeDateFunc replaces a relative date (e.g. tomorrow, in 3 days, next monday) by the date as
+// RFC 3339 time at midnight (relativedate) or ISO 8601 date (relativedate=iso), other values are unchanged
+func (t *TransformerImpl) relativeDateFunc(fl FieldLevel) error {
+	layout := time.RFC3339
+	if fl.Param() != "" {
+		l, ok := dateOutputs[fl.Param()]
+		if !ok {
+			return fmt.Errorf("%w: relativedate=%s", ErrInvalidParam, fl.Param())
+		}
+
+		layout = l
+	}
+
+	d, ok := relativeDate(t.clock(), fl.String())
+	if !ok {
+		return nil
+	}
+
+	SetString(fl, d.Format(layout))
+
+	return nil
+}
+
+// relativeDate returns the date of the phrase relative to the day of now
+func relativeDate(now time.Time, s string) (time.Time, bool) {
+	words := strings.Fields(strings.ToLower(s))
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	switch len(words) {
+	case 1:
+		switch words[0] {
+		case "today":
+			return today, true
+		case "tomorrow":
+			return today.AddDate(0, 0, 1), true
+		case "yesterday":
+			return today.AddDate(0, 0, -1), true
+		}
+	case 2:
+		sign := 0
+
+		switch words[0] {
+		case "next":
+			sign = 1
+		case "last":
+			sign = -1
+		default:
+			return time.Time{}, false
+		}
+
+		if wd, ok := weekdays[words[1]]; ok {
+			days := (int(wd) - int(today.Weekday()) + 7*sign) % 7
+			if days == 0 {
+				days = 7 * sign
+			}
+
+			return today.AddDate(0, 0, days), true
+		}
+
+		return addUnits(today, sign, words[1])
+	case 3:
+		if words[0] == "in" {
+			return addCount(today, 1, words[1], words[2])
+		}
+
+		if words[2] == "ago" {
+			return addCount(today, -1, words[0], words[1])
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// addCount adds the count (e.g. 3 or a) of units (e.g. days) to the day in the direction of the sign
+func addCount(day time.Time, sign int, count, unit string) (time.Time, bool) {
+	n := 1
+	if count != "a" && count != "an" {
+		var err error
+
+		n, err = strconv.Atoi(count)
+		if err != nil || n < 0 || !isDigits(count) {
+			return time.Time{}, false
+		}
+	}
+
+	return addUnits(day, sign*n, unit)
+}
+
+// addUnits adds n units (day, week, month or year) to the day
+func addUnits(day time.Time, n int, unit string) (time.Time, bool) {
+	switch strings.TrimSuffix(unit, "s") {
+	case "day":
+		return day.AddDate(0, 0, n), true
+	case "week":
+		return day.AddDate(0, 0, 7*n), true
+	case "month":
+		return day.AddDate(0, n, 0), true
+	case "year":
+		return day.AddDate(n, 0, 0), true
+	}
+
+	return time.Time{}, false
+}
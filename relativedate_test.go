@@ -0,0 +1,70 @@
+package transform_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/zeiss/go-transform"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRelativeDate(t *testing.T) {
+	type testStruct struct {
+		Due  string  `transform:"trim,relativedate"`
+		Day  string  `transform:"relativedate=iso"`
+		Next *string `transform:"relativedate=iso"`
+	}
+
+	// Wednesday
+	now := time.Date(2024, time.January, 31, 15, 4, 5, 0, time.UTC)
+	trans := transform.New(transform.WithClock(func() time.Time { return now }))
+
+	tests := []struct {
+		in  string
+		out string
+	}{
+		{in: "", out: ""},
+		{in: "today", out: "2024-01-31"},
+		{in: "Tomorrow", out: "2024-02-01"},
+		{in: "yesterday", out: "2024-01-30"},
+		{in: "in 3 days", out: "2024-02-03"},
+		{in: "in a week", out: "2024-02-07"},
+		{in: "2 weeks ago", out: "2024-01-17"},
+		{in: "in 1 year", out: "2025-01-31"},
+		{in: "next monday", out: "2024-02-05"},
+		{in: "next  Wednesday", out: "2024-02-07"},
+		{in: "last monday", out: "2024-01-29"},
+		{in: "last wednesday", out: "2024-01-24"},
+		{in: "next week", out: "2024-02-07"},
+		{in: "last month", out: "2023-12-31"},
+		{in: "2024-05-01", out: "2024-05-01"},
+		{in: "in -3 days", out: "in -3 days"},
+		{in: "next fortnight", out: "next fortnight"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			in := &testStruct{Day: tt.in}
+			require.NoError(t, trans.Transform(in))
+			require.Equal(t, tt.out, in.Day)
+		})
+	}
+
+	next := "next friday"
+	in := &testStruct{Due: " tomorrow ", Next: &next}
+	require.NoError(t, trans.Transform(in))
+	require.Equal(t, &testStruct{Due: "2024-02-01T00:00:00Z", Next: &[]string{"2024-02-02"}[0]}, in)
+
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	in = &testStruct{Due: "today"}
+	require.NoError(t, transform.New(transform.WithClock(func() time.Time { return now.In(berlin) })).Transform(in))
+	require.Equal(t, "2024-01-31T00:00:00+01:00", in.Due)
+
+	err = transform.Transform(&struct {
+		V string `transform:"relativedate=unix"`
+	}{V: "today"})
+	require.ErrorIs(t, err, transform.ErrInvalidParam)
+}
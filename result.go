@@ -0,0 +1,112 @@
+package transform
+
+import "errors"
+
+// Change is the modification of a string field
+type Change struct {
+	// Path is the Go path of the field (e.g. Addresses[0].City)
+	Path string
+	// Pointer is the JSON pointer of the field using the names of the json tags
+	Pointer string
+	// Old is the value before the transformation
+	Old string
+	// New is the value after the transformation
+	New string
+	// Funcs are the names of the functions that changed the value in the order of their calls,
+	// TransformStruct for a struct-level transformer and the interface type for an interface handler
+	Funcs []string
+	// From is the JSON pointer of the old key if the change renames a map key, Old and New are the keys
+	// and Pointer is the new key. A change of the value of the key is reported separately at the new key.
+	From string
+}
+
+// Result is the report of a transformation
+type Result struct {
+	// Changes are the changed fields in the order of their transformation
+	Changes []Change
+	// Warnings are the problems that did not fail the transformation (e.g. unknown functions)
+	Warnings []string
+	// Errors are the errors of the transformation, isolated elements and collected fields report an error each
+	Errors []error
+	// Unreached are the tagged fields that were not transformed, they are only reported with WithDiagnostics.
+	// The fields of a struct are reported in the order of their declaration, before the fields of its nested structs.
+	Unreached []Unreached
+}
+
+// Ok returns true if the transformation did not fail
+func (r *Result) Ok() bool {
+	return len(r.Errors) == 0
+}
+
+// Err returns the errors of the transformation joined, or nil
+func (r *Result) Err() error {
+	return errors.Join(r.Errors...)
+}
+
+// Changed returns true if the field at the Go path (e.g. Email or Addresses[0].City) changed
+func (r *Result) Changed(path string) bool {
+	for _, c := range r.Changes {
+		if c.Path == path {
+			return true
+		}
+	}
+
+	return false
+}
+
+// public returns the change as Change
+func (c change) public() Change {
+	ch := Change{Path: c.loc.path, Pointer: c.loc.pointer, Old: c.old, New: c.new, Funcs: c.funcs}
+	if c.from != nil {
+		ch.From = c.from.pointer
+	}
+
+	return ch
+}
+
+// TransformWithReport transforms the struct and reports the changed fields, warnings and errors
+func (t *TransformerImpl) TransformWithReport(s interface{}) *Result {
+	r := &Result{}
+
+	ifv, err := structValue(s)
+	if err != nil {
+		r.Errors = append(r.Errors, err)
+		return r
+	}
+
+	if !ifv.IsValid() {
+		return r // bail out of if this nil
+	}
+
+	st := newState()
+	st.track = true
+	st.diagnose = t.diagnostics
+	st.guard = true
+
+	err = t.transform(st, ifv)
+
+	for _, c := range st.changes {
+		r.Changes = append(r.Changes, c.public())
+	}
+
+	r.Warnings = st.warnings
+	r.Unreached = st.unreached
+
+	var (
+		errs  ElementErrors
+		ferrs TransformErrors
+	)
+
+	switch {
+	case errors.As(err, &errs):
+		for _, e := range errs {
+			r.Errors = append(r.Errors, e)
+		}
+	case errors.As(err, &ferrs):
+		r.Errors = append(r.Errors, ferrs...)
+	case err != nil:
+		r.Errors = append(r.Errors, err)
+	}
+
+	return r
+}
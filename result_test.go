@@ -0,0 +1,58 @@
+package transform_test
+
+import (
+	"testing"
+
+	"github.com/zeiss/go-transform"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransformWithReport(t *testing.T) {
+	type address struct {
+		City string `json:"city" transform:"trim"`
+	}
+
+	type testStruct struct {
+		Email     string     `json:"email" transform:"trim,lowercase"`
+		Name      string     `json:"name" transform:"trim"`
+		Nickname  string     `transform:"unknown"`
+		Addresses []*address `json:"addresses"`
+	}
+
+	in := &testStruct{
+		Email:     " Foo@Example.com",
+		Name:      "john",
+		Addresses: []*address{{City: " Jena "}},
+	}
+
+	r := transform.New().TransformWithReport(in)
+	require.True(t, r.Ok())
+	require.NoError(t, r.Err())
+
+	require.True(t, r.Changed("Email"))
+	require.False(t, r.Changed("Name"))
+	require.True(t, r.Changed("Addresses[0].City"))
+
+	require.Equal(t, []transform.Change{
+		{Path: "Email", Pointer: "/email", Old: " Foo@Example.com", New: "foo@example.com", Funcs: []string{"trim", "lowercase"}},
+		{Path: "Addresses[0].City", Pointer: "/addresses/0/city", Old: " Jena ", New: "Jena", Funcs: []string{"trim"}},
+	}, r.Changes)
+
+	require.Equal(t, []string{`Nickname: unknown function "unknown"`}, r.Warnings)
+}
+
+func TestTransformWithReportErrors(t *testing.T) {
+	r := transform.New().TransformWithReport("no pointer")
+	require.False(t, r.Ok())
+	require.ErrorIs(t, r.Err(), transform.ErrNoPointer)
+
+	trans := transform.New(transform.WithIsolatedElements())
+
+	in := &elementBatch{Docs: []*elementDoc{{Payload: "{"}, {Name: " a "}, {Payload: "["}}}
+
+	r = trans.TransformWithReport(in)
+	require.False(t, r.Ok())
+	require.Len(t, r.Errors, 2)
+	require.True(t, r.Changed("Docs[1].Name"))
+}
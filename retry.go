@@ -0,0 +1,105 @@
+package transform
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrRetryable marks errors of transform functions that may succeed when retried
+var ErrRetryable = newError("transformer: retryable error")
+
+// retryableError wraps an error as retryable
+type retryableError struct {
+	err error
+}
+
+// Error implements the error interface
+func (e *retryableError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the wrapped error and ErrRetryable
+func (e *retryableError) Unwrap() []error {
+	return []error{e.err, ErrRetryable}
+}
+
+// Retryable marks the error as retryable, transform functions return it
+// for transient failures (e.g. a timeout of an enrichment lookup)
+func Retryable(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &retryableError{err}
+}
+
+// RetryPolicy configures the retries of transform functions
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first one
+	MaxAttempts int
+	// InitialBackoff is the backoff before the first retry
+	InitialBackoff time.Duration
+	// MaxBackoff caps the backoff, zero means no cap
+	MaxBackoff time.Duration
+	// Multiplier increases the backoff after each retry, values below 1 are treated as 1
+	Multiplier float64
+}
+
+// DefaultRetryPolicy retries three times with an exponential backoff starting at 100ms
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: 100 * time.Millisecond,
+	MaxBackoff:     time.Second,
+	Multiplier:     2,
+}
+
+// Do calls fn until it succeeds, returns an error that is not retryable,
+// the attempts are exhausted or the context is done.
+func (p RetryPolicy) Do(ctx context.Context, fn func() error) error {
+	backoff := p.InitialBackoff
+
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || !errors.Is(err, ErrRetryable) || attempt >= p.MaxAttempts {
+			return err
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return errors.Join(err, ctx.Err())
+		case <-timer.C:
+		}
+
+		backoff = p.next(backoff)
+	}
+}
+
+// next returns the backoff after the given one
+func (p RetryPolicy) next(backoff time.Duration) time.Duration {
+	if p.Multiplier > 1 {
+		backoff = time.Duration(float64(backoff) * p.Multiplier)
+	}
+
+	if p.MaxBackoff > 0 && backoff > p.MaxBackoff {
+		backoff = p.MaxBackoff
+	}
+
+	return backoff
+}
+
+// WithRetry retries the named transform functions with the policy when they return
+// a retryable error. Functions opt in by name, all other functions are never retried.
+func WithRetry(policy RetryPolicy, funcs ...string) TransformerOpt {
+	return func(o *TransformerImpl) {
+		if o.retries == nil {
+			o.retries = make(map[string]RetryPolicy, len(funcs))
+		}
+
+		for _, name := range funcs {
+			o.retries[name] = policy
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package transform_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/zeiss/go-transform"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryPolicy(t *testing.T) {
+	errLookup := errors.New("lookup failed")
+
+	policy := transform.RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		Multiplier:     2,
+	}
+
+	tests := []struct {
+		name     string
+		errs     []error
+		attempts int
+		err      error
+	}{
+		{
+			name:     "success",
+			errs:     []error{nil},
+			attempts: 1,
+		},
+		{
+			name:     "retried",
+			errs:     []error{transform.Retryable(errLookup), nil},
+			attempts: 2,
+		},
+		{
+			name:     "exhausted",
+			errs:     []error{transform.Retryable(errLookup), transform.Retryable(errLookup), transform.Retryable(errLookup)},
+			attempts: 3,
+			err:      errLookup,
+		},
+		{
+			name:     "not retryable",
+			errs:     []error{errLookup},
+			attempts: 1,
+			err:      errLookup,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+
+			err := policy.Do(context.Background(), func() error {
+				err := tt.errs[attempts]
+				attempts++
+
+				return err
+			})
+
+			require.Equal(t, tt.attempts, attempts)
+
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestRetryPolicyCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	policy := transform.RetryPolicy{MaxAttempts: 5, InitialBackoff: time.Hour}
+
+	err := policy.Do(ctx, func() error {
+		return transform.Retryable(errors.New("lookup failed"))
+	})
+	require.ErrorIs(t, err, context.Canceled)
+	require.ErrorIs(t, err, transform.ErrRetryable)
+}
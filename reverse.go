@@ -0,0 +1,135 @@
+package transform
+
+import (
+	"fmt"
+	"strings"
+)
+
+// inverseTransformers are the pairs of built-in functions reverting each other
+var inverseTransformers = map[string]string{
+	"b64enc":     "b64dec",
+	"b64dec":     "b64enc",
+	"encrypt":    "decrypt",
+	"decrypt":    "encrypt",
+	"tokenize":   "detokenize",
+	"detokenize": "tokenize",
+}
+
+// WithInversePair adds two transform functions reverting each other to the transformer,
+// Reverse replaces each function of a tag by its inverse
+func WithInversePair(name string, fn Func, inverse string, inv Func) TransformerOpt {
+	return func(o *TransformerImpl) {
+		WithTransformation(name, fn)(o)
+		WithTransformation(inverse, inv)(o)
+
+		o.mu.Lock()
+		defer o.mu.Unlock()
+
+		o.addInverse(name, inverse)
+	}
+}
+
+// RegisterInversePair adds two transform functions reverting each other to the transformer,
+// the names must be valid names of RegisterTransformation.
+// It returns ErrFrozen if the transformer is frozen.
+func (t *TransformerImpl) RegisterInversePair(name string, fn Func, inverse string, inv Func) error {
+	for _, f := range []struct {
+		name string
+		fn   Func
+	}{{name, fn}, {inverse, inv}} {
+		if err := validFunc(f.name, f.fn); err != nil {
+			return err
+		}
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.frozen {
+		return ErrFrozen
+	}
+
+	if t.funcs == nil {
+		t.funcs = make(map[string]Func)
+	}
+
+	t.funcs[name] = fn
+	t.funcs[inverse] = inv
+	t.addInverse(name, inverse)
+
+	return nil
+}
+
+// addInverse records the functions as inverse of each other, the lock must be held
+func (t *TransformerImpl) addInverse(name, inverse string) {
+	if t.inverses == nil {
+		t.inverses = make(map[string]string)
+	}
+
+	t.inverses[name] = inverse
+	t.inverses[inverse] = name
+}
+
+// inverse returns the name of the function reverting the named function
+func (t *TransformerImpl) inverse(name string) (string, bool) {
+	if !t.frozen {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+	}
+
+	if inv, ok := t.inverses[name]; ok {
+		return inv, true
+	}
+
+	if _, ok := t.funcs[name]; ok {
+		return "", false // a replaced built-in function has no inverse
+	}
+
+	inv, ok := inverseTransformers[name]
+
+	return inv, ok
+}
+
+// Reverse reverts the transformation of the struct (e.g. on the read path of stored models),
+// the functions of a tag are replaced by their inverse and run from right to left.
+// Functions without an inverse (e.g. trim) are skipped, the program of WithProgram is not run.
+func (t *TransformerImpl) Reverse(s interface{}) error {
+	ifv, err := structValue(s)
+	if err != nil {
+		return err
+	}
+
+	if !ifv.IsValid() {
+		return nil // bail out of if this nil
+	}
+
+	st := newState()
+	st.reverse = true
+
+	return t.transform(st, ifv)
+}
+
+// reverseField runs the inverse functions of the field from right to left
+func (t *TransformerImpl) reverseField(field FieldLevel) error {
+	funcs, _ := omitEmpty(field.Funcs())
+
+	for i := len(funcs) - 1; i >= 0; i-- {
+		name, param, _ := strings.Cut(funcs[i], "=")
+
+		inv, ok := t.inverse(name)
+		if !ok {
+			continue // the function can not be reverted
+		}
+
+		fn, ok := t.lookup(inv)
+		if !ok {
+			return &UnknownFuncError{Path: field.Path(), Name: inv}
+		}
+
+		if err := t.call(inv, fn, withParam(field, param)); err != nil {
+			return t.fieldError(field, inv, fmt.Errorf("reverting %s: %w", name, err))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+package transform_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/zeiss/go-transform"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReverse(t *testing.T) {
+	rot13 := func(fl transform.FieldLevel) error {
+		transform.SetString(fl, strings.Map(func(r rune) rune {
+			switch {
+			case r >= 'a' && r <= 'z':
+				return 'a' + (r-'a'+13)%26
+			case r >= 'A' && r <= 'Z':
+				return 'A' + (r-'A'+13)%26
+			}
+
+			return r
+		}, fl.String()))
+
+		return nil
+	}
+
+	type address struct {
+		Street string `transform:"trim,tokenize=v"`
+	}
+
+	type testStruct struct {
+		Name     string   `transform:"trim,lowercase,b64enc"`
+		Secret   *string  `transform:"encrypt=k,b64enc"`
+		Note     string   `transform:"rot13"`
+		Tags     []string `transform:"dive,b64enc"`
+		Address  address
+		Computed string `transform:"trim"`
+	}
+
+	trans := transform.New(
+		transform.WithEncryptionKey("k", []byte("0123456789abcdef")),
+		transform.WithVault("v", &mapVault{tokens: map[string]string{}}),
+		transform.WithInversePair("rot13", rot13, "unrot13", rot13),
+	)
+
+	secret := "secret"
+
+	in := &testStruct{
+		Name:     " John ",
+		Secret:   &secret,
+		Note:     "Hello",
+		Tags:     []string{"a", "b"},
+		Address:  address{Street: " Main St "},
+		Computed: " computed ",
+	}
+
+	err := trans.Transform(in)
+	require.NoError(t, err)
+	require.Equal(t, "am9obg==", in.Name)
+	require.NotEqual(t, "secret", *in.Secret)
+	require.Equal(t, "Uryyb", in.Note)
+	require.Equal(t, []string{"YQ==", "Yg=="}, in.Tags)
+	require.Equal(t, "tok_main st", in.Address.Street)
+
+	err = trans.Reverse(in)
+	require.NoError(t, err)
+	require.Equal(t, "john", in.Name)
+	require.Equal(t, "secret", *in.Secret)
+	require.Equal(t, "Hello", in.Note)
+	require.Equal(t, []string{"a", "b"}, in.Tags)
+	require.Equal(t, "Main St", in.Address.Street)
+	require.Equal(t, "computed", in.Computed)
+}
+
+func TestReverseErrors(t *testing.T) {
+	type testStruct struct {
+		Name string `json:"name" transform:"trim,b64enc"`
+	}
+
+	err := transform.New().Reverse(&testStruct{Name: "%"})
+	require.ErrorIs(t, err, transform.ErrTransform)
+
+	var ferr *transform.FieldError
+	require.ErrorAs(t, err, &ferr)
+	require.Equal(t, "b64dec", ferr.Func)
+	require.Equal(t, "/name", ferr.Pointer)
+
+	err = transform.New().Reverse(testStruct{})
+	require.ErrorIs(t, err, transform.ErrNoPointer)
+
+	// a replaced built-in function has no inverse
+	in := &testStruct{Name: "x"}
+
+	err = transform.New(transform.WithTransformation("b64enc", func(transform.FieldLevel) error { return nil })).Reverse(in)
+	require.NoError(t, err)
+	require.Equal(t, "x", in.Name)
+}
+
+func TestRegisterInversePair(t *testing.T) {
+	upper := func(fl transform.FieldLevel) error {
+		transform.SetString(fl, strings.ToUpper(fl.String()))
+		return nil
+	}
+
+	lower := func(fl transform.FieldLevel) error {
+		transform.SetString(fl, strings.ToLower(fl.String()))
+		return nil
+	}
+
+	trans := transform.New()
+
+	err := trans.RegisterInversePair("shout", upper, "whisper", lower)
+	require.NoError(t, err)
+
+	in := &struct {
+		Name string `transform:"shout"`
+	}{Name: "hello"}
+
+	err = trans.Transform(in)
+	require.NoError(t, err)
+	require.Equal(t, "HELLO", in.Name)
+
+	err = trans.Reverse(in)
+	require.NoError(t, err)
+	require.Equal(t, "hello", in.Name)
+
+	err = trans.RegisterInversePair("a,b", upper, "c", lower)
+	require.ErrorIs(t, err, transform.ErrInvalidFunc)
+
+	err = trans.Freeze().RegisterInversePair("x", upper, "y", lower)
+	require.ErrorIs(t, err, transform.ErrFrozen)
+}
@@ -0,0 +1,200 @@
+package transform
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// Rule is the effective transformation rule of a field
+type Rule struct {
+	// Path is the path of the field, elements of slices and arrays and the values of maps are denoted by [],
+	// the keys of maps by {}
+	Path string `json:"path"`
+	// JSONPath is the path of the field using the names of the json tags
+	JSONPath string `json:"jsonPath"`
+	// Type is the Go type of the field
+	Type string `json:"type"`
+	// Funcs are the transform functions applied to the field in order
+	Funcs []string `json:"funcs"`
+}
+
+// Rules returns the transformation rules of the type of the sample,
+// which is a struct or a (nil) pointer to a struct.
+func (t *TransformerImpl) Rules(sample interface{}) ([]Rule, error) {
+	typ := reflect.TypeOf(sample)
+	if typ == nil {
+		return nil, ErrNoStruct
+	}
+
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	if typ.Kind() != reflect.Struct {
+		return nil, &KindError{Kind: typ.Kind(), Err: ErrNoStruct}
+	}
+
+	rules := []Rule{}
+	t.rules(typ, "", "", map[reflect.Type]bool{}, &rules)
+
+	return rules, nil
+}
+
+// ExportRules returns the transformation rules of the type of the sample as JSON
+func (t *TransformerImpl) ExportRules(sample interface{}) ([]byte, error) {
+	rules, err := t.Rules(sample)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(rules)
+}
+
+// rules collects the rules of the struct type, visiting guards against recursive types
+func (t *TransformerImpl) rules(typ reflect.Type, path, jsonPath string, visiting map[reflect.Type]bool, rules *[]Rule) {
+	if visiting[typ] {
+		return
+	}
+
+	visiting[typ] = true
+	defer delete(visiting, typ)
+
+	for i := 0; i < typ.NumField(); i++ {
+		ft := typ.Field(i)
+
+		tag := t.fieldTag(ft)
+		if tag == "-" || (!ft.IsExported() && !t.promoted(ft)) || t.skipType(ft.Type) {
+			continue
+		}
+
+		fp := joinPath(path, ft.Name)
+
+		jp := joinPath(jsonPath, t.nameOf(ft))
+		if t.promoted(ft) {
+			jp = jsonPath
+		}
+
+		et := ft.Type
+		if et.Kind() == reflect.Ptr {
+			et = et.Elem()
+		}
+
+		// nolint:exhaustive
+		switch et.Kind() {
+		case reflect.String:
+			if tag != "" {
+				*rules = append(*rules, Rule{Path: fp, JSONPath: jp, Type: ft.Type.String(), Funcs: funcsOf(tag)})
+			}
+		case reflect.Struct:
+			t.rules(et, fp, jp, visiting, rules)
+		case reflect.Slice, reflect.Array:
+			et = et.Elem()
+			if et.Kind() == reflect.Ptr {
+				et = et.Elem()
+			}
+
+			if et.Kind() == reflect.Struct && !t.skipType(et) {
+				t.rules(et, fp+"[]", jp+"[]", visiting, rules)
+			}
+
+			if funcs, ok := diveFuncs(tag); ok && funcs != "" && et.Kind() == reflect.String {
+				*rules = append(*rules, Rule{Path: fp + "[]", JSONPath: jp + "[]", Type: ft.Type.String(), Funcs: funcsOf(funcs)})
+			}
+		case reflect.Map:
+			t.mapRules(ft, et, fp, jp, tag, rules)
+		default:
+			if _, ok := t.enum(et); ok && tag != "" {
+				*rules = append(*rules, Rule{Path: fp, JSONPath: jp, Type: ft.Type.String(), Funcs: funcsOf(tag)})
+			}
+		}
+	}
+}
+
+// mapRules collects the rules of the keys and string values of a map with string keys handled by dive
+func (t *TransformerImpl) mapRules(ft reflect.StructField, typ reflect.Type, path, jsonPath, tag string, rules *[]Rule) {
+	funcs, ok := diveFuncs(tag)
+	if !ok || typ.Key().Kind() != reflect.String {
+		return
+	}
+
+	keyFuncs, valueFuncs, err := mapFuncs(funcs)
+	if err != nil {
+		return // reported when the map is transformed
+	}
+
+	if keyFuncs != "" {
+		*rules = append(*rules, Rule{Path: path + "{}", JSONPath: jsonPath + "{}", Type: ft.Type.String(), Funcs: funcsOf(keyFuncs)})
+	}
+
+	et := typ.Elem()
+	if et.Kind() == reflect.Ptr {
+		et = et.Elem()
+	}
+
+	if valueFuncs != "" && et.Kind() == reflect.String {
+		*rules = append(*rules, Rule{Path: path + "[]", JSONPath: jsonPath + "[]", Type: ft.Type.String(), Funcs: funcsOf(valueFuncs)})
+	}
+}
+
+// nameTag is the default tag naming the fields in JSON pointers
+const nameTag = "json"
+
+// WithNameTag names the fields in JSON pointers, FieldError.JSON and Rule.JSONPath by the struct tag
+// (e.g. bson for documents stored in MongoDB), the default is the json tag
+func WithNameTag(tag string) TransformerOpt {
+	return func(o *TransformerImpl) {
+		o.nameTag = tag
+	}
+}
+
+// nameOf returns the name of the field in the name tag or the field name
+func (t *TransformerImpl) nameOf(ft reflect.StructField) string {
+	return t.nameOfField(fieldLevel{field: ft})
+}
+
+// nameOfField returns the name of the field in the name tag or the field name,
+// a nil transformer uses the json tag for the locations of the conditions of a program
+func (t *TransformerImpl) nameOfField(fl FieldLevel) string {
+	name, _, _ := strings.Cut(fl.Tag(t.nameTagName()), ",")
+	if name == "" || name == "-" {
+		return fl.FieldName()
+	}
+
+	return name
+}
+
+// nameTagName returns the name of the tag naming the fields
+func (t *TransformerImpl) nameTagName() string {
+	if t != nil && t.nameTag != "" {
+		return t.nameTag
+	}
+
+	return nameTag
+}
+
+// promoted returns true if the field embeds a struct or a pointer to a struct without a name in the name tag,
+// its fields are promoted to the embedding struct as by encoding/json
+func (t *TransformerImpl) promoted(ft reflect.StructField) bool {
+	if !ft.Anonymous {
+		return false
+	}
+
+	typ := ft.Type
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	name, _, _ := strings.Cut(ft.Tag.Get(t.nameTagName()), ",")
+
+	return typ.Kind() == reflect.Struct && name == ""
+}
+
+// locate returns the location of the i-th field of the struct at the location
+func (t *TransformerImpl) locate(loc location, ft reflect.StructField, i int) location {
+	if t.promoted(ft) {
+		return loc.embed(ft.Name, i)
+	}
+
+	return loc.member(ft.Name, t.nameOf(ft), i)
+}
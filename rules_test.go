@@ -0,0 +1,73 @@
+package transform_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/zeiss/go-transform"
+
+	"github.com/stretchr/testify/require"
+)
+
+type ruleNode struct {
+	Name     string      `json:"name" transform:"trim"`
+	Children []*ruleNode `json:"children"`
+}
+
+func TestRules(t *testing.T) {
+	type address struct {
+		City string `json:"city" transform:"trim,uppercase"`
+	}
+
+	type testStruct struct {
+		Email     string    `json:"email" transform:"trim,lowercase"`
+		Nickname  *string   `transform:"trim"`
+		Ignored   string    `transform:"-"`
+		Untagged  string    `json:"untagged"`
+		Address   address   `json:"address"`
+		Addresses []address `json:"addresses,omitempty"`
+		Tree      ruleNode  `json:"tree"`
+		internal  string    `transform:"trim"`
+	}
+
+	rules, err := transform.New().Rules((*testStruct)(nil))
+	require.NoError(t, err)
+	require.Equal(t, []transform.Rule{
+		{Path: "Email", JSONPath: "email", Type: "string", Funcs: []string{"trim", "lowercase"}},
+		{Path: "Nickname", JSONPath: "Nickname", Type: "*string", Funcs: []string{"trim"}},
+		{Path: "Address.City", JSONPath: "address.city", Type: "string", Funcs: []string{"trim", "uppercase"}},
+		{Path: "Addresses[].City", JSONPath: "addresses[].city", Type: "string", Funcs: []string{"trim", "uppercase"}},
+		{Path: "Tree.Name", JSONPath: "tree.name", Type: "string", Funcs: []string{"trim"}},
+	}, rules)
+
+	b, err := transform.New().ExportRules(address{})
+	require.NoError(t, err)
+	require.JSONEq(t, `[{"path":"City","jsonPath":"city","type":"string","funcs":["trim","uppercase"]}]`, string(b))
+
+	_, err = transform.New().Rules("test")
+	require.ErrorIs(t, err, transform.ErrNoStruct)
+}
+
+func TestRulesMapsAndKindDefaults(t *testing.T) {
+	type testStruct struct {
+		Name    string            `json:"name"`
+		Code    string            `json:"code" transform:"uppercase"`
+		Labels  map[string]string `json:"labels" transform:"dive,keys,lowercase,endkeys,trim"`
+		Headers map[string]string `json:"headers" transform:"dive,keys,lowercase,endkeys"`
+		Notes   map[string]string `json:"notes" transform:"dive,trim"`
+		Counts  map[string]int    `json:"counts" transform:"dive,trim"`
+		Broken  map[string]string `json:"broken" transform:"dive,keys,lowercase"`
+		secret  string
+	}
+
+	rules, err := transform.New(transform.WithKindDefaults(reflect.String, "trim")).Rules(testStruct{})
+	require.NoError(t, err)
+	require.Equal(t, []transform.Rule{
+		{Path: "Name", JSONPath: "name", Type: "string", Funcs: []string{"trim"}},
+		{Path: "Code", JSONPath: "code", Type: "string", Funcs: []string{"uppercase"}},
+		{Path: "Labels{}", JSONPath: "labels{}", Type: "map[string]string", Funcs: []string{"lowercase"}},
+		{Path: "Labels[]", JSONPath: "labels[]", Type: "map[string]string", Funcs: []string{"trim"}},
+		{Path: "Headers{}", JSONPath: "headers{}", Type: "map[string]string", Funcs: []string{"lowercase"}},
+		{Path: "Notes[]", JSONPath: "notes[]", Type: "map[string]string", Funcs: []string{"trim"}},
+	}, rules)
+}
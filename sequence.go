@@ -0,0 +1,67 @@
+package transform
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+)
+
+// SequenceProvider allocates monotonically increasing identifiers of named sequences
+// (e.g. a database sequence or a Redis counter), it must be safe for concurrent use
+type SequenceProvider interface {
+	// Next returns the next identifier of the sequence
+	Next(name string) (int64, error)
+}
+
+// WithSequenceProvider sets the provider of sequence=name
+func WithSequenceProvider(p SequenceProvider) TransformerOpt {
+	return func(o *TransformerImpl) {
+		o.sequences = p
+	}
+}
+
+// MemorySequences is a SequenceProvider keeping the sequences in memory, the sequences start at 1.
+// The zero value is ready to use.
+type MemorySequences struct {
+	mu   sync.Mutex
+	last map[string]int64
+}
+
+// Next returns the next identifier of the sequence
+func (m *MemorySequences) Next(name string) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.last == nil {
+		m.last = make(map[string]int64)
+	}
+
+	m.last[name]++
+
+	return m.last[name], nil
+}
+
+// sequenceFunc sets an empty value to the next identifier of the sequence named in the parameter (sequence=orders)
+func (t *TransformerImpl) sequenceFunc(fl FieldLevel) error {
+	name := fl.Param()
+	if name == "" {
+		return fmt.Errorf("%w: sequence requires the name of the sequence", ErrInvalidParam)
+	}
+
+	if t.sequences == nil {
+		return fmt.Errorf("%w: sequence %q", ErrUnknownKey, name)
+	}
+
+	if fl.String() != "" {
+		return nil
+	}
+
+	id, err := t.sequences.Next(name)
+	if err != nil {
+		return err
+	}
+
+	SetString(fl, strconv.FormatInt(id, 10))
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package transform_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/zeiss/go-transform"
+
+	"github.com/stretchr/testify/require"
+)
+
+// failingSequences is a sequence provider failing for every sequence
+type failingSequences struct{}
+
+var errSequence = errors.New("sequence unavailable")
+
+func (failingSequences) Next(string) (int64, error) {
+	return 0, errSequence
+}
+
+func TestSequence(t *testing.T) {
+	type row struct {
+		ID       string `transform:"trim,sequence=orders"`
+		Customer string `transform:"sequence=customers"`
+	}
+
+	trans := transform.New(transform.WithSequenceProvider(&transform.MemorySequences{}))
+
+	rows := []row{{}, {ID: " A-1 "}, {Customer: "c"}}
+	for i := range rows {
+		require.NoError(t, trans.Transform(&rows[i]))
+	}
+
+	require.Equal(t, []row{{ID: "1", Customer: "1"}, {ID: "A-1", Customer: "2"}, {ID: "2", Customer: "c"}}, rows)
+}
+
+func TestSequenceConcurrent(t *testing.T) {
+	type row struct {
+		ID string `transform:"sequence=orders"`
+	}
+
+	trans := transform.New(transform.WithSequenceProvider(&transform.MemorySequences{}))
+
+	rows := make([]row, 100)
+	errs := make([]error, len(rows))
+	wg := sync.WaitGroup{}
+
+	for i := range rows {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = trans.Transform(&rows[i])
+		}(i)
+	}
+
+	wg.Wait()
+
+	ids := map[string]struct{}{}
+	for i, r := range rows {
+		require.NoError(t, errs[i])
+		ids[r.ID] = struct{}{}
+	}
+
+	require.Len(t, ids, len(rows))
+}
+
+func TestSequenceErrors(t *testing.T) {
+	type row struct {
+		ID string `transform:"sequence=orders"`
+	}
+
+	type unnamed struct {
+		ID string `transform:"sequence"`
+	}
+
+	tests := []struct {
+		name  string
+		trans *transform.TransformerImpl
+		in    interface{}
+		err   error
+	}{
+		{name: "no provider", trans: transform.New(), in: &row{}, err: transform.ErrUnknownKey},
+		{name: "no name", trans: transform.New(transform.WithSequenceProvider(&transform.MemorySequences{})), in: &unnamed{}, err: transform.ErrInvalidParam},
+		{name: "provider", trans: transform.New(transform.WithSequenceProvider(failingSequences{})), in: &row{}, err: errSequence},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.ErrorIs(t, tc.trans.Transform(tc.in), tc.err)
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package transform
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// serialFunc reformats a serial number with the pattern of the parameter
+// (serial=AAA-9999-XX turns "abc 1234/x7" into "ABC-1234-X7").
+// Separators of the value are removed and the characters are uppercased,
+// the placeholders of the pattern are X for any letter or digit,
+// A for a letter and 9 for a digit, all other characters are inserted.
+func serialFunc(fl FieldLevel) error {
+	pattern := fl.Param()
+	if strings.IndexFunc(pattern, isSerialPlaceholder) < 0 {
+		return fmt.Errorf("%w: serial=%s", ErrInvalidParam, pattern)
+	}
+
+	s := fl.String()
+	if strings.TrimSpace(s) == "" {
+		return nil
+	}
+
+	chars := []rune(strings.ToUpper(strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+
+		return -1
+	}, s)))
+
+	var b strings.Builder
+
+	i := 0
+
+	for _, p := range pattern {
+		if !isSerialPlaceholder(p) {
+			b.WriteRune(p)
+			continue
+		}
+
+		if i >= len(chars) || !matchSerial(p, chars[i]) {
+			return fmt.Errorf("serial: %q does not match %s", s, pattern)
+		}
+
+		b.WriteRune(chars[i])
+		i++
+	}
+
+	if i != len(chars) {
+		return fmt.Errorf("serial: %q does not match %s", s, pattern)
+	}
+
+	SetString(fl, b.String())
+
+	return nil
+}
+
+// isSerialPlaceholder returns true for the placeholders of a serial pattern
+func isSerialPlaceholder(r rune) bool {
+	return r == 'X' || r == 'A' || r == '9'
+}
+
+// matchSerial returns true if the character matches the placeholder
+func matchSerial(p, r rune) bool {
+	switch p {
+	case 'A':
+		return unicode.IsLetter(r)
+	case '9':
+		return unicode.IsDigit(r)
+	default:
+		return true
+	}
+}
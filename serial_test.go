@@ -0,0 +1,53 @@
+package transform_test
+
+import (
+	"testing"
+
+	"github.com/zeiss/go-transform"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSerial(t *testing.T) {
+	type testStruct struct {
+		Serial string `transform:"serial=AAA-9999-XX"`
+	}
+
+	tests := []struct {
+		in  string
+		out string
+		err bool
+	}{
+		{in: "", out: ""},
+		{in: "abc 1234/x7", out: "ABC-1234-X7"},
+		{in: "ABC-1234-X7", out: "ABC-1234-X7"},
+		{in: " a.b.c.1.2.3.4.9.9 ", out: "ABC-1234-99"},
+		{in: "ab1-1234-x7", err: true},
+		{in: "abc-1234-x", err: true},
+		{in: "abc-1234-x77", err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			in := &testStruct{Serial: tt.in}
+
+			err := transform.Transform(in)
+			if tt.err {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.out, in.Serial)
+		})
+	}
+}
+
+func TestSerialInvalidParam(t *testing.T) {
+	type testStruct struct {
+		Serial string `transform:"serial=--"`
+	}
+
+	err := transform.Transform(&testStruct{Serial: "1"})
+	require.ErrorIs(t, err, transform.ErrInvalidParam)
+}
@@ -0,0 +1,77 @@
+package transform
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ErrCycleDetected is returned in strict mode if a pointer refers to a struct that contains it
+var ErrCycleDetected = newError("transformer: cycle detected")
+
+// WithStrictMode returns an UnknownFuncError when a tag of the struct type references an unknown function.
+// The tags are checked before any field is transformed, so a typo (e.g. lowercsae) fails the transformation
+// without a partially transformed struct, even if the field is not reached (e.g. a nil pointer).
+// The path of the error denotes the elements of slices and the values of maps by [], the keys of maps by {}.
+// It implies WithErrorOnUnknownFunc.
+func WithStrictMode() TransformerOpt {
+	return func(o *TransformerImpl) {
+		o.strict = true
+		o.errorOnUnknown = true
+	}
+}
+
+// checkFuncs returns an UnknownFuncError for the first unknown function in the tags of the struct type,
+// types without unknown functions are cached with the tag name as functions are never removed from a transformer
+func (t *TransformerImpl) checkFuncs(typ reflect.Type) error {
+	key := planKey{typ, t.TagName}
+	if _, ok := t.checked.Load(key); ok {
+		return nil
+	}
+
+	rules := []Rule{}
+	t.rules(typ, "", "", map[reflect.Type]bool{}, &rules)
+
+	for _, r := range rules {
+		funcs, _ := omitEmpty(r.Funcs)
+
+		for _, f := range funcs {
+			if _, ok := bufTransformers[f]; ok {
+				continue
+			}
+
+			name, _, _ := strings.Cut(f, "=")
+			if _, ok := t.lookup(name); !ok && name != "" {
+				return &UnknownFuncError{Path: r.Path, Name: name}
+			}
+		}
+	}
+
+	t.checked.Store(key, struct{}{})
+
+	return nil
+}
+
+// checkCycle returns ErrCycleDetected in strict mode if the struct pointer at the location is being transformed,
+// otherwise the pointer is shared by multiple fields and skipped
+func (t *TransformerImpl) checkCycle(st *state, p pointer, loc location) error {
+	if _, ok := st.active[p]; ok && t.strict {
+		return fmt.Errorf("%w: %s", ErrCycleDetected, loc.path)
+	}
+
+	return nil
+}
+
+// enter marks the struct pointer as being transformed
+func (st *state) enter(p pointer) {
+	if st.active == nil {
+		st.active = make(map[pointer]struct{})
+	}
+
+	st.active[p] = struct{}{}
+}
+
+// leave marks the struct pointer as transformed
+func (st *state) leave(p pointer) {
+	delete(st.active, p)
+}
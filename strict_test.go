@@ -0,0 +1,157 @@
+package transform_test
+
+import (
+	"testing"
+
+	"github.com/zeiss/go-transform"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithStrictMode(t *testing.T) {
+	type address struct {
+		City string `transform:"trim,titel"`
+	}
+
+	type testStruct struct {
+		Name     string   `transform:"trim,lowercase"`
+		Tags     []string `transform:"dive,trim,lowercsae"`
+		Address  *address
+		Previous []address
+	}
+
+	type validStruct struct {
+		Name    string  `transform:"trim,truncate=3"`
+		Comment *string `transform:"squish"`
+	}
+
+	tests := []struct {
+		name string
+		in   interface{}
+		path string
+		fn   string
+	}{
+		{name: "dive", in: &testStruct{Name: " JOHN "}, path: "Tags[]", fn: "lowercsae"},
+		{name: "nil pointer", in: &struct {
+			Address *address
+		}{}, path: "Address.City", fn: "titel"},
+		{name: "slice", in: &struct {
+			Previous []address
+		}{}, path: "Previous[].City", fn: "titel"},
+		{name: "map values", in: &struct {
+			M map[string]string `transform:"dive,trimx"`
+		}{}, path: "M[]", fn: "trimx"},
+		{name: "map keys", in: &struct {
+			M map[string]string `transform:"dive,keys,lowercsae,endkeys,trim"`
+		}{M: map[string]string{}}, path: "M{}", fn: "lowercsae"},
+		{name: "valid", in: &validStruct{Name: " John "}},
+	}
+
+	trans := transform.New(transform.WithStrictMode())
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := trans.Transform(tc.in)
+
+			if tc.fn == "" {
+				require.NoError(t, err)
+				require.Equal(t, "Joh", tc.in.(*validStruct).Name)
+
+				return
+			}
+
+			var uerr *transform.UnknownFuncError
+			require.ErrorAs(t, err, &uerr)
+			require.ErrorIs(t, err, transform.ErrUnknownFunc)
+			require.Equal(t, tc.path, uerr.Path)
+			require.Equal(t, tc.fn, uerr.Name)
+		})
+	}
+}
+
+func TestWithStrictModeUntouched(t *testing.T) {
+	type testStruct struct {
+		Name string `transform:"trim"`
+		City string `transform:"trim,lowercsae"`
+	}
+
+	s := testStruct{Name: " John ", City: " Berlin "}
+
+	err := transform.New(transform.WithStrictMode()).Transform(&s)
+	require.ErrorContains(t, err, `City: "lowercsae"`)
+	require.Equal(t, testStruct{Name: " John ", City: " Berlin "}, s)
+}
+
+func TestWithStrictModeRegistered(t *testing.T) {
+	type testStruct struct {
+		Name string `transform:"slug"`
+	}
+
+	trans := transform.New(transform.WithStrictMode())
+
+	err := trans.Transform(&testStruct{})
+	require.ErrorIs(t, err, transform.ErrUnknownFunc)
+
+	err = trans.RegisterTransformation("slug", func(fl transform.FieldLevel) error { return nil })
+	require.NoError(t, err)
+
+	err = trans.Transform(&testStruct{})
+	require.NoError(t, err)
+}
+
+type node struct {
+	Name     string `transform:"trim,uppercase"`
+	Next     *node
+	Children []*node
+}
+
+func TestCycle(t *testing.T) {
+	cyclic := func() *node {
+		root := &node{Name: " a "}
+		child := &node{Name: " b ", Next: root}
+		root.Children = []*node{child}
+		root.Next = child
+
+		return root
+	}
+
+	shared := func() *node {
+		leaf := &node{Name: " c "}
+
+		return &node{Name: " a ", Next: leaf, Children: []*node{leaf}}
+	}
+
+	tests := []struct {
+		name string
+		opts []transform.TransformerOpt
+		in   *node
+		next string
+		err  string
+	}{
+		{name: "cycle", in: cyclic(), next: "B"},
+		{name: "strict cycle", opts: []transform.TransformerOpt{transform.WithStrictMode()}, in: cyclic(), err: "Next.Next"},
+		{name: "strict self", opts: []transform.TransformerOpt{transform.WithStrictMode()}, in: func() *node {
+			n := &node{Name: " a "}
+			n.Next = n
+
+			return n
+		}(), err: "Next"},
+		{name: "strict shared", opts: []transform.TransformerOpt{transform.WithStrictMode()}, in: shared(), next: "C"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := transform.New(tc.opts...).Transform(tc.in)
+			if tc.err != "" {
+				require.ErrorIs(t, err, transform.ErrCycleDetected)
+				require.ErrorContains(t, err, ": "+tc.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, "A", tc.in.Name)
+			require.Equal(t, tc.next, tc.in.Next.Name)
+		})
+	}
+}
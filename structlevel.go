@@ -0,0 +1,195 @@
+package transform
+
+import (
+	"context"
+	"reflect"
+	"sort"
+)
+
+// StructTransformer is implemented by pointers to structs with a custom transformation (e.g. syncing Slug from Title),
+// TransformStruct is called after the fields of the struct have been transformed by their tags.
+// TransformMasked and MergeInto only call it if the struct itself is selected (e.g. by the path of a nested struct).
+type StructTransformer interface {
+	TransformStruct(sl StructLevel) error
+}
+
+// structTransformerType is the type of StructTransformer
+var structTransformerType = reflect.TypeOf((*StructTransformer)(nil)).Elem()
+
+// StructLevel is the struct being transformed by its TransformStruct method
+type StructLevel interface {
+	// Current returns the addressable struct
+	Current() reflect.Value
+	// Path returns the Go path of the struct, it is empty for the transformed value
+	Path() string
+	// Context returns the context of TransformCtx or context.Background()
+	Context() context.Context
+	// Transformer returns the transformer, e.g. to transform other values of the struct
+	Transformer() *TransformerImpl
+}
+
+// structLevel implements StructLevel
+type structLevel struct {
+	t   *TransformerImpl
+	v   reflect.Value
+	loc location
+	ctx context.Context
+}
+
+// Current returns the addressable struct
+func (sl structLevel) Current() reflect.Value {
+	return sl.v
+}
+
+// Path returns the Go path of the struct
+func (sl structLevel) Path() string {
+	return sl.loc.path
+}
+
+// Context returns the context of the transformation
+func (sl structLevel) Context() context.Context {
+	if sl.ctx == nil {
+		return context.Background()
+	}
+
+	return sl.ctx
+}
+
+// Transformer returns the transformer
+func (sl structLevel) Transformer() *TransformerImpl {
+	return sl.t
+}
+
+// transformStructLevel calls the TransformStruct method of the struct, its error is reported as FieldError
+// of the struct with the function TransformStruct and collected with WithCollectErrors
+func (t *TransformerImpl) transformStructLevel(st *state, v reflect.Value, loc location) error {
+	s, ok := v.Addr().Interface().(StructTransformer)
+	if !ok || !st.include(fieldLevel{loc: loc}) {
+		return nil
+	}
+
+	var before []leaf
+	if st.track {
+		before = t.leaves(v, loc)
+	}
+
+	err := s.TransformStruct(structLevel{t: t, v: v, loc: loc, ctx: st.ctx})
+	if st.track {
+		st.diff(before, t.leaves(v, loc), "TransformStruct")
+	}
+
+	if err == nil {
+		return nil
+	}
+
+	err = &FieldError{Path: loc.path, Pointer: loc.pointer, Func: "TransformStruct", Err: err}
+	if t.collectErrors {
+		st.errs = append(st.errs, err)
+		return nil
+	}
+
+	return err
+}
+
+// leaf is a string value reachable from a struct written by a struct-level transformer or interface handler
+type leaf struct {
+	loc   location
+	value string
+}
+
+// leaves returns the string values reachable from the value in the order of the fields,
+// they are compared before and after functions that may write any field of the value
+func (t *TransformerImpl) leaves(v reflect.Value, loc location) []leaf {
+	var leaves []leaf
+
+	t.collectLeaves(v, loc, map[pointer]struct{}{}, &leaves)
+
+	return leaves
+}
+
+// collectLeaves appends the string values reachable from the value, visited guards against cycles
+// nolint:gocyclo
+func (t *TransformerImpl) collectLeaves(v reflect.Value, loc location, visited map[pointer]struct{}, leaves *[]leaf) {
+	// nolint:exhaustive
+	switch v.Kind() {
+	case reflect.String:
+		*leaves = append(*leaves, leaf{loc: loc, value: v.String()})
+	case reflect.Ptr:
+		if v.IsNil() {
+			return
+		}
+
+		p := pointer{v.Pointer(), v.Type()}
+		if _, ok := visited[p]; ok {
+			return
+		}
+
+		visited[p] = struct{}{}
+
+		t.collectLeaves(v.Elem(), loc, visited, leaves)
+	case reflect.Interface:
+		if !v.IsNil() {
+			t.collectLeaves(v.Elem(), loc, visited, leaves)
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			ft := v.Type().Field(i)
+			if (!ft.IsExported() && !t.promoted(ft)) || t.skipType(ft.Type) {
+				continue
+			}
+
+			t.collectLeaves(v.Field(i), t.locate(loc, ft, i), visited, leaves)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			t.collectLeaves(v.Index(i), loc.element(i), visited, leaves)
+		}
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return
+		}
+
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+
+		for _, k := range keys {
+			t.collectLeaves(v.MapIndex(k), loc.key(k.String()), visited, leaves)
+		}
+	}
+}
+
+// diff records the string values changed by the function, a value already changed
+// by the functions of its tag is updated so every value has a single change
+func (st *state) diff(before, after []leaf, fn string) {
+	old := make(map[string]string, len(before))
+	for _, l := range before {
+		old[l.loc.path] = l.value
+	}
+
+	for _, l := range after {
+		prev := old[l.loc.path]
+		if prev == l.value {
+			continue
+		}
+
+		if c := st.lastChange(l.loc.path); c != nil && c.from == nil {
+			c.new = l.value
+			c.funcs = append(c.funcs, fn)
+
+			continue
+		}
+
+		st.changes = append(st.changes, change{loc: l.loc, old: prev, new: l.value, funcs: []string{fn}})
+	}
+}
+
+// lastChange returns the last recorded change of the value at the path
+func (st *state) lastChange(path string) *change {
+	for i := len(st.changes) - 1; i >= 0; i-- {
+		if st.changes[i].loc.path == path {
+			return &st.changes[i]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,121 @@
+package transform_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/zeiss/go-transform"
+
+	"github.com/stretchr/testify/require"
+)
+
+type article struct {
+	Title string `transform:"squish"`
+	Slug  string
+}
+
+// TransformStruct sets the slug of the transformed title
+func (a *article) TransformStruct(sl transform.StructLevel) error {
+	if a.Title == "" {
+		return errors.New("title is required")
+	}
+
+	if a.Slug == "" {
+		a.Slug = strings.ToLower(strings.ReplaceAll(a.Title, " ", "-"))
+	}
+
+	if prefix, ok := sl.Context().Value(tenantKey{}).(string); ok {
+		a.Slug = prefix + "/" + a.Slug
+	}
+
+	return nil
+}
+
+type blog struct {
+	Name     string `transform:"trim"`
+	Featured *article
+	Articles []article
+}
+
+func TestStructLevel(t *testing.T) {
+	in := &blog{
+		Name:     " News ",
+		Featured: &article{Title: "  Hello   World "},
+		Articles: []article{{Title: "First  Post"}, {Title: "Second", Slug: "custom"}},
+	}
+
+	require.NoError(t, transform.New().Transform(in))
+	require.Equal(t, &blog{
+		Name:     "News",
+		Featured: &article{Title: "Hello World", Slug: "hello-world"},
+		Articles: []article{{Title: "First Post", Slug: "first-post"}, {Title: "Second", Slug: "custom"}},
+	}, in)
+
+	a := &article{Title: "Hello"}
+	require.NoError(t, transform.New().TransformCtx(context.WithValue(context.Background(), tenantKey{}, "acme"), a))
+	require.Equal(t, "acme/hello", a.Slug)
+
+	compiled, err := transform.New().Compile(blog{})
+	require.NoError(t, err)
+
+	in = &blog{Featured: &article{Title: "A  B"}}
+	require.NoError(t, compiled.Transform(in))
+	require.Equal(t, "a-b", in.Featured.Slug)
+}
+
+func TestStructLevelChanges(t *testing.T) {
+	in := &blog{Featured: &article{Title: " Hello  World "}}
+
+	changes, err := transform.New().Plan(in)
+	require.NoError(t, err)
+	require.Equal(t, transform.Changes{
+		{Path: "Featured.Title", Pointer: "/Featured/Title", Old: " Hello  World ", New: "Hello World", Funcs: []string{"squish"}},
+		{Path: "Featured.Slug", Pointer: "/Featured/Slug", New: "hello-world", Funcs: []string{"TransformStruct"}},
+	}, changes)
+
+	patch, err := transform.New().TransformPatch(in)
+	require.NoError(t, err)
+	require.Equal(t, []transform.PatchOperation{
+		{Op: "replace", Path: "/Featured/Title", Value: "Hello World"},
+		{Op: "replace", Path: "/Featured/Slug", Value: "hello-world"},
+	}, patch)
+
+	// a field changed by its tag and the struct-level transformer has a single change
+	changes, err = transform.New().Plan(&headline{Title: " news "})
+	require.NoError(t, err)
+	require.Equal(t, transform.Changes{
+		{Path: "Title", Pointer: "/Title", Old: " news ", New: "NEWS", Funcs: []string{"trim", "TransformStruct"}},
+	}, changes)
+}
+
+type headline struct {
+	Title string `transform:"trim"`
+}
+
+// TransformStruct sets the title in upper case
+func (h *headline) TransformStruct(transform.StructLevel) error {
+	h.Title = strings.ToUpper(h.Title)
+	return nil
+}
+
+func TestStructLevelError(t *testing.T) {
+	err := transform.New().Transform(&blog{Articles: []article{{Title: "a"}, {}}})
+
+	var ferr *transform.FieldError
+	require.ErrorAs(t, err, &ferr)
+	require.Equal(t, "Articles[1]", ferr.Path)
+	require.Equal(t, "/Articles/1", ferr.Pointer)
+	require.Equal(t, "TransformStruct", ferr.Func)
+	require.EqualError(t, err, "Articles[1]: TransformStruct: title is required")
+
+	err = transform.New().Transform(&article{})
+	require.EqualError(t, err, "TransformStruct: title is required")
+
+	err = transform.New(transform.WithCollectErrors()).Transform(&blog{Featured: &article{}, Articles: []article{{}}})
+
+	var errs transform.TransformErrors
+	require.ErrorAs(t, err, &errs)
+	require.Len(t, errs, 2)
+}
@@ -0,0 +1,102 @@
+package transform
+
+import "strings"
+
+// Call is a function of a tag with its parameter (e.g. truncate=64)
+type Call struct {
+	// Name is the name of the function
+	Name string
+	// Param is the parameter of the function, without escapes
+	Param string
+}
+
+// String returns the function and its parameter as name=param
+func (c Call) String() string {
+	if c.Param == "" {
+		return c.Name
+	}
+
+	return c.Name + "=" + c.Param
+}
+
+// parseTag parses the functions of a tag. The functions are separated by commas,
+// commas, equals signs and backslashes in a parameter are escaped by a backslash
+// (e.g. replace=a\,b:c replaces "a,b" by "c").
+func parseTag(tag string) []Call {
+	units := splitTag(tag)
+
+	calls := make([]Call, len(units))
+	for i, u := range units {
+		calls[i] = parseCall(u)
+	}
+
+	return calls
+}
+
+// funcsOf returns the functions of the tag as name=param
+func funcsOf(tag string) []string {
+	calls := parseTag(tag)
+
+	funcs := make([]string, len(calls))
+	for i, c := range calls {
+		funcs[i] = c.String()
+	}
+
+	return funcs
+}
+
+// splitTag splits the tag at the commas not escaped by a backslash, the units keep their escapes
+func splitTag(tag string) []string {
+	if !strings.Contains(tag, `\`) {
+		return strings.Split(tag, ",")
+	}
+
+	var units []string
+
+	start, escaped := 0, false
+
+	for i := 0; i < len(tag); i++ {
+		switch {
+		case escaped:
+			escaped = false
+		case tag[i] == '\\':
+			escaped = true
+		case tag[i] == ',':
+			units = append(units, tag[start:i])
+			start = i + 1
+		}
+	}
+
+	return append(units, tag[start:])
+}
+
+// parseCall splits the unit of a tag at the first equals sign into the name and the unescaped parameter
+func parseCall(unit string) Call {
+	name, param, _ := strings.Cut(unit, "=")
+
+	return Call{Name: name, Param: unescape(param)}
+}
+
+// unescape removes the backslashes escaping a character
+func unescape(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+
+	var b strings.Builder
+
+	escaped := false
+
+	for _, r := range s {
+		if r == '\\' && !escaped {
+			escaped = true
+			continue
+		}
+
+		escaped = false
+
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,58 @@
+package transform_test
+
+import (
+	"testing"
+
+	"github.com/zeiss/go-transform"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTagEscaping(t *testing.T) {
+	type testStruct struct {
+		Replace string `transform:"trim,replace=a\\,b:c"`
+		Equals  string `transform:"replace=x\\=1:y,uppercase"`
+		Slash   string `transform:"replace=\\\\:/"`
+		Format  string `transform:"format={{.Replace}}\\, {{.Equals}}"`
+	}
+
+	in := &testStruct{Replace: " a,b a,b ", Equals: "x=1", Slash: `a\b`}
+
+	err := transform.Transform(in)
+	require.NoError(t, err)
+	require.Equal(t, &testStruct{Replace: "c c", Equals: "Y", Slash: "a/b", Format: "c c, Y"}, in)
+}
+
+func TestCalls(t *testing.T) {
+	var calls []transform.Call
+	var funcs []string
+
+	trans := transform.New(transform.WithTransformation("inspect", func(fl transform.FieldLevel) error {
+		calls = fl.Calls()
+		funcs = fl.Funcs()
+
+		return nil
+	}))
+
+	in := &struct {
+		Name string `transform:"trim,truncate=64,replace=a\\,b:c,inspect"`
+	}{}
+
+	err := trans.Transform(in)
+	require.NoError(t, err)
+	require.Equal(t, []transform.Call{
+		{Name: "trim"},
+		{Name: "truncate", Param: "64"},
+		{Name: "replace", Param: "a,b:c"},
+		{Name: "inspect"},
+	}, calls)
+	require.Equal(t, []string{"trim", "truncate=64", "replace=a,b:c", "inspect"}, funcs)
+	require.Equal(t, "replace=a,b:c", calls[2].String())
+}
+
+func TestReplaceInvalid(t *testing.T) {
+	err := transform.Transform(&struct {
+		Name string `transform:"replace=abc"`
+	}{})
+	require.ErrorIs(t, err, transform.ErrInvalidParam)
+}
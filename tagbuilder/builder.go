@@ -0,0 +1,155 @@
+// Package tagbuilder composes transform tags for structs created with reflect.StructOf or by code generators.
+// The parameters are escaped and the names of the functions are validated, unknown functions are found
+// by a transformer created with transform.WithStrictMode.
+//
+//	tag, err := tagbuilder.New().Func("trim").Param("replace", "a,b:c").StructTag(transform.DefaultTagName)
+package tagbuilder
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/zeiss/go-transform"
+)
+
+// directives of a tag, they are added with Dive, Keys, EndKeys and OmitEmpty
+const (
+	dive      = "dive"
+	keys      = "keys"
+	endKeys   = "endkeys"
+	omitEmpty = "omitempty"
+)
+
+// Builder composes a transform tag, the first error is returned by Build
+type Builder struct {
+	units []string
+	keys  bool
+	err   error
+}
+
+// New returns an empty builder
+func New() *Builder {
+	return &Builder{}
+}
+
+// Func adds a function without a parameter
+func (b *Builder) Func(name string) *Builder {
+	return b.add(name, "", false)
+}
+
+// Param adds a function with a parameter, commas, equals signs and backslashes of the parameter are escaped
+func (b *Builder) Param(name, param string) *Builder {
+	return b.add(name, param, true)
+}
+
+// Dive applies the following functions to the elements of a slice, array or map, it must be the first directive
+func (b *Builder) Dive() *Builder {
+	if len(b.units) > 0 {
+		b.fail(fmt.Errorf("%w: dive must be the first directive", transform.ErrSyntax))
+	}
+
+	b.units = append(b.units, dive)
+
+	return b
+}
+
+// Keys applies the following functions to the keys of a map up to EndKeys, it must directly follow Dive
+func (b *Builder) Keys() *Builder {
+	if len(b.units) != 1 || b.units[0] != dive {
+		b.fail(fmt.Errorf("%w: keys must follow dive", transform.ErrSyntax))
+	}
+
+	b.units = append(b.units, keys)
+	b.keys = true
+
+	return b
+}
+
+// EndKeys ends the functions of the keys of a map
+func (b *Builder) EndKeys() *Builder {
+	if !b.keys {
+		b.fail(fmt.Errorf("%w: endkeys without keys", transform.ErrSyntax))
+	}
+
+	b.units = append(b.units, endKeys)
+	b.keys = false
+
+	return b
+}
+
+// OmitEmpty skips the following functions for empty values, it must be the first directive or directly follow Dive
+func (b *Builder) OmitEmpty() *Builder {
+	if len(b.units) > 1 || (len(b.units) == 1 && b.units[0] != dive) {
+		b.fail(fmt.Errorf("%w: omitempty must be the first directive or follow dive", transform.ErrSyntax))
+	}
+
+	b.units = append(b.units, omitEmpty)
+
+	return b
+}
+
+// Build returns the tag (e.g. trim,replace=a\,b:c) or the first error of the builder
+func (b *Builder) Build() (string, error) {
+	if b.err != nil {
+		return "", b.err
+	}
+
+	if b.keys {
+		return "", fmt.Errorf("%w: keys without endkeys", transform.ErrSyntax)
+	}
+
+	return strings.Join(b.units, ","), nil
+}
+
+// StructTag returns the tag as struct tag with the name (e.g. transform:"trim") for a reflect.StructField
+func (b *Builder) StructTag(name string) (reflect.StructTag, error) {
+	tag, err := b.Build()
+	if err != nil {
+		return "", err
+	}
+
+	return reflect.StructTag(name + ":" + strconv.Quote(tag)), nil
+}
+
+// Escape escapes the commas, equals signs and backslashes of a parameter
+func Escape(param string) string {
+	if !strings.ContainsAny(param, `,=\`) {
+		return param
+	}
+
+	var sb strings.Builder
+
+	for _, r := range param {
+		if r == ',' || r == '=' || r == '\\' {
+			sb.WriteByte('\\')
+		}
+
+		sb.WriteRune(r)
+	}
+
+	return sb.String()
+}
+
+// add adds a function after validating its name
+func (b *Builder) add(name, param string, withParam bool) *Builder {
+	if name == "" || strings.ContainsAny(name, ",=\\\" \t\n") || name == dive || name == keys || name == endKeys || name == omitEmpty {
+		b.fail(fmt.Errorf("%w: invalid name %q", transform.ErrInvalidFunc, name))
+	}
+
+	if withParam {
+		name += "=" + Escape(param)
+	}
+
+	b.units = append(b.units, name)
+
+	return b
+}
+
+// fail records the first error
+func (b *Builder) fail(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
@@ -0,0 +1,65 @@
+package tagbuilder_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/zeiss/go-transform"
+	"github.com/zeiss/go-transform/tagbuilder"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuild(t *testing.T) {
+	tests := []struct {
+		name string
+		b    *tagbuilder.Builder
+		tag  string
+		err  error
+	}{
+		{name: "empty", b: tagbuilder.New(), tag: ""},
+		{name: "funcs", b: tagbuilder.New().Func("trim").Func("lowercase"), tag: "trim,lowercase"},
+		{name: "param", b: tagbuilder.New().Param("truncate", "64"), tag: "truncate=64"},
+		{name: "escaped", b: tagbuilder.New().Param("replace", `a,b=\:c`), tag: `replace=a\,b\=\\:c`},
+		{name: "dive", b: tagbuilder.New().Dive().Keys().Func("lowercase").EndKeys().Func("trim"), tag: "dive,keys,lowercase,endkeys,trim"},
+		{name: "omitempty", b: tagbuilder.New().OmitEmpty().Func("uppercase"), tag: "omitempty,uppercase"},
+		{name: "dive omitempty", b: tagbuilder.New().Dive().OmitEmpty().Func("uppercase"), tag: "dive,omitempty,uppercase"},
+		{name: "late omitempty", b: tagbuilder.New().Func("trim").OmitEmpty(), err: transform.ErrSyntax},
+		{name: "empty name", b: tagbuilder.New().Func(""), err: transform.ErrInvalidFunc},
+		{name: "comma", b: tagbuilder.New().Func("trim,lowercase"), err: transform.ErrInvalidFunc},
+		{name: "directive", b: tagbuilder.New().Func("dive"), err: transform.ErrInvalidFunc},
+		{name: "late dive", b: tagbuilder.New().Func("trim").Dive(), err: transform.ErrSyntax},
+		{name: "keys without dive", b: tagbuilder.New().Keys().EndKeys(), err: transform.ErrSyntax},
+		{name: "open keys", b: tagbuilder.New().Dive().Keys().Func("trim"), err: transform.ErrSyntax},
+		{name: "endkeys without keys", b: tagbuilder.New().Dive().EndKeys(), err: transform.ErrSyntax},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tag, err := tc.b.Build()
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.tag, tag)
+		})
+	}
+}
+
+func TestStructTag(t *testing.T) {
+	tag, err := tagbuilder.New().Func("trim").Param("replace", `a,b:c\d`).StructTag(transform.DefaultTagName)
+	require.NoError(t, err)
+
+	typ := reflect.StructOf([]reflect.StructField{
+		{Name: "Name", Type: reflect.TypeOf(""), Tag: tag},
+	})
+
+	v := reflect.New(typ)
+	v.Elem().Field(0).SetString(` a,b a,b\d `)
+
+	err = transform.New(transform.WithStrictMode()).Transform(v.Interface())
+	require.NoError(t, err)
+	require.Equal(t, `c\d c\d\d`, v.Elem().Field(0).String())
+}
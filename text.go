@@ -0,0 +1,272 @@
+package transform
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+func squishFunc(fl FieldLevel) error {
+	SetString(fl, squish(fl.String()))
+
+	return nil
+}
+
+func stripCtlFunc(fl FieldLevel) error {
+	SetString(fl, stripCtl(fl.String()))
+
+	return nil
+}
+
+func validUTF8Func(fl FieldLevel) error {
+	SetString(fl, strings.ToValidUTF8(fl.String(), "�"))
+
+	return nil
+}
+
+// snakeCaseFunc converts an identifier to snake case (SomeFieldValue becomes some_field_value)
+func snakeCaseFunc(fl FieldLevel) error {
+	SetString(fl, snakeCase(fl.String()))
+
+	return nil
+}
+
+// truncateFunc keeps the first n characters of the value (truncate=64)
+func truncateFunc(fl FieldLevel) error {
+	n, err := strconv.Atoi(fl.Param())
+	if err != nil || n < 0 {
+		return fmt.Errorf("%w: truncate=%s", ErrInvalidParam, fl.Param())
+	}
+
+	SetString(fl, truncate(fl.String(), n))
+
+	return nil
+}
+
+// trimCharsFunc removes the leading and trailing characters of the parameter (trim_chars=*- )
+func trimCharsFunc(fl FieldLevel) error {
+	if fl.Param() == "" {
+		return fmt.Errorf("%w: trim_chars requires the characters to trim", ErrInvalidParam)
+	}
+
+	SetString(fl, strings.Trim(fl.String(), fl.Param()))
+
+	return nil
+}
+
+// padLeftFunc pads the non-empty value on the left with a character to a width (pad_left=0:8),
+// the character defaults to a space (pad_left=8)
+func padLeftFunc(fl FieldLevel) error {
+	pad, width := " ", fl.Param()
+	if i := strings.LastIndex(width, ":"); i >= 0 {
+		pad, width = width[:i], width[i+1:]
+	}
+
+	n, err := strconv.Atoi(width)
+	if err != nil || n < 0 || utf8.RuneCountInString(pad) != 1 {
+		return fmt.Errorf("%w: pad_left=%s", ErrInvalidParam, fl.Param())
+	}
+
+	s := fl.String()
+	if s == "" {
+		return nil // empty values are not padded
+	}
+
+	if l := utf8.RuneCountInString(s); l < n {
+		s = strings.Repeat(pad, n-l) + s
+	}
+
+	SetString(fl, s)
+
+	return nil
+}
+
+// replaceFunc replaces all occurrences of a string by another (replace=old:new),
+// commas in the strings are escaped by a backslash (replace=a\,b:c)
+func replaceFunc(fl FieldLevel) error {
+	old, repl, ok := strings.Cut(fl.Param(), ":")
+	if !ok || old == "" {
+		return fmt.Errorf("%w: replace=%s", ErrInvalidParam, fl.Param())
+	}
+
+	SetString(fl, strings.ReplaceAll(fl.String(), old, repl))
+
+	return nil
+}
+
+// wrapFunc wraps the lines of the value at spaces to at most n characters (wrap=80),
+// words longer than n are kept. Existing line breaks, the spaces between words and the indentation
+// of the lines are kept, wrap=80:reflow joins the lines and squishes the whitespace first.
+func wrapFunc(fl FieldLevel) error {
+	return wrapLines(fl, "wrap", wordWrap)
+}
+
+// hardWrapFunc breaks the lines of the value after n characters (hardwrap=72), also within words.
+// Existing line breaks are kept, hardwrap=72:reflow joins the lines first.
+func hardWrapFunc(fl FieldLevel) error {
+	return wrapLines(fl, "hardwrap", hardWrap)
+}
+
+// wrapLines wraps every line of the value with the function and the width of the parameter
+func wrapLines(fl FieldLevel, name string, wrap func(line string, n int, sep string) string) error {
+	width, mode, _ := strings.Cut(fl.Param(), ":")
+
+	n, err := strconv.Atoi(width)
+	if err != nil || n <= 0 || (mode != "" && mode != "reflow") {
+		return fmt.Errorf("%w: %s=%s", ErrInvalidParam, name, fl.Param())
+	}
+
+	s := fl.String()
+
+	sep := "\n"
+	if strings.Contains(s, "\r\n") {
+		sep = "\r\n"
+	}
+
+	if mode == "reflow" {
+		s = squish(s)
+	}
+
+	lines := strings.Split(s, sep)
+	for i, line := range lines {
+		lines[i] = wrap(line, n, sep)
+	}
+
+	SetString(fl, strings.Join(lines, sep))
+
+	return nil
+}
+
+// wordWrap breaks the line at spaces into lines of at most n characters separated by sep,
+// the spaces between words are kept and the leading whitespace indents every line
+func wordWrap(line string, n int, sep string) string {
+	if utf8.RuneCountInString(line) <= n {
+		return line
+	}
+
+	rest := strings.TrimLeft(line, " \t")
+	indent := line[:len(line)-len(rest)]
+	il := utf8.RuneCountInString(indent)
+
+	b := strings.Builder{}
+	b.WriteString(indent)
+
+	l, spaces, empty := il, 0, true
+
+	for i, w := range strings.Split(rest, " ") {
+		if i > 0 {
+			spaces++
+		}
+
+		if w == "" {
+			continue // repeated spaces are written before the next word
+		}
+
+		wl := utf8.RuneCountInString(w)
+
+		switch {
+		case empty:
+		case l+spaces+wl > n:
+			b.WriteString(sep)
+			b.WriteString(indent)
+			l = il
+		default:
+			b.WriteString(strings.Repeat(" ", spaces))
+			l += spaces
+		}
+
+		b.WriteString(w)
+		l += wl
+		spaces, empty = 0, false
+	}
+
+	b.WriteString(strings.Repeat(" ", spaces))
+
+	return b.String()
+}
+
+// hardWrap breaks the line after every n characters with sep
+func hardWrap(line string, n int, sep string) string {
+	if utf8.RuneCountInString(line) <= n {
+		return line
+	}
+
+	b := strings.Builder{}
+	l := 0
+
+	for _, r := range line {
+		if l == n {
+			b.WriteString(sep)
+			l = 0
+		}
+
+		b.WriteRune(r)
+		l++
+	}
+
+	return b.String()
+}
+
+// truncate returns the first n characters of the value
+func truncate(s string, n int) string {
+	for i := range s {
+		if n == 0 {
+			return s[:i]
+		}
+
+		n--
+	}
+
+	return s
+}
+
+// squish removes leading and trailing whitespace and collapses inner whitespace to a single space
+func squish(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// stripCtl removes control characters except tabs and line breaks
+func stripCtl(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) && r != '\t' && r != '\n' && r != '\r' {
+			return -1
+		}
+
+		return r
+	}, s)
+}
+
+// snakeCase returns the words of the identifier in lowercase joined by underscores. Words are separated
+// by characters other than letters and digits and start at an uppercase letter following a lowercase
+// letter or digit, or at the last letter of an uppercase run followed by a lowercase letter (HTTPServer).
+func snakeCase(s string) string {
+	runes := []rune(s)
+
+	b := strings.Builder{}
+	b.Grow(len(s) + 4)
+
+	sep := false
+
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			sep = true
+			continue
+		}
+
+		if b.Len() > 0 {
+			prev := runes[i-1]
+			acronym := unicode.IsUpper(prev) && i+1 < len(runes) && unicode.IsLower(runes[i+1])
+
+			if sep || (unicode.IsUpper(r) && (unicode.IsLower(prev) || unicode.IsDigit(prev) || acronym)) {
+				b.WriteByte('_')
+			}
+		}
+
+		b.WriteRune(unicode.ToLower(r))
+		sep = false
+	}
+
+	return b.String()
+}
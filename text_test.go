@@ -0,0 +1,265 @@
+package transform_test
+
+import (
+	"testing"
+
+	"github.com/zeiss/go-transform"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestText(t *testing.T) {
+	type testStruct struct {
+		Squish    string `transform:"squish"`
+		StripCtl  string `transform:"stripctl"`
+		ValidUTF8 string `transform:"validutf8"`
+	}
+
+	tests := []struct {
+		name string
+		in   *testStruct
+		out  *testStruct
+	}{
+		{
+			name: "empty",
+			in:   &testStruct{},
+			out:  &testStruct{},
+		},
+		{
+			name: "values",
+			in: &testStruct{
+				Squish:    "  John \t\n  Doe  ",
+				StripCtl:  "a\x00b\x1bc\td\ne\u0085",
+				ValidUTF8: "a\xffb",
+			},
+			out: &testStruct{
+				Squish:    "John Doe",
+				StripCtl:  "abc\td\ne",
+				ValidUTF8: "a�b",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := transform.Transform(tt.in)
+			require.NoError(t, err)
+			require.Equal(t, tt.out, tt.in)
+		})
+	}
+}
+
+func TestSnakeCase(t *testing.T) {
+	tests := []struct {
+		in  string
+		out string
+	}{
+		{in: "", out: ""},
+		{in: "SomeFieldValue", out: "some_field_value"},
+		{in: "someFieldValue", out: "some_field_value"},
+		{in: "HTTPServer", out: "http_server"},
+		{in: "userID", out: "user_id"},
+		{in: "APIKeyV2", out: "api_key_v2"},
+		{in: "base64Encode", out: "base64_encode"},
+		{in: "already_snake_case", out: "already_snake_case"},
+		{in: " Order Line-Item ", out: "order_line_item"},
+		{in: "__Private__Field", out: "private_field"},
+		{in: "StraßeNummer", out: "straße_nummer"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			in := &struct {
+				Column string `transform:"snakecase"`
+			}{Column: tt.in}
+
+			require.NoError(t, transform.Transform(in))
+			require.Equal(t, tt.out, in.Column)
+		})
+	}
+}
+
+func TestErrorOnUnknownFunc(t *testing.T) {
+	type testStruct struct {
+		Name string `transform:"trim,lowercsae"`
+		City string
+	}
+
+	err := transform.Transform(&testStruct{})
+	require.NoError(t, err)
+
+	err = transform.New(transform.WithErrorOnUnknownFunc()).Transform(&testStruct{})
+	require.ErrorIs(t, err, transform.ErrUnknownFunc)
+	require.ErrorContains(t, err, `Name: "lowercsae"`)
+}
+
+func TestParams(t *testing.T) {
+	type testStruct struct {
+		Truncate  string  `transform:"truncate=5"`
+		Zero      string  `transform:"truncate=0"`
+		TrimChars string  `transform:"trim_chars=*- "`
+		PadLeft   string  `transform:"pad_left=0:8"`
+		PadColon  string  `transform:"pad_left=::3"`
+		PadSpace  *string `transform:"pad_left=4"`
+	}
+
+	tests := []struct {
+		name string
+		in   *testStruct
+		out  *testStruct
+	}{
+		{
+			name: "empty",
+			in:   &testStruct{},
+			out:  &testStruct{},
+		},
+		{
+			name: "values",
+			in: &testStruct{
+				Truncate:  "Grüße aus Jena",
+				Zero:      "abc",
+				TrimChars: "-* name *-",
+				PadLeft:   "4711",
+				PadColon:  "ab",
+				PadSpace:  &[]string{"ab"}[0],
+			},
+			out: &testStruct{
+				Truncate:  "Grüße",
+				TrimChars: "name",
+				PadLeft:   "00004711",
+				PadColon:  ":ab",
+				PadSpace:  &[]string{"  ab"}[0],
+			},
+		},
+		{
+			name: "long",
+			in: &testStruct{
+				Truncate: "abc",
+				PadLeft:  "123456789",
+			},
+			out: &testStruct{
+				Truncate: "abc",
+				PadLeft:  "123456789",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := transform.Transform(tt.in)
+			require.NoError(t, err)
+			require.Equal(t, tt.out, tt.in)
+		})
+	}
+}
+
+func TestParamsInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		in   interface{}
+	}{
+		{
+			name: "truncate",
+			in: &struct {
+				V string `transform:"truncate=x"`
+			}{},
+		},
+		{
+			name: "negative",
+			in: &struct {
+				V string `transform:"truncate=-1"`
+			}{},
+		},
+		{
+			name: "trim_chars",
+			in: &struct {
+				V string `transform:"trim_chars"`
+			}{},
+		},
+		{
+			name: "pad_left",
+			in: &struct {
+				V string `transform:"pad_left=00:8"`
+			}{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := transform.Transform(tt.in)
+			require.ErrorIs(t, err, transform.ErrInvalidParam)
+		})
+	}
+}
+
+func TestWrap(t *testing.T) {
+	type testStruct struct {
+		Wrap       string `transform:"wrap=10"`
+		WrapReflow string `transform:"wrap=10:reflow"`
+		HardWrap   string `transform:"hardwrap=4"`
+	}
+
+	tests := []struct {
+		name string
+		in   *testStruct
+		out  *testStruct
+	}{
+		{
+			name: "empty",
+			in:   &testStruct{},
+			out:  &testStruct{},
+		},
+		{
+			name: "short",
+			in:   &testStruct{Wrap: "a  b", WrapReflow: "a\nb", HardWrap: "abcd"},
+			out:  &testStruct{Wrap: "a  b", WrapReflow: "a b", HardWrap: "abcd"},
+		},
+		{
+			name: "long",
+			in: &testStruct{
+				Wrap:       "Grüße aus Jena und Berlin\nextraordinarily long",
+				WrapReflow: "Grüße aus\nJena und Berlin",
+				HardWrap:   "Grüße\r\nabcdefghi",
+			},
+			out: &testStruct{
+				Wrap:       "Grüße aus\nJena und\nBerlin\nextraordinarily\nlong",
+				WrapReflow: "Grüße aus\nJena und\nBerlin",
+				HardWrap:   "Grüß\r\ne\r\nabcd\r\nefgh\r\ni",
+			},
+		},
+		{
+			name: "indented",
+			in: &testStruct{
+				Wrap:       "  - a  b c d e\n\tfour five six",
+				WrapReflow: "  - one  two three",
+			},
+			out: &testStruct{
+				Wrap:       "  - a  b c\n  d e\n\tfour five\n\tsix",
+				WrapReflow: "- one two\nthree",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, transform.Transform(tt.in))
+			require.Equal(t, tt.out, tt.in)
+		})
+	}
+
+	invalid := []interface{}{
+		&struct {
+			V string `transform:"wrap=0"`
+		}{},
+		&struct {
+			V string `transform:"wrap=x"`
+		}{},
+		&struct {
+			V string `transform:"hardwrap=10:keep"`
+		}{},
+	}
+
+	for _, in := range invalid {
+		require.ErrorIs(t, transform.Transform(in), transform.ErrInvalidParam)
+	}
+}
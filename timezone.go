@@ -0,0 +1,107 @@
+package transform
+
+import (
+	"strings"
+)
+
+// countryZones are the time zones of the countries with a single time zone by ISO 3166-1 alpha-2 code
+var countryZones = map[string]string{
+	"AE": "Asia/Dubai", "AR": "America/Argentina/Buenos_Aires", "AT": "Europe/Vienna", "BE": "Europe/Brussels",
+	"BG": "Europe/Sofia", "CH": "Europe/Zurich", "CL": "America/Santiago", "CN": "Asia/Shanghai",
+	"CO": "America/Bogota", "CZ": "Europe/Prague", "DE": "Europe/Berlin", "DK": "Europe/Copenhagen",
+	"EE": "Europe/Tallinn", "EG": "Africa/Cairo", "FI": "Europe/Helsinki", "FR": "Europe/Paris",
+	"GB": "Europe/London", "GR": "Europe/Athens", "HK": "Asia/Hong_Kong", "HR": "Europe/Zagreb",
+	"HU": "Europe/Budapest", "IE": "Europe/Dublin", "IL": "Asia/Jerusalem", "IN": "Asia/Kolkata",
+	"IS": "Atlantic/Reykjavik", "IT": "Europe/Rome", "JP": "Asia/Tokyo", "KE": "Africa/Nairobi",
+	"KR": "Asia/Seoul", "LI": "Europe/Vaduz", "LT": "Europe/Vilnius", "LU": "Europe/Luxembourg",
+	"LV": "Europe/Riga", "NG": "Africa/Lagos", "NL": "Europe/Amsterdam", "NO": "Europe/Oslo",
+	"NZ": "Pacific/Auckland", "PE": "America/Lima", "PH": "Asia/Manila", "PL": "Europe/Warsaw",
+	"RO": "Europe/Bucharest", "SA": "Asia/Riyadh", "SE": "Europe/Stockholm", "SG": "Asia/Singapore",
+	"SI": "Europe/Ljubljana", "SK": "Europe/Bratislava", "TH": "Asia/Bangkok", "TR": "Europe/Istanbul",
+	"TW": "Asia/Taipei", "VN": "Asia/Ho_Chi_Minh", "ZA": "Africa/Johannesburg",
+}
+
+// postalZone is the time zone of the postal codes starting with the prefix
+type postalZone struct {
+	prefix string
+	zone   string
+}
+
+// postalZones are the time zones of the countries with multiple time zones by the prefixes of their postal codes,
+// the longest prefixes come first
+var postalZones = map[string][]postalZone{
+	"US": {
+		{"967", "Pacific/Honolulu"}, {"968", "Pacific/Honolulu"},
+		{"995", "America/Anchorage"}, {"996", "America/Anchorage"}, {"997", "America/Anchorage"},
+		{"998", "America/Anchorage"}, {"999", "America/Anchorage"},
+		{"85", "America/Phoenix"}, {"86", "America/Phoenix"}, {"889", "America/Los_Angeles"},
+		{"890", "America/Los_Angeles"}, {"891", "America/Los_Angeles"}, {"893", "America/Los_Angeles"},
+		{"894", "America/Los_Angeles"}, {"895", "America/Los_Angeles"}, {"897", "America/Los_Angeles"},
+		{"898", "America/Los_Angeles"},
+		{"0", "America/New_York"}, {"1", "America/New_York"}, {"2", "America/New_York"}, {"3", "America/New_York"},
+		{"4", "America/New_York"}, {"5", "America/Chicago"}, {"6", "America/Chicago"}, {"7", "America/Chicago"},
+		{"8", "America/Denver"}, {"9", "America/Los_Angeles"},
+	},
+	"CA": {
+		{"A", "America/St_Johns"}, {"B", "America/Halifax"}, {"C", "America/Halifax"}, {"E", "America/Moncton"},
+		{"G", "America/Toronto"}, {"H", "America/Toronto"}, {"J", "America/Toronto"}, {"K", "America/Toronto"},
+		{"L", "America/Toronto"}, {"M", "America/Toronto"}, {"N", "America/Toronto"}, {"P", "America/Toronto"},
+		{"R", "America/Winnipeg"}, {"S", "America/Regina"}, {"T", "America/Edmonton"}, {"V", "America/Vancouver"},
+		{"X", "America/Yellowknife"}, {"Y", "America/Whitehorse"},
+	},
+	"AU": {
+		{"0", "Australia/Darwin"}, {"2", "Australia/Sydney"}, {"3", "Australia/Melbourne"}, {"4", "Australia/Brisbane"},
+		{"5", "Australia/Adelaide"}, {"6", "Australia/Perth"}, {"7", "Australia/Hobart"},
+	},
+}
+
+// timezoneFunc sets an empty value to the IANA time zone of the country of the field named in the parameter
+// (timezone=Country), countries with multiple time zones need the field of the postal code (timezone=Country:PostalCode).
+// The value is kept empty if the time zone is unknown.
+func timezoneFunc(fl FieldLevel) error {
+	if fl.String() != "" {
+		return nil
+	}
+
+	country, postal, hasPostal := strings.Cut(fl.Param(), ":")
+
+	c, err := siblingString(fl, country)
+	if err != nil {
+		return err
+	}
+
+	p := ""
+	if hasPostal {
+		if p, err = siblingString(fl, postal); err != nil {
+			return err
+		}
+	}
+
+	if zone := timezoneOf(c, p); zone != "" {
+		SetString(fl, zone)
+	}
+
+	return nil
+}
+
+// timezoneOf returns the time zone of the country code and postal code, it is empty if it is unknown
+func timezoneOf(country, postal string) string {
+	country = strings.ToUpper(strings.TrimSpace(country))
+
+	if zone, ok := countryZones[country]; ok {
+		return zone
+	}
+
+	postal = strings.ToUpper(strings.TrimSpace(postal))
+	if postal == "" {
+		return ""
+	}
+
+	for _, z := range postalZones[country] {
+		if strings.HasPrefix(postal, z.prefix) {
+			return z.zone
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,55 @@
+package transform_test
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata" // the time zones of the table are loaded without a system database
+
+	"github.com/zeiss/go-transform"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimezone(t *testing.T) {
+	type address struct {
+		Timezone   string `transform:"timezone=Country:PostalCode"`
+		Country    string `transform:"trim,uppercase"`
+		PostalCode string `transform:"trim"`
+	}
+
+	tests := []struct {
+		name string
+		in   address
+		out  string
+	}{
+		{name: "single zone", in: address{Country: " de "}, out: "Europe/Berlin"},
+		{name: "postal code", in: address{Country: "US", PostalCode: " 94105 "}, out: "America/Los_Angeles"},
+		{name: "longest prefix", in: address{Country: "US", PostalCode: "96813"}, out: "Pacific/Honolulu"},
+		{name: "letter prefix", in: address{Country: "CA", PostalCode: "m5v 2t6"}, out: "America/Toronto"},
+		{name: "australia", in: address{Country: "AU", PostalCode: "6000"}, out: "Australia/Perth"},
+		{name: "missing postal code", in: address{Country: "US"}},
+		{name: "unknown country", in: address{Country: "XX", PostalCode: "1"}},
+		{name: "set", in: address{Timezone: "UTC", Country: "DE"}, out: "UTC"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			in := tc.in
+			require.NoError(t, transform.New().Transform(&in))
+			require.Equal(t, tc.out, in.Timezone)
+
+			if tc.out != "" {
+				_, err := time.LoadLocation(tc.out)
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestTimezoneUnknownField(t *testing.T) {
+	type address struct {
+		Timezone string `transform:"timezone=Country"`
+	}
+
+	require.ErrorIs(t, transform.New().Transform(&address{}), transform.ErrUnknownField)
+}
@@ -1,44 +1,107 @@
 package transform
 
 import (
-	"errors"
+	"context"
+	"fmt"
 	"reflect"
 	"strings"
+	"sync"
+	"time"
 )
 
 const (
+	// DefaultTagName is the name of the struct tag read by a transformer
 	DefaultTagName = "transform"
 )
 
-// FieldLevel ...
+// FieldLevel is the field passed to a transform function.
+// It is implemented by the package only, methods may be added in minor versions.
 type FieldLevel interface {
-	// GetTag returns the current validation tag name
+	// GetTag returns the transform tag of the field
 	GetTag() string
-	// FieldName returns the current field name+
+	// FieldName returns the current field name
 	FieldName() string
 	// Field returns the current field value
 	Field() reflect.Value
-	// Funcs is returning the list of tag functions
+	// Funcs is returning the list of tag functions as name=param, with the escapes of the parameters removed
 	Funcs() []string
+	// Calls returns the parsed functions of the tag
+	Calls() []Call
 	// Kind returns the kind of the field
 	Kind() reflect.Kind
 	// String returns the string value of the field
 	String() string
+	// Tag returns the value of the named struct tag of the field
+	Tag(name string) string
+	// Path returns the full path of the field (e.g. Address.City)
+	Path() string
+	// Param returns the parameter of the current function (e.g. 64 for truncate=64)
+	Param() string
+	// Index returns the index sequence of the field for reflect.Value.FieldByIndex,
+	// fields of slice or array elements are relative to the element
+	Index() []int
+	// Parent returns the struct containing the field
+	Parent() reflect.Value
 }
 
 // Func transforms the field value
 type Func func(fl FieldLevel) error
 
 var internalTransformers = map[string]Func{
-	"trim":      trimFunc,
-	"ltrim":     trimLeftFunc,
-	"rtrim":     trimRightFunc,
-	"lowercase": toLowerCaseFunc,
-	"uppercase": toUpperCaseFunc,
+	"trim":            trimFunc,
+	"ltrim":           trimLeftFunc,
+	"rtrim":           trimRightFunc,
+	"lowercase":       toLowerCaseFunc,
+	"uppercase":       toUpperCaseFunc,
+	"safefilename":    safeFilenameFunc,
+	"mimetype":        mimeTypeFunc,
+	"canonicaljson":   canonicalJSONFunc,
+	"generalize_zip":  generalizeZipFunc,
+	"generalize_age":  generalizeAgeFunc,
+	"generalize_date": generalizeDateFunc,
+	"copyfrom":        copyFromFunc,
+	"slugfrom":        slugFromFunc,
+	"hashof":          hashOfFunc,
+	"squish":          squishFunc,
+	"stripctl":        stripCtlFunc,
+	"validutf8":       validUTF8Func,
+	"format":          formatFunc,
+	"unit":            unitFunc,
+	"temp":            tempFunc,
+	"float":           floatFunc,
+	"angle":           angleFunc,
+	"serial":          serialFunc,
+	"b64enc":          base64EncodeFunc,
+	"b64dec":          base64DecodeFunc,
+	"truncate":        truncateFunc,
+	"trim_chars":      trimCharsFunc,
+	"pad_left":        padLeftFunc,
+	"replace":         replaceFunc,
+	"default":         defaultFunc,
+	"timezone":        timezoneFunc,
+	"wrap":            wrapFunc,
+	"hardwrap":        hardWrapFunc,
+	"date":            dateFunc,
+	"epoch":           epochFunc,
+	"snakecase":       snakeCaseFunc,
+}
+
+// boundTransformers are the built-in transform functions that use the configuration of the transformer
+var boundTransformers = map[string]func(t *TransformerImpl, fl FieldLevel) error{
+	"pseudonym":    (*TransformerImpl).pseudonymFunc,
+	"encrypt":      (*TransformerImpl).encryptFunc,
+	"decrypt":      (*TransformerImpl).decryptFunc,
+	"tokenize":     (*TransformerImpl).tokenizeFunc,
+	"detokenize":   (*TransformerImpl).detokenizeFunc,
+	"randomsuffix": (*TransformerImpl).randomSuffixFunc,
+	"jittersuffix": (*TransformerImpl).jitterSuffixFunc,
+	"sequence":     (*TransformerImpl).sequenceFunc,
+	"enrich":       (*TransformerImpl).enrichFunc,
+	"relativedate": (*TransformerImpl).relativeDateFunc,
 }
 
 func toUpperCaseFunc(fl FieldLevel) error {
-	SetString(fl, strings.ToUpper(fl.String()))
+	SetString(fl, toUpperString(fl.String()))
 
 	return nil
 }
@@ -62,7 +125,7 @@ func trimFunc(fl FieldLevel) error {
 }
 
 func toLowerCaseFunc(fl FieldLevel) error {
-	SetString(fl, strings.ToLower(fl.String()))
+	SetString(fl, toLowerString(fl.String()))
 
 	return nil
 }
@@ -74,6 +137,12 @@ type fieldLevel struct {
 	val     reflect.Value
 	json    bool
 	tagName string
+	loc     location
+	tag     string
+	funcs   []string
+	param   string
+	parent  reflect.Value
+	ctx     context.Context
 }
 
 // Field returns the current field value
@@ -88,13 +157,60 @@ func (fl fieldLevel) FieldName() string {
 
 // GetTag returns the current transform tag
 func (fl fieldLevel) GetTag() string {
+	if fl.tag != "" {
+		return fl.tag
+	}
+
 	return fl.field.Tag.Get(fl.tagName)
 }
 
 // Funcs return the list of tag functions
 func (fl fieldLevel) Funcs() []string {
-	tag := fl.GetTag()
-	return strings.Split(tag, ",")
+	if fl.funcs != nil {
+		return fl.funcs // parsed once per struct type
+	}
+
+	return funcsOf(fl.GetTag())
+}
+
+// Calls returns the parsed functions of the tag
+func (fl fieldLevel) Calls() []Call {
+	return parseTag(fl.GetTag())
+}
+
+// Tag returns the value of the named struct tag of the field
+func (fl fieldLevel) Tag(name string) string {
+	return fl.field.Tag.Get(name)
+}
+
+// Param returns the parameter of the current function
+func (fl fieldLevel) Param() string {
+	return fl.param
+}
+
+// withParam returns the field with the parameter of the current function
+func withParam(fl FieldLevel, param string) FieldLevel {
+	if f, ok := fl.(fieldLevel); ok {
+		f.param = param
+		return f
+	}
+
+	return fl
+}
+
+// Path returns the full path of the field
+func (fl fieldLevel) Path() string {
+	return fl.loc.path
+}
+
+// Index returns the index sequence of the field
+func (fl fieldLevel) Index() []int {
+	return fl.loc.index
+}
+
+// Parent returns the struct containing the field
+func (fl fieldLevel) Parent() reflect.Value {
+	return fl.parent
 }
 
 // Kind returns the kind of the field
@@ -113,43 +229,239 @@ func (fl fieldLevel) String() string {
 
 var (
 	// ErrNoPointer is returned when the interface is not a pointer
-	ErrNoPointer = errors.New("transformer: interface must be a pointer")
+	ErrNoPointer = newError("transformer: interface must be a pointer")
 	// ErrNoAddressable is returned when the interface is not addressable
-	ErrNoAddressable = errors.New("transformer: interface must be addressable (a pointer)")
+	ErrNoAddressable = newError("transformer: interface must be addressable (a pointer)")
 	// ErrNoStruct is returned when the interface is not a struct
-	ErrNoStruct = errors.New("transformer: interface must be a struct")
+	ErrNoStruct = newError("transformer: interface must be a struct")
+	// ErrNoInterface is returned when a handler is registered for a type that is not an interface
+	ErrNoInterface = newError("transformer: type must be an interface")
+	// ErrTypeMismatch is returned when two values must have the same type
+	ErrTypeMismatch = newError("transformer: values must have the same type")
+	// ErrInvalidParam is returned when the parameter of a transform function is invalid
+	ErrInvalidParam = newError("transformer: invalid parameter")
+	// ErrUnknownKey is returned when a transform function references a key that has not been configured
+	ErrUnknownKey = newError("transformer: unknown key")
+	// ErrUnexportedField is returned when an unexported field has a transform tag
+	ErrUnexportedField = newError("transformer: unexported field must not have a transform tag")
+	// ErrUnknownFunc is returned if a tag references an unknown function
+	ErrUnknownFunc = newError("transformer: unknown function")
+	// ErrUnsupportedKind is returned when a transform tag is set on a field of a kind that is not transformed
+	ErrUnsupportedKind = newError("transformer: unsupported kind")
 )
 
-// Transformer ...
+// Transformer transforms the string fields of structs according to their tags,
+// it is implemented by TransformerImpl so code using it can be tested with a fake.
 type Transformer interface {
-	transform(string, interface{}) error
+	// Transform transforms the struct in place
+	Transform(s interface{}) error
+	// TransformCOW transforms a copy of the struct and returns it, if a field changed
+	TransformCOW(s interface{}) (interface{}, bool, error)
+	// TransformWithReport transforms the struct and reports the changed fields
+	TransformWithReport(s interface{}) *Result
+	// Reverse reverts the transformation of the struct
+	Reverse(s interface{}) error
 }
 
-// TransformerImpl ...
+var _ Transformer = (*TransformerImpl)(nil)
+
+// TransformerImpl transforms the string fields of structs according to their tags, it is created with New.
+//
+// Each transformer has its own set of functions, the built-in functions replaced and extended
+// by the functions added with WithTransformation or RegisterTransformation.
+//
+// A transformer is safe for concurrent use by multiple goroutines once it is set up:
+// Transform and the other transformation methods may be called concurrently, but not
+// concurrently with SkipType or RegisterInterfaceHandler. Freeze the transformer after
+// the setup to turn these modifications into errors. Functions added with RegisterTransformation
+// or to the default registry with Register are safe to add at any time.
 type TransformerImpl struct {
 	// TagName is the name of the tag to look for
 	TagName string
+
+	// mu guards the functions added to the transformer
+	mu                sync.RWMutex
+	skipTypes         map[reflect.Type]struct{}
+	repeatShared      bool
+	errorOnUnexported bool
+	interfaceHandlers map[reflect.Type]Func
+	recorder          *Recorder
+	hmacKeys          map[string][]byte
+	retries           map[string]RetryPolicy
+	limiters          map[string]Limiter
+	breakers          map[string]breaker
+	program           *Program
+	isolateElements   bool
+	collectErrors     bool
+	metrics           Metrics
+	funcs             map[string]Func
+	kindDefaults      map[reflect.Kind]string
+	errorOnUnknown    bool
+	errorOnKind       bool
+	withoutDefaults   bool
+	frozen            bool
+	frozenFuncs       map[string]Func
+	diagnostics       bool
+	skipFunc          func(fl FieldLevel) bool
+	middleware        []Middleware
+	now               func() time.Time
+	encryptionKeys    map[string][]byte
+	vaults            map[string]Vault
+	inverses          map[string]string
+	idempotent        bool
+	enums             map[reflect.Type]enumCodec
+	strict            bool
+	checked           sync.Map
+	plans             sync.Map
+	nameTag           string
+	random            *lockedRand
+	initNilPointers   bool
+	sequences         SequenceProvider
+	maxDepth          int
+	enrichers         map[string]Enricher
 }
 
-// TransformerOpt ...
+// TransformerOpt configures a transformer
 type TransformerOpt func(o *TransformerImpl)
 
-// WithTagName ...
+// WithTagName sets the name of the struct tag, the default is DefaultTagName
 func WithTagName(tagName string) TransformerOpt {
 	return func(o *TransformerImpl) {
 		o.TagName = tagName
 	}
 }
 
-// Transform ...
+// WithSkipTypes excludes fields of the types from transformation, see SkipType
+func WithSkipTypes(types ...reflect.Type) TransformerOpt {
+	return func(o *TransformerImpl) {
+		_ = o.SkipType(types...)
+	}
+}
+
+// WithSkipFunc skips the fields for which the predicate returns true,
+// nested structs, slices and arrays are skipped with all their fields.
+// The predicate is called with the full path of the field, so a transformer
+// created for a call can skip the fields not present in a patch of the call.
+func WithSkipFunc(fn func(fl FieldLevel) bool) TransformerOpt {
+	return func(o *TransformerImpl) {
+		o.skipFunc = fn
+	}
+}
+
+// WithRepeatSharedPointers runs the pipeline for every string pointer field, even if
+// several fields point to the same value. By default the pipeline runs once per pointer
+// and tag, the result is shared by all fields with the same tag pointing to it.
+// Nested structs are always transformed once per pointer.
+func WithRepeatSharedPointers() TransformerOpt {
+	return func(o *TransformerImpl) {
+		o.repeatShared = true
+	}
+}
+
+// WithErrorOnUnexported returns ErrUnexportedField when an unexported field
+// has a transform tag. By default these fields are silently skipped.
+func WithErrorOnUnexported() TransformerOpt {
+	return func(o *TransformerImpl) {
+		o.errorOnUnexported = true
+	}
+}
+
+// WithErrorOnUnknownFunc returns ErrUnknownFunc when a tag references an unknown function.
+// By default the remaining functions of the field are silently skipped.
+func WithErrorOnUnknownFunc() TransformerOpt {
+	return func(o *TransformerImpl) {
+		o.errorOnUnknown = true
+	}
+}
+
+// WithErrorOnUnsupportedKind returns a KindError matching ErrUnsupportedKind when a transform tag
+// is set on a field that is not transformed (e.g. an int or a slice of ints without dive).
+// By default the tag is ignored.
+func WithErrorOnUnsupportedKind() TransformerOpt {
+	return func(o *TransformerImpl) {
+		o.errorOnKind = true
+	}
+}
+
+// WithRecorder records the input and output of sampled transformations
+func WithRecorder(r *Recorder) TransformerOpt {
+	return func(o *TransformerImpl) {
+		o.recorder = r
+	}
+}
+
+// WithTransformation adds a transform function to the transformer,
+// it replaces a built-in function of the same name
+func WithTransformation(name string, fn Func) TransformerOpt {
+	return func(o *TransformerImpl) {
+		o.mu.Lock()
+		defer o.mu.Unlock()
+
+		if o.funcs == nil {
+			o.funcs = make(map[string]Func)
+		}
+
+		o.funcs[name] = fn
+	}
+}
+
+// WithTransformations adds the transform functions of the table to the transformer,
+// they replace the built-in functions of the same name
+func WithTransformations(table map[string]Func) TransformerOpt {
+	return func(o *TransformerImpl) {
+		for name, fn := range table {
+			WithTransformation(name, fn)(o)
+		}
+	}
+}
+
+// WithKindDefaults sets the functions applied to exported fields of the kind
+// without a transform tag (e.g. WithKindDefaults(reflect.String, "trim")).
+// The kind of a pointer field is the kind of its element.
+func WithKindDefaults(kind reflect.Kind, funcs string) TransformerOpt {
+	return func(o *TransformerImpl) {
+		if o.kindDefaults == nil {
+			o.kindDefaults = make(map[reflect.Kind]string)
+		}
+
+		o.kindDefaults[kind] = funcs
+	}
+}
+
+// WithHMACKey adds a named key for keyed transform functions (e.g. pseudonym=name)
+func WithHMACKey(name string, key []byte) TransformerOpt {
+	return func(o *TransformerImpl) {
+		if o.hmacKeys == nil {
+			o.hmacKeys = make(map[string][]byte)
+		}
+
+		o.hmacKeys[name] = key
+	}
+}
+
+// Transform transforms the struct with a transformer without options
 func Transform(s interface{}) error {
-	t := NewTransformer()
+	t := New()
 
 	return t.Transform(s)
 }
 
-// NewTransformer ...
-func NewTransformer(opts ...TransformerOpt) *TransformerImpl {
+// TransformCtx transforms the struct with a transformer without options and the context
+func TransformCtx(ctx context.Context, s interface{}) error {
+	t := New()
+
+	return t.TransformCtx(ctx, s)
+}
+
+// TransformCOW transforms a copy of the struct with a transformer without options
+func TransformCOW(s interface{}) (interface{}, bool, error) {
+	t := New()
+
+	return t.TransformCOW(s)
+}
+
+// New returns a transformer configured by the options
+func New(opts ...TransformerOpt) *TransformerImpl {
 	t := new(TransformerImpl)
 	t.TagName = DefaultTagName
 
@@ -161,97 +473,703 @@ func NewTransformer(opts ...TransformerOpt) *TransformerImpl {
 	return t
 }
 
-// Transform ...
+// NewTransformer returns a transformer configured by the options.
+//
+// Deprecated: Use New.
+func NewTransformer(opts ...TransformerOpt) *TransformerImpl {
+	return New(opts...)
+}
+
+// SkipType excludes fields of the given types (or pointers to them) from transformation.
+// The transformer never descends into values of a skipped type, which makes it
+// possible to exclude mutexes, channels or large blobs.
+// It returns ErrFrozen if the transformer is frozen.
+func (t *TransformerImpl) SkipType(types ...reflect.Type) error {
+	if t.frozen {
+		return ErrFrozen
+	}
+
+	if t.skipTypes == nil {
+		t.skipTypes = make(map[reflect.Type]struct{}, len(types))
+	}
+
+	for _, typ := range types {
+		t.skipTypes[typ] = struct{}{}
+	}
+
+	t.resetPlans()
+
+	return nil
+}
+
+// fieldTag returns the tag of the field, an exported field without a tag has the default functions of its kind
+func (t *TransformerImpl) fieldTag(ft reflect.StructField) string {
+	tag := ft.Tag.Get(t.TagName)
+	if tag == "" && ft.IsExported() {
+		return t.kindDefault(ft.Type)
+	}
+
+	return tag
+}
+
+// kindDefault returns the default functions of the kind of the type
+func (t *TransformerImpl) kindDefault(typ reflect.Type) string {
+	if len(t.kindDefaults) == 0 {
+		return ""
+	}
+
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	return t.kindDefaults[typ.Kind()]
+}
+
+// skipType returns true if the type is excluded from transformation
+func (t *TransformerImpl) skipType(typ reflect.Type) bool {
+	if len(t.skipTypes) == 0 {
+		return false
+	}
+
+	if _, ok := t.skipTypes[typ]; ok {
+		return true
+	}
+
+	if typ.Kind() == reflect.Ptr {
+		_, ok := t.skipTypes[typ.Elem()]
+		return ok
+	}
+
+	return false
+}
+
+// RegisterInterfaceHandler registers a handler for fields of the given interface type.
+// The handler is called for every non-nil field of this type, it usually
+// uses a type switch on the field value to transform the concrete types.
+// It returns ErrFrozen if the transformer is frozen.
+func (t *TransformerImpl) RegisterInterfaceHandler(iface reflect.Type, fn Func) error {
+	if t.frozen {
+		return ErrFrozen
+	}
+
+	if iface == nil || iface.Kind() != reflect.Interface {
+		return ErrNoInterface
+	}
+
+	if t.interfaceHandlers == nil {
+		t.interfaceHandlers = make(map[reflect.Type]Func)
+	}
+
+	t.interfaceHandlers[iface] = fn
+
+	return nil
+}
+
+// Transform transforms the string fields of the struct in place, s is a pointer to a struct
 func (t *TransformerImpl) Transform(s interface{}) error {
-	ifv := reflect.ValueOf(s)
+	return t.TransformCtx(context.Background(), s)
+}
 
-	if ifv.IsNil() {
+// TransformCtx transforms the string fields of the struct in place like Transform, functions added
+// with WithTransformationCtx receive the context. The transformation stops with an error matching
+// ErrCanceled and the error of the context when the context is done.
+func (t *TransformerImpl) TransformCtx(ctx context.Context, s interface{}) error {
+	ifv, err := structValue(s)
+	if err != nil {
+		return err
+	}
+
+	if !ifv.IsValid() {
 		return nil // bail out of if this nil
 	}
 
+	st := newState()
+	st.guard = true
+	st.ctx = ctx
+
+	if t.recorder != nil && t.recorder.sample() {
+		in := t.recorder.snapshot(s)
+		err := t.transform(st, ifv)
+		t.recorder.record(s, in, err)
+
+		return err
+	}
+
+	return t.transform(st, ifv)
+}
+
+// TransformCOW transforms a copy of the struct and returns it, if any field changed.
+// Otherwise the original value is returned. The original value is never modified.
+func (t *TransformerImpl) TransformCOW(s interface{}) (interface{}, bool, error) {
+	ifv, err := structValue(s)
+	if err != nil || !ifv.IsValid() {
+		return s, false, err
+	}
+
+	cp := newCopier().copy(reflect.ValueOf(s))
+
+	st := newState()
+	st.guard = true
+
+	if err := t.transform(st, cp.Elem()); err != nil {
+		return s, false, err
+	}
+
+	if equal(reflect.ValueOf(s), cp, map[visit]bool{}) {
+		return s, false, nil
+	}
+
+	return cp.Interface(), true, nil
+}
+
+// TransformCopy transforms a deep copy of the struct and returns it, the original value is never modified.
+// Unlike TransformCOW the copy is returned even if no field changed, so it never shares memory with the original.
+func (t *TransformerImpl) TransformCopy(s interface{}) (interface{}, error) {
+	cp, err := Clone(s)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := t.Transform(cp); err != nil {
+		return nil, err
+	}
+
+	return cp, nil
+}
+
+// structValue returns the addressable struct the pointer points to
+func structValue(s interface{}) (reflect.Value, error) {
+	ifv := reflect.ValueOf(s)
+
+	if !ifv.IsValid() {
+		return reflect.Value{}, nil // bail out of if this nil
+	}
+
 	if ifv.Kind() != reflect.Ptr { // we only accept pointer
-		return ErrNoPointer
+		return reflect.Value{}, &KindError{Kind: ifv.Kind(), Err: ErrNoPointer}
+	}
+
+	if ifv.IsNil() {
+		return reflect.Value{}, nil // bail out of if this nil
 	}
 
 	ifv = ifv.Elem()
 	if !ifv.CanAddr() {
-		return ErrNoAddressable
+		return reflect.Value{}, ErrNoAddressable
 	}
 
 	if ifv.Kind() != reflect.Struct {
-		return ErrNoStruct // we only support struct, because of the need of tags
+		return reflect.Value{}, &KindError{Kind: ifv.Kind(), Err: ErrNoStruct} // we only support struct, because of the need of tags
 	}
 
-	return t.transform(ifv)
+	return ifv, nil
+}
+
+// state is the state of a single transformation call
+type state struct {
+	// seen maps pointers to the result of their transformation
+	seen map[pointer]reflect.Value
+	// shared maps string pointers to the result of the functions of a tag
+	shared map[sharedString]reflect.Value
+	// filter restricts the transformed fields, nested structs are always traversed
+	filter func(fl FieldLevel) bool
+	// track enables the tracking of changes
+	track bool
+	// changes are the changed string fields
+	changes []change
+	// changedBy are the functions that changed the value of the current field while tracking
+	changedBy []string
+	// warnings are the problems that did not fail the transformation
+	warnings []string
+	// diagnose enables the reporting of unreached fields
+	diagnose bool
+	// unreached are the tagged fields that were not transformed
+	unreached []Unreached
+	// reverse runs the inverse functions
+	reverse bool
+	// guard checks and stamps the marker of the transformed structs
+	guard bool
+	// errs are the errors of the failed fields collected with WithCollectErrors
+	errs TransformErrors
+	// active are the struct pointers being transformed in strict mode, to tell cycles from shared pointers
+	active map[pointer]struct{}
+	// depth is the nesting level of the transformed value
+	depth int
+	// ctx is the context of TransformCtx, it is nil for the other entry points
+	ctx context.Context
+}
+
+// change is the modification of a string field
+type change struct {
+	loc   location
+	old   string
+	new   string
+	funcs []string
+	// from is the location of the old key if the change renames a map key
+	from *location
+	// value is the value of a renamed map key
+	value string
+}
+
+// pointer identifies a value that is shared by multiple fields
+type pointer struct {
+	addr uintptr
+	typ  reflect.Type
+}
+
+// sharedString identifies a string pointer transformed by the functions of a tag,
+// fields pointing to the same string with different tags run their own pipelines
+type sharedString struct {
+	pointer
+	tag string
+}
+
+func newState() *state {
+	return &state{
+		seen: make(map[pointer]reflect.Value),
+	}
+}
+
+// include returns true if the field passes the filter of the call
+func (st *state) include(fl FieldLevel) bool {
+	return st.filter == nil || st.filter(fl)
 }
 
 // this is the heavy lifting
-func (t *TransformerImpl) transform(ifv reflect.Value) error {
+func (t *TransformerImpl) transform(st *state, ifv reflect.Value) error {
+	var m *Marker
+	if t.idempotent && (st.guard || st.reverse) {
+		m = marker(ifv)
+	}
+
+	if m != nil && st.guard && m.stamped {
+		return nil // the struct has already been transformed
+	}
+
+	if t.strict && !st.reverse {
+		if err := t.checkFuncs(reflect.Indirect(ifv).Type()); err != nil {
+			return err
+		}
+	}
+
+	if ifv.CanAddr() {
+		// fields pointing to the struct itself don't transform it again
+		p := pointer{ifv.Addr().Pointer(), ifv.Addr().Type()}
+		st.seen[p] = ifv.Addr()
+
+		if t.strict {
+			st.enter(p)
+			defer st.leave(p)
+		}
+	}
+
+	if err := t.transformStruct(st, ifv, location{}); err != nil {
+		return err
+	}
+
+	if t.program != nil && !st.reverse {
+		err := t.program.run(t, st, ifv)
+		if err != nil && t.collectErrors {
+			st.errs = append(st.errs, err)
+		} else if err != nil {
+			return err
+		}
+	}
+
+	if errs := st.errs; len(errs) > 0 {
+		st.errs = nil // the state is shared by the elements of TransformSlice and TransformMany
+
+		return errs
+	}
+
+	if m != nil {
+		m.stamped = !st.reverse
+	}
+
+	return nil
+}
+
+// transformStruct transforms the fields of a (nested) struct at the location
+func (t *TransformerImpl) transformStruct(st *state, ifv reflect.Value, loc location) error {
 	vif := reflect.Indirect(ifv)
-	vt := vif.Type()
 
-	fields := []FieldLevel{}
+	p, err := t.planOf(vif.Type(), loc)
+	if err != nil {
+		return err
+	}
 
-	for i := 0; i < ifv.NumField(); i++ {
-		ft := vt.Field(i)
+	fields := make([]FieldLevel, len(p.fields))
 
-		if ft.Tag.Get(t.TagName) == "-" {
-			continue
+	for i, f := range p.fields {
+		fl := f.locate(loc)
+
+		if st.diagnose {
+			t.diagnose(st, fl, f.field, ifv.Field(f.index), f.tag)
 		}
 
-		isJSON := false
-		// detected if this field is json
-		if ft.Tag.Get("json") != "" {
-			isJSON = true
+		fields[i] = fieldLevel{
+			field:   f.field,
+			val:     ifv.Field(f.index),
+			json:    f.json,
+			tagName: t.TagName,
+			tag:     f.tag,
+			funcs:   f.funcs,
+			loc:     fl,
+			parent:  vif,
 		}
+	}
 
-		fields = append(fields, fieldLevel{ft, ifv.Field(i), isJSON, t.TagName})
+	if p.order != nil {
+		sorted := make([]FieldLevel, len(fields))
+		for i, j := range p.order {
+			sorted[i] = fields[j]
+		}
+
+		fields = sorted
 	}
 
-	return t.transformFields(fields...)
+	if err := t.transformFields(st, fields...); err != nil {
+		return err
+	}
+
+	if p.structLevel && !st.reverse && vif.CanAddr() {
+		return t.transformStructLevel(st, vif, loc)
+	}
+
+	return nil
 }
 
-// transformField
-func (t *TransformerImpl) transformFields(fields ...FieldLevel) error {
+// transformFields transforms the fields, the errors of the fields are collected with WithCollectErrors
+func (t *TransformerImpl) transformFields(st *state, fields ...FieldLevel) error {
 	for _, f := range fields {
-		k := f.Kind()
+		if err := st.canceled(); err != nil {
+			return err
+		}
 
-		if k == reflect.Ptr {
-			k = f.Field().Elem().Kind()
+		if t.skipFunc != nil && t.skipFunc(f) {
+			continue
 		}
 
-		// nolint:exhaustive
-		switch k {
-		case reflect.String:
-			if f.Field().CanSet() {
-				if err := t.transformField(f); err != nil {
-					return err
-				}
-			}
-		default:
+		err := t.transformValue(st, f)
+		if err != nil && t.collectErrors {
+			st.errs = append(st.errs, err)
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// transformValue transforms the value of the field by its kind
+func (t *TransformerImpl) transformValue(st *state, f FieldLevel) error {
+	if !st.reverse && st.include(f) {
+		t.initNil(f)
+	}
+
+	k := f.Kind()
+
+	if k == reflect.Ptr {
+		k = f.Field().Elem().Kind()
+	}
+
+	// nolint:exhaustive
+	switch k {
+	case reflect.String:
+		if f.Field().CanSet() && st.include(f) {
+			return t.transformString(st, f)
+		}
+	case reflect.Struct:
+		return t.transformNested(st, f.Field(), locationOf(f))
+	case reflect.Slice, reflect.Array:
+		return t.transformElements(st, f)
+	case reflect.Map:
+		return t.transformMap(st, f)
+	case reflect.Interface:
+		if st.include(f) {
+			return t.transformInterface(st, f)
+		}
+	default:
+		// enums are transformed through their string form, other kinds and nil pointers are never transformed
+		if c, ok := t.enum(f.Field().Type()); ok && f.GetTag() != "" {
+			return t.transformEnum(st, f, c)
+		}
+	}
+
+	return nil
+}
+
+// transformNested transforms the fields of a nested struct or pointer to struct
+func (t *TransformerImpl) transformNested(st *state, v reflect.Value, loc location) error {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
 			return nil
 		}
+
+		// a struct pointed to by multiple fields is only transformed once, which also stops at cycles
+		p := pointer{v.Pointer(), v.Type()}
+		if _, ok := st.seen[p]; ok {
+			return t.checkCycle(st, p, loc)
+		}
+
+		st.seen[p] = v
+
+		if t.strict {
+			st.enter(p)
+			defer st.leave(p)
+		}
+
+		v = v.Elem()
+	}
+
+	if err := t.descend(st, loc); err != nil {
+		return err
+	}
+	defer st.ascend()
+
+	return t.transformStruct(st, v, loc)
+}
+
+// transformElements transforms the struct elements of a slice or array,
+// the index sequence of the fields of an element is relative to the element
+func (t *TransformerImpl) transformElements(st *state, field FieldLevel) error {
+	v := field.Field()
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+
+	et := v.Type().Elem()
+	if et.Kind() == reflect.Ptr {
+		et = et.Elem()
+	}
+
+	if et.Kind() != reflect.String && (et.Kind() != reflect.Struct || t.skipType(et)) || v.Len() == 0 {
+		return nil
+	}
+
+	if err := t.descend(st, locationOf(field)); err != nil {
+		return err
+	}
+	defer st.ascend()
+
+	if et.Kind() == reflect.String {
+		return t.transformDive(st, field, v)
+	}
+
+	return t.eachElement(v, locationOf(field), func(i int, loc location) error {
+		return t.transformNested(st, v.Index(i), loc)
+	})
+}
+
+// transformInterface calls the registered handler of the interface type
+func (t *TransformerImpl) transformInterface(st *state, field FieldLevel) error {
+	fn, ok := t.interfaceHandlers[field.Field().Type()]
+	if !ok || field.Field().IsNil() || !field.Field().CanSet() || !st.include(field) {
+		return nil
+	}
+
+	var before []leaf
+	if st.track {
+		before = t.leaves(field.Field(), locationOf(field))
+	}
+
+	err := fn(withContext(field, st.ctx))
+	if st.track {
+		st.diff(before, t.leaves(field.Field(), locationOf(field)), field.Field().Type().String())
+	}
+
+	if err != nil {
+		return t.fieldError(field, "", err)
 	}
 
 	return nil
 }
 
-func (t *TransformerImpl) transformField(field FieldLevel) error {
-	for _, f := range field.Funcs() {
-		fn, ok := internalTransformers[f]
+// transformString transforms a string field, tracks the change if requested and reports its metrics
+func (t *TransformerImpl) transformString(st *state, field FieldLevel) error {
+	label := ""
+	if t.metrics != nil {
+		label = field.Tag(metricTag)
+	}
+
+	if !st.track && label == "" {
+		return t.transformShared(st, field)
+	}
+
+	old := field.String()
+	st.changedBy = nil
+
+	if err := t.transformShared(st, field); err != nil {
+		return err
+	}
+
+	v := field.String()
+
+	if label != "" {
+		observe(t.metrics, label, old, v)
+	}
+
+	if st.track && v != old {
+		st.changes = append(st.changes, change{loc: locationOf(field), old: old, new: v, funcs: st.changedBy})
+	}
+
+	return nil
+}
+
+// transformShared runs the pipeline once per pointer and tag and assigns the result
+// to all fields sharing the pointer and the tag
+func (t *TransformerImpl) transformShared(st *state, field FieldLevel) error {
+	if t.repeatShared || field.Kind() != reflect.Ptr {
+		return t.transformField(st, field)
+	}
+
+	p := sharedString{pointer{field.Field().Pointer(), field.Field().Type()}, field.GetTag()}
+	if v, ok := st.shared[p]; ok {
+		field.Field().Set(v)
+		return nil
+	}
+
+	if err := t.transformField(st, field); err != nil {
+		return err
+	}
+
+	if st.shared == nil {
+		st.shared = make(map[sharedString]reflect.Value)
+	}
+
+	st.shared[p] = field.Field()
+
+	return nil
+}
+
+// lookup returns the transform function of the name
+func (t *TransformerImpl) lookup(name string) (Func, bool) {
+	if t.frozen {
+		fn, ok := t.frozenFuncs[name]
+		return fn, ok
+	}
+
+	if fn, ok := t.custom(name); ok {
+		return fn, true
+	}
+
+	if fn, ok := internalTransformers[name]; ok {
+		return fn, true
+	}
+
+	if fn, ok := boundTransformers[name]; ok {
+		return func(fl FieldLevel) error { return fn(t, fl) }, true
+	}
+
+	if !t.withoutDefaults {
+		return registered(name)
+	}
+
+	return nil, false
+}
+
+// custom returns the function added to the transformer
+func (t *TransformerImpl) custom(name string) (Func, bool) {
+	if t.frozen {
+		fn, ok := t.funcs[name] // the functions of a frozen transformer don't change
+		return fn, ok
+	}
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	fn, ok := t.funcs[name]
+
+	return fn, ok
+}
+
+// replaced returns true if a function has been added to the transformer under the name of a built-in function
+func (t *TransformerImpl) replaced(name string) bool {
+	_, ok := t.custom(name)
+	return ok
+}
+
+func (t *TransformerImpl) transformField(st *state, field FieldLevel) error {
+	funcs, omit := omitEmpty(field.Funcs())
+	if omit && isEmpty(field) {
+		return nil
+	}
+
+	if st.reverse {
+		return t.reverseField(field)
+	}
+
+	var buf buffer
+	defer buf.release()
+
+	// flush writes the buffer back to the field
+	flush := func() {
+		if buf.active() {
+			SetString(field, string(buf.b))
+			buf.release()
+		}
+	}
+
+	for _, f := range funcs {
+		// consecutive string functions share a single buffer, unless they are replaced
+		if bf, ok := t.buffered(f); ok {
+			if !buf.active() {
+				buf.reset(field.String())
+			}
+
+			if !st.track {
+				buf.b = bf(buf.b)
+				continue
+			}
+
+			before := string(buf.b)
+			if buf.b = bf(buf.b); string(buf.b) != before {
+				st.changedBy = append(st.changedBy, f)
+			}
+
+			continue
+		}
+
+		flush()
+
+		name, param, _ := strings.Cut(f, "=")
+
+		fn, ok := t.lookup(name)
+		if !ok && name != "" && t.errorOnUnknown {
+			return &UnknownFuncError{Path: field.Path(), Name: name}
+		}
+
 		if !ok {
+			if name != "" {
+				st.warnings = append(st.warnings, fmt.Sprintf("%s: unknown function %q", field.Path(), name))
+			}
+
 			return nil // bail out if we don't have the function
 		}
 
-		if err := fn(field); err != nil {
-			return err
+		before := ""
+		if st.track {
+			before = field.String()
+		}
+
+		if err := t.call(name, fn, withContext(withParam(field, param), st.ctx)); err != nil {
+			return t.fieldError(field, name, err)
+		}
+
+		if st.track && field.String() != before {
+			st.changedBy = append(st.changedBy, name)
 		}
 	}
 
+	flush()
+
 	return nil
 }
 
-// SetString ...
+// SetString sets the string value of the field, nil pointers are not set
 func SetString(f FieldLevel, s string) {
 	if f.Kind() == reflect.Ptr && f.Field().IsNil() {
 		return // we don't want to set nil
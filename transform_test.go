@@ -3,7 +3,10 @@ package transform_test
 import (
 	"fmt"
 	"log"
+	"reflect"
+	"strings"
 	"testing"
+	"unsafe"
 
 	"github.com/zeiss/go-transform"
 
@@ -15,7 +18,7 @@ func ExampleTransform() {
 		Name string `transform:"trim,lowercase"`
 	}
 
-	t := transform.NewTransformer()
+	t := transform.New()
 	e := example{Name: "  John Doe  "}
 
 	if err := t.Transform(&e); err != nil {
@@ -27,7 +30,7 @@ func ExampleTransform() {
 }
 
 func BenchmarkStruct(b *testing.B) {
-	trans := transform.NewTransformer()
+	trans := transform.New()
 
 	type testStruct struct {
 		Name string `transform:"trim"`
@@ -39,13 +42,27 @@ func BenchmarkStruct(b *testing.B) {
 	}
 }
 
-func TestNewTransformer(t *testing.T) {
-	test := transform.NewTransformer()
+func TestNew(t *testing.T) {
+	test := transform.New()
 	require.NotNil(t, test)
+	require.Equal(t, transform.DefaultTagName, test.TagName)
+
+	//nolint:staticcheck
+	test = transform.NewTransformer(transform.WithTagName("mod"))
+	require.Equal(t, "mod", test.TagName)
+
+	var trans transform.Transformer = test
+
+	s := struct {
+		Name string `mod:"trim"`
+	}{Name: " John "}
+
+	require.NoError(t, trans.Transform(&s))
+	require.Equal(t, "John", s.Name)
 }
 
 func TestStruct(t *testing.T) {
-	trans := transform.NewTransformer()
+	trans := transform.New()
 
 	type testStruct struct {
 		Name    string  `transform:"trim,lowercase"`
@@ -91,7 +108,7 @@ func TestStruct(t *testing.T) {
 }
 
 func TestIgnore(t *testing.T) {
-	trans := transform.NewTransformer()
+	trans := transform.New()
 
 	type testStruct struct {
 		Name    string  `transform:"-"`
@@ -136,7 +153,7 @@ func TestIgnore(t *testing.T) {
 }
 
 func TestStructLowercase(t *testing.T) {
-	trans := transform.NewTransformer()
+	trans := transform.New()
 
 	type testStruct struct {
 		Name string `transform:"lowercase"`
@@ -178,7 +195,7 @@ func TestStructLowercase(t *testing.T) {
 }
 
 func TestStructTrimRight(t *testing.T) {
-	trans := transform.NewTransformer()
+	trans := transform.New()
 
 	type testStruct struct {
 		Name string `transform:"rtrim"`
@@ -220,7 +237,7 @@ func TestStructTrimRight(t *testing.T) {
 }
 
 func TestStructTrimLeft(t *testing.T) {
-	trans := transform.NewTransformer()
+	trans := transform.New()
 
 	type testStruct struct {
 		Name string `transform:"ltrim"`
@@ -262,7 +279,7 @@ func TestStructTrimLeft(t *testing.T) {
 }
 
 func TestStructUppercase(t *testing.T) {
-	trans := transform.NewTransformer()
+	trans := transform.New()
 
 	type testStruct struct {
 		Name string `transform:"uppercase"`
@@ -302,3 +319,963 @@ func TestStructUppercase(t *testing.T) {
 		})
 	}
 }
+
+func TestSkipType(t *testing.T) {
+	type secret string
+
+	type testStruct struct {
+		Name    string  `transform:"trim"`
+		Secret  secret  `transform:"trim"`
+		NamePtr *string `transform:"trim"`
+	}
+
+	tests := []struct {
+		name  string
+		types []reflect.Type
+		in    *testStruct
+		out   *testStruct
+	}{
+		{
+			name:  "type",
+			types: []reflect.Type{reflect.TypeOf(secret(""))},
+			in: &testStruct{
+				Name:   "  test  ",
+				Secret: "  test  ",
+			},
+			out: &testStruct{
+				Name:   "test",
+				Secret: "  test  ",
+			},
+		},
+		{
+			name:  "pointer",
+			types: []reflect.Type{reflect.TypeOf("")},
+			in: &testStruct{
+				Name:    "  test  ",
+				Secret:  "  test  ",
+				NamePtr: &[]string{"  test  "}[0],
+			},
+			out: &testStruct{
+				Name:    "  test  ",
+				Secret:  "test",
+				NamePtr: &[]string{"  test  "}[0],
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trans := transform.New(transform.WithSkipTypes(tt.types...))
+			err := trans.Transform(tt.in)
+			require.NoError(t, err)
+			require.Equal(t, tt.out, tt.in)
+		})
+	}
+}
+
+func TestSharedPointers(t *testing.T) {
+	type testStruct struct {
+		First  *string `transform:"trim,uppercase"`
+		Second *string `transform:"trim"`
+		Third  *string `transform:"trim,uppercase"`
+	}
+
+	tests := []struct {
+		name   string
+		opts   []transform.TransformerOpt
+		shared bool
+	}{
+		{name: "once", shared: true},
+		{name: "repeat", opts: []transform.TransformerOpt{transform.WithRepeatSharedPointers()}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, trans := range []interface {
+				Transform(s interface{}) error
+			}{transform.New(tt.opts...), compiled(t, transform.New(tt.opts...), testStruct{})} {
+				s := "  test  "
+				in := &testStruct{First: &s, Second: &s, Third: &s}
+
+				err := trans.Transform(in)
+				require.NoError(t, err)
+				require.Equal(t, "TEST", *in.First)
+				require.Equal(t, "test", *in.Second)
+				require.Equal(t, "TEST", *in.Third)
+				require.Equal(t, tt.shared, in.First == in.Third)
+				require.NotSame(t, in.First, in.Second)
+				require.Equal(t, "  test  ", s)
+			}
+		})
+	}
+}
+
+// compiled returns the transformer compiled for the type of the sample
+func compiled(t *testing.T, trans *transform.TransformerImpl, sample interface{}) *transform.CompiledTransformer {
+	t.Helper()
+
+	c, err := trans.Compile(sample)
+	require.NoError(t, err)
+
+	return c
+}
+
+func TestTransformCOW(t *testing.T) {
+	trans := transform.New()
+
+	type testStruct struct {
+		Name    string  `transform:"trim"`
+		NamePtr *string `transform:"trim"`
+		Tags    []string
+	}
+
+	tests := []struct {
+		name    string
+		in      *testStruct
+		out     *testStruct
+		changed bool
+	}{
+		{
+			name: "nil",
+			in:   nil,
+			out:  nil,
+		},
+		{
+			name: "unchanged",
+			in: &testStruct{
+				Name:    "test",
+				NamePtr: &[]string{"test"}[0],
+				Tags:    []string{"a"},
+			},
+			out: &testStruct{
+				Name:    "test",
+				NamePtr: &[]string{"test"}[0],
+				Tags:    []string{"a"},
+			},
+		},
+		{
+			name: "changed",
+			in: &testStruct{
+				Name:    "  test  ",
+				NamePtr: &[]string{"  test  "}[0],
+				Tags:    []string{"a"},
+			},
+			out: &testStruct{
+				Name:    "test",
+				NamePtr: &[]string{"test"}[0],
+				Tags:    []string{"a"},
+			},
+			changed: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orig := fmt.Sprintf("%+v", tt.in)
+
+			out, changed, err := trans.TransformCOW(tt.in)
+			require.NoError(t, err)
+			require.Equal(t, tt.changed, changed)
+			require.Equal(t, tt.out, out)
+			require.Equal(t, orig, fmt.Sprintf("%+v", tt.in))
+
+			if !changed {
+				require.Same(t, tt.in, out)
+			}
+		})
+	}
+}
+
+func TestTransformCOWFuncs(t *testing.T) {
+	type node struct {
+		Name     string `transform:"trim"`
+		OnChange func(string)
+		Format   func(string) string `transform:"-"`
+		Parent   *node
+	}
+
+	trans := transform.New()
+
+	in := &node{Name: "test", OnChange: func(string) {}, Format: strings.ToUpper}
+	in.Parent = in
+
+	out, changed, err := trans.TransformCOW(in)
+	require.NoError(t, err)
+	require.False(t, changed)
+	require.Same(t, in, out)
+
+	in.Name = " test "
+
+	out, changed, err = trans.TransformCOW(in)
+	require.NoError(t, err)
+	require.True(t, changed)
+	require.Equal(t, "test", out.(*node).Name)
+	require.Equal(t, " test ", in.Name)
+}
+
+func BenchmarkStructPipeline(b *testing.B) {
+	trans := transform.New()
+
+	type testStruct struct {
+		Name string `transform:"trim,lowercase,rtrim,uppercase"`
+	}
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		err := trans.Transform(&testStruct{Name: "  Test Name  "})
+		require.NoError(b, err)
+	}
+}
+
+func TestErrorOnUnexported(t *testing.T) {
+	type testStruct struct {
+		Name string `transform:"trim"`
+		name string `transform:"trim"`
+	}
+
+	in := &testStruct{Name: "  test  ", name: "  test  "}
+
+	err := transform.New().Transform(in)
+	require.NoError(t, err)
+	require.Equal(t, "test", in.Name)
+	require.Equal(t, "  test  ", in.name)
+
+	err = transform.New(transform.WithErrorOnUnexported()).Transform(in)
+	require.ErrorIs(t, err, transform.ErrUnexportedField)
+	require.ErrorContains(t, err, "name")
+}
+
+type paymentMethod interface {
+	Method() string
+}
+
+type card struct {
+	Holder string
+}
+
+func (c *card) Method() string {
+	return "card"
+}
+
+type invoice struct {
+	Reference string
+}
+
+func (i invoice) Method() string {
+	return "invoice"
+}
+
+func TestRegisterInterfaceHandler(t *testing.T) {
+	trans := transform.New()
+
+	err := trans.RegisterInterfaceHandler(reflect.TypeOf((*paymentMethod)(nil)).Elem(), func(fl transform.FieldLevel) error {
+		switch v := fl.Field().Interface().(type) {
+		case *card:
+			v.Holder = strings.ToUpper(v.Holder)
+		case invoice:
+			v.Reference = strings.TrimSpace(v.Reference)
+			fl.Field().Set(reflect.ValueOf(v))
+		}
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	err = trans.RegisterInterfaceHandler(reflect.TypeOf(card{}), nil)
+	require.ErrorIs(t, err, transform.ErrNoInterface)
+
+	type testStruct struct {
+		Payment paymentMethod
+		Other   paymentMethod `transform:"-"`
+		hidden  paymentMethod
+	}
+
+	tests := []struct {
+		name string
+		in   *testStruct
+		out  *testStruct
+	}{
+		{
+			name: "empty",
+			in:   &testStruct{},
+			out:  &testStruct{},
+		},
+		{
+			name: "pointer",
+			in:   &testStruct{Payment: &card{Holder: "john doe"}, Other: &card{Holder: "john doe"}},
+			out:  &testStruct{Payment: &card{Holder: "JOHN DOE"}, Other: &card{Holder: "john doe"}},
+		},
+		{
+			name: "value",
+			in:   &testStruct{Payment: invoice{Reference: "  123  "}},
+			out:  &testStruct{Payment: invoice{Reference: "123"}},
+		},
+		{
+			name: "unexported",
+			in:   &testStruct{hidden: invoice{Reference: "  123  "}},
+			out:  &testStruct{hidden: invoice{Reference: "  123  "}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := trans.Transform(tt.in)
+			require.NoError(t, err)
+			require.Equal(t, tt.out, tt.in)
+		})
+	}
+
+	in := &testStruct{Payment: invoice{Reference: "  123  "}}
+	require.NoError(t, trans.TransformMasked(in, transform.Paths{"Other"}))
+	require.Equal(t, invoice{Reference: "  123  "}, in.Payment)
+
+	// the values changed by handlers are reported
+	changes, err := trans.Plan(&testStruct{Payment: &card{Holder: "john"}})
+	require.NoError(t, err)
+	require.Equal(t, transform.Changes{
+		{Path: "Payment.Holder", Pointer: "/Payment/Holder", Old: "john", New: "JOHN", Funcs: []string{"transform_test.paymentMethod"}},
+	}, changes)
+
+	patch, err := trans.TransformPatch(&testStruct{Payment: invoice{Reference: "  123  "}})
+	require.NoError(t, err)
+	require.Equal(t, []transform.PatchOperation{{Op: "replace", Path: "/Payment/Reference", Value: "123"}}, patch)
+}
+
+func TestNestedStruct(t *testing.T) {
+	trans := transform.New()
+
+	type address struct {
+		City string `transform:"trim,uppercase"`
+	}
+
+	type testStruct struct {
+		Name    string `transform:"trim"`
+		Address address
+		Billing *address
+		Inline  struct {
+			Street string `transform:"trim"`
+			Nested struct {
+				Zip string `transform:"trim"`
+			}
+		}
+	}
+
+	tests := []struct {
+		name string
+		in   func() *testStruct
+		out  func() *testStruct
+	}{
+		{
+			name: "empty",
+			in:   func() *testStruct { return &testStruct{} },
+			out:  func() *testStruct { return &testStruct{} },
+		},
+		{
+			name: "nested",
+			in: func() *testStruct {
+				s := &testStruct{Name: "  test  ", Address: address{City: " berlin "}, Billing: &address{City: " jena "}}
+				s.Inline.Street = "  main street  "
+				s.Inline.Nested.Zip = " 07745 "
+
+				return s
+			},
+			out: func() *testStruct {
+				s := &testStruct{Name: "test", Address: address{City: "BERLIN"}, Billing: &address{City: "JENA"}}
+				s.Inline.Street = "main street"
+				s.Inline.Nested.Zip = "07745"
+
+				return s
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := tt.in()
+			err := trans.Transform(in)
+			require.NoError(t, err)
+			require.Equal(t, tt.out(), in)
+		})
+	}
+}
+
+func TestNestedSharedStruct(t *testing.T) {
+	type counter struct {
+		Value paymentMethod
+	}
+
+	type testStruct struct {
+		First  *counter
+		Second *counter
+	}
+
+	calls := 0
+	paths := []string{}
+	indexes := [][]int{}
+
+	trans := transform.New()
+	err := trans.RegisterInterfaceHandler(reflect.TypeOf((*paymentMethod)(nil)).Elem(), func(fl transform.FieldLevel) error {
+		calls++
+		paths = append(paths, fl.Path())
+		indexes = append(indexes, fl.Index())
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	c := &counter{Value: &card{}}
+	err = trans.Transform(&testStruct{First: c, Second: c})
+	require.NoError(t, err)
+	require.Equal(t, 1, calls)
+	require.Equal(t, []string{"First.Value"}, paths)
+	require.Equal(t, [][]int{{0, 0}}, indexes)
+}
+
+func TestArrayOfStruct(t *testing.T) {
+	trans := transform.New()
+
+	type address struct {
+		City string `transform:"trim,uppercase"`
+	}
+
+	type testStruct struct {
+		Array    [2]address
+		Slice    []address
+		Pointers []*address
+	}
+
+	tests := []struct {
+		name string
+		in   *testStruct
+		out  *testStruct
+	}{
+		{
+			name: "empty",
+			in:   &testStruct{},
+			out:  &testStruct{},
+		},
+		{
+			name: "elements",
+			in: &testStruct{
+				Array:    [2]address{{City: " berlin "}, {City: " jena "}},
+				Slice:    []address{{City: " munich "}},
+				Pointers: []*address{{City: " hamburg "}, nil},
+			},
+			out: &testStruct{
+				Array:    [2]address{{City: "BERLIN"}, {City: "JENA"}},
+				Slice:    []address{{City: "MUNICH"}},
+				Pointers: []*address{{City: "HAMBURG"}, nil},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := trans.Transform(tt.in)
+			require.NoError(t, err)
+			require.Equal(t, tt.out, tt.in)
+		})
+	}
+}
+
+func TestArrayOfStructPath(t *testing.T) {
+	type element struct {
+		Value paymentMethod
+	}
+
+	type testStruct struct {
+		Elements [2]element
+	}
+
+	paths := []string{}
+
+	trans := transform.New()
+	err := trans.RegisterInterfaceHandler(reflect.TypeOf((*paymentMethod)(nil)).Elem(), func(fl transform.FieldLevel) error {
+		paths = append(paths, fl.Path())
+		return nil
+	})
+	require.NoError(t, err)
+
+	err = trans.Transform(&testStruct{Elements: [2]element{{Value: &card{}}, {Value: &card{}}}})
+	require.NoError(t, err)
+	require.Equal(t, []string{"Elements[0].Value", "Elements[1].Value"}, paths)
+}
+
+func TestUnsupportedKinds(t *testing.T) {
+	trans := transform.New()
+
+	ch := make(chan int)
+	fn := func() {}
+	i := 42
+
+	type nested struct {
+		Chan chan int `transform:"trim"`
+		Name string   `transform:"trim"`
+	}
+
+	type testStruct struct {
+		Chan    chan int       `transform:"trim"`
+		ChanPtr *chan int      `transform:"trim"`
+		Func    func()         `transform:"trim"`
+		Unsafe  unsafe.Pointer `transform:"trim"`
+		Chans   []chan int     `transform:"trim"`
+		Nested  nested
+		Name    string `transform:"trim"`
+	}
+
+	in := &testStruct{
+		Chan:    ch,
+		ChanPtr: &ch,
+		Func:    fn,
+		Unsafe:  unsafe.Pointer(&i),
+		Chans:   []chan int{ch, nil},
+		Nested:  nested{Chan: ch, Name: "  nested  "},
+		Name:    "  test  ",
+	}
+
+	err := trans.Transform(in)
+	require.NoError(t, err)
+	require.Equal(t, "test", in.Name)
+	require.Equal(t, "nested", in.Nested.Name)
+	require.Equal(t, ch, in.Chan)
+	require.Equal(t, unsafe.Pointer(&i), in.Unsafe)
+	require.NotNil(t, in.Func)
+
+	in.Func = nil
+	in.Name = "  test  "
+
+	out, changed, err := trans.TransformCOW(in)
+	require.NoError(t, err)
+	require.True(t, changed)
+	require.Equal(t, ch, out.(*testStruct).Chan)
+	require.Equal(t, "  test  ", in.Name)
+}
+
+type page[T any] struct {
+	Items []T
+	Query string `transform:"trim,lowercase"`
+	Next  *page[T]
+}
+
+type item struct {
+	Name string `transform:"trim"`
+}
+
+func TestGenericStruct(t *testing.T) {
+	trans := transform.New()
+
+	structs := &page[item]{
+		Items: []item{{Name: "  first  "}, {Name: "  second  "}},
+		Query: "  QUERY  ",
+		Next:  &page[item]{Query: "  NEXT  "},
+	}
+
+	err := trans.Transform(structs)
+	require.NoError(t, err)
+	require.Equal(t, &page[item]{
+		Items: []item{{Name: "first"}, {Name: "second"}},
+		Query: "query",
+		Next:  &page[item]{Query: "next"},
+	}, structs)
+
+	strs := &page[string]{
+		Items: []string{"  first  "},
+		Query: "  QUERY  ",
+	}
+
+	err = trans.Transform(strs)
+	require.NoError(t, err)
+	require.Equal(t, &page[string]{
+		Items: []string{"  first  "},
+		Query: "query",
+	}, strs)
+}
+
+func TestWithTransformation(t *testing.T) {
+	reverse := func(fl transform.FieldLevel) error {
+		r := []rune(fl.String())
+		for i, j := 0, len(r)-1; i < j; i, j = i+1, j-1 {
+			r[i], r[j] = r[j], r[i]
+		}
+
+		transform.SetString(fl, string(r))
+
+		return nil
+	}
+
+	// trim is replaced, so it must not be run by the buffer engine
+	trans := transform.New(
+		transform.WithTransformation("reverse", reverse),
+		transform.WithTransformation("trim", reverse),
+	)
+
+	type testStruct struct {
+		Name string `transform:"reverse,uppercase"`
+		City string `transform:"lowercase,trim"`
+	}
+
+	in := &testStruct{Name: "abc ", City: "JENA "}
+
+	err := trans.Transform(in)
+	require.NoError(t, err)
+	require.Equal(t, &testStruct{Name: " CBA", City: " anej"}, in)
+}
+
+func TestWithKindDefaults(t *testing.T) {
+	trans := transform.New(transform.WithKindDefaults(reflect.String, "trim,lowercase"))
+
+	type testStruct struct {
+		Name    string
+		City    *string
+		Code    string `transform:"trim"`
+		Raw     string `transform:"-"`
+		private string
+	}
+
+	city := " JENA "
+	in := &testStruct{Name: " JOHN ", City: &city, Code: " AB ", Raw: " RAW ", private: " X "}
+
+	err := trans.Transform(in)
+	require.NoError(t, err)
+	require.Equal(t, "john", in.Name)
+	require.Equal(t, "jena", *in.City)
+	require.Equal(t, "AB", in.Code)
+	require.Equal(t, " RAW ", in.Raw)
+	require.Equal(t, " X ", in.private)
+}
+
+func TestWithSkipFunc(t *testing.T) {
+	type address struct {
+		City string `transform:"trim"`
+		Zip  string `transform:"trim"`
+	}
+
+	type testStruct struct {
+		Name      string `transform:"trim"`
+		Email     string `transform:"trim"`
+		Address   address
+		Addresses []address
+	}
+
+	mask := map[string]bool{"Name": true, "Address": true, "Address.City": true}
+
+	trans := transform.New(transform.WithSkipFunc(func(fl transform.FieldLevel) bool {
+		return !mask[fl.Path()]
+	}))
+
+	in := &testStruct{
+		Name:      " name ",
+		Email:     " email ",
+		Address:   address{City: " city ", Zip: " zip "},
+		Addresses: []address{{City: " city "}},
+	}
+
+	err := trans.Transform(in)
+	require.NoError(t, err)
+	require.Equal(t, &testStruct{
+		Name:      "name",
+		Email:     " email ",
+		Address:   address{City: "city", Zip: " zip "},
+		Addresses: []address{{City: " city "}},
+	}, in)
+}
+
+func TestWithTransformations(t *testing.T) {
+	exclaim := func(fl transform.FieldLevel) error {
+		transform.SetString(fl, fl.String()+"!")
+		return nil
+	}
+
+	trans := transform.New(transform.WithTransformations(map[string]transform.Func{
+		"exclaim":   exclaim,
+		"lowercase": exclaim,
+	}))
+
+	type testStruct struct {
+		Name  string `transform:"trim,exclaim"`
+		Lower string `transform:"lowercase"`
+	}
+
+	in := &testStruct{Name: " Hello ", Lower: "ABC"}
+
+	err := trans.Transform(in)
+	require.NoError(t, err)
+	require.Equal(t, &testStruct{Name: "Hello!", Lower: "ABC!"}, in)
+
+	// other transformers keep their functions
+	in = &testStruct{Name: " Hello ", Lower: "ABC"}
+
+	err = transform.New().Transform(in)
+	require.NoError(t, err)
+	require.Equal(t, &testStruct{Name: "Hello", Lower: "abc"}, in)
+}
+
+func TestUnsupportedKind(t *testing.T) {
+	type testStruct struct {
+		Count   int      `transform:"trim"`
+		Missing *string  `transform:"trim"`
+		IDs     []int    `transform:"dive,trim"`
+		Name    string   `transform:"trim"`
+		Ratio   *float64 `transform:"trim"`
+		Tags    []string `transform:"dive,trim"`
+		Plain   int
+		City    string `transform:"uppercase"`
+	}
+
+	s := testStruct{Count: 1, Name: " John ", Tags: []string{" a "}, City: "berlin"}
+
+	err := transform.New().Transform(&s)
+	require.NoError(t, err)
+	require.Equal(t, "John", s.Name)
+	require.Equal(t, []string{"a"}, s.Tags)
+	require.Equal(t, "BERLIN", s.City)
+
+	tests := []struct {
+		name string
+		in   interface{}
+		path string
+		kind reflect.Kind
+	}{
+		{name: "int", in: &testStruct{}, path: "Count", kind: reflect.Int},
+		{name: "slice", in: &struct {
+			IDs []int `transform:"dive,trim"`
+		}{}, path: "IDs", kind: reflect.Slice},
+		{name: "nil pointer", in: &struct {
+			Ratio *float64 `transform:"trim"`
+		}{}, path: "Ratio", kind: reflect.Float64},
+	}
+
+	trans := transform.New(transform.WithErrorOnUnsupportedKind())
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := trans.Transform(tc.in)
+			require.ErrorIs(t, err, transform.ErrUnsupportedKind)
+
+			var kerr *transform.KindError
+			require.ErrorAs(t, err, &kerr)
+			require.Equal(t, tc.path, kerr.Path)
+			require.Equal(t, tc.kind, kerr.Kind)
+		})
+	}
+
+	err = trans.Transform(&struct {
+		Plain   int
+		Name    *string  `transform:"trim"`
+		Tags    []string `transform:"dive,trim"`
+		Address struct {
+			City string `transform:"trim"`
+		}
+	}{})
+	require.NoError(t, err)
+}
+
+func TestTransformCopy(t *testing.T) {
+	type testStruct struct {
+		Name *string  `transform:"trim"`
+		Tags []string `transform:"dive,lowercase"`
+		Fail string   `transform:"fail"`
+	}
+
+	trans := transform.New(transform.WithTransformation("fail", func(fl transform.FieldLevel) error {
+		if fl.String() != "" {
+			return fmt.Errorf("failed")
+		}
+
+		return nil
+	}))
+
+	name := " John "
+	in := &testStruct{Name: &name, Tags: []string{"A"}}
+
+	out, err := trans.TransformCopy(in)
+	require.NoError(t, err)
+	require.Equal(t, "John", *out.(*testStruct).Name)
+	require.Equal(t, []string{"a"}, out.(*testStruct).Tags)
+	require.Equal(t, " John ", name)
+	require.Equal(t, []string{"A"}, in.Tags)
+
+	// the copy is returned even if nothing changed
+	unchanged := &testStruct{}
+	out, err = trans.TransformCopy(unchanged)
+	require.NoError(t, err)
+	require.NotSame(t, unchanged, out)
+
+	_, err = trans.TransformCopy(&testStruct{Fail: "x"})
+	require.ErrorContains(t, err, "Fail: fail: failed")
+
+	_, err = trans.TransformCopy(testStruct{})
+	require.ErrorIs(t, err, transform.ErrNoPointer)
+}
+
+type BaseRequest struct {
+	ID      string `json:"id" transform:"trim,fail"`
+	Country string `json:"country" transform:"trim,uppercase"`
+}
+
+type auditInfo struct {
+	User string `json:"user" transform:"trim,lowercase"`
+}
+
+type embeddedRequest struct {
+	BaseRequest
+	*auditInfo
+	Name string `json:"name" transform:"trim"`
+}
+
+type taggedRequest struct {
+	BaseRequest `json:"base"`
+}
+
+func TestEmbedded(t *testing.T) {
+	trans := transform.New(transform.WithTransformation("fail", func(fl transform.FieldLevel) error {
+		if fl.String() == "fail" {
+			return fmt.Errorf("failed")
+		}
+
+		return nil
+	}))
+
+	compiled, err := trans.Compile(embeddedRequest{})
+	require.NoError(t, err)
+
+	funcs := map[string]func(s interface{}) error{
+		"transform": trans.Transform,
+		"compiled":  compiled.Transform,
+	}
+
+	for name, fn := range funcs {
+		t.Run(name, func(t *testing.T) {
+			in := &embeddedRequest{
+				BaseRequest: BaseRequest{ID: " 1 ", Country: " de "},
+				auditInfo:   &auditInfo{User: " Jane "},
+				Name:        " Jane ",
+			}
+
+			require.NoError(t, fn(in))
+			require.Equal(t, BaseRequest{ID: "1", Country: "DE"}, in.BaseRequest)
+			require.Equal(t, "jane", in.User)
+			require.Equal(t, "Jane", in.Name)
+
+			require.NoError(t, fn(&embeddedRequest{BaseRequest: BaseRequest{ID: "1"}}))
+		})
+	}
+
+	err = trans.Transform(&embeddedRequest{BaseRequest: BaseRequest{ID: "fail"}})
+
+	var ferr *transform.FieldError
+	require.ErrorAs(t, err, &ferr)
+	require.Equal(t, "BaseRequest.ID", ferr.Path)
+	require.Equal(t, "/id", ferr.Pointer)
+
+	// embedded structs named by a json tag are not promoted
+	err = trans.Transform(&taggedRequest{BaseRequest: BaseRequest{ID: "fail"}})
+	require.ErrorAs(t, err, &ferr)
+	require.Equal(t, "/base/id", ferr.Pointer)
+
+	rules, err := trans.Rules(embeddedRequest{})
+	require.NoError(t, err)
+
+	paths := []string{}
+	for _, r := range rules {
+		paths = append(paths, r.JSONPath)
+	}
+
+	require.Equal(t, []string{"id", "country", "user", "name"}, paths)
+
+	visited := []string{}
+	require.NoError(t, transform.Walk(&embeddedRequest{auditInfo: &auditInfo{}}, func(fl transform.FieldLevel) error {
+		visited = append(visited, fl.Path())
+		return nil
+	}))
+	require.Equal(t, []string{"BaseRequest", "BaseRequest.ID", "BaseRequest.Country", "auditInfo.User", "Name"}, visited)
+
+	// the mask selects promoted fields by their json names
+	in := &embeddedRequest{BaseRequest: BaseRequest{ID: " 1 ", Country: " de "}}
+	require.NoError(t, trans.TransformMasked(in, transform.Paths{"country"}))
+	require.Equal(t, BaseRequest{ID: " 1 ", Country: "DE"}, in.BaseRequest)
+}
+
+func TestFieldLevelTag(t *testing.T) {
+	type testStruct struct {
+		Name string `json:"name,omitempty" db:"user_name" transform:"trim,wrap=[ \\, ]"`
+	}
+
+	type seen struct {
+		tag, transform, json, db, missing, param string
+		funcs                                    []string
+	}
+
+	var got seen
+
+	trans := transform.New(transform.WithTransformation("wrap", func(fl transform.FieldLevel) error {
+		got = seen{
+			tag:       fl.GetTag(),
+			transform: fl.Tag("transform"),
+			json:      fl.Tag("json"),
+			db:        fl.Tag("db"),
+			missing:   fl.Tag("xml"),
+			param:     fl.Param(),
+			funcs:     fl.Funcs(),
+		}
+
+		return nil
+	}))
+
+	err := trans.Transform(&testStruct{Name: " John "})
+	require.NoError(t, err)
+	require.Equal(t, seen{
+		tag:       `trim,wrap=[ \, ]`,
+		transform: `trim,wrap=[ \, ]`,
+		json:      "name,omitempty",
+		db:        "user_name",
+		param:     "[ , ]",
+		funcs:     []string{"trim", "wrap=[ , ]"},
+	}, got)
+}
+
+func TestFieldLevelPathIndex(t *testing.T) {
+	type address struct {
+		City string `transform:"record"`
+	}
+
+	type Base struct {
+		ID string `transform:"record"`
+	}
+
+	type testStruct struct {
+		Base
+		Name      string `transform:"record"`
+		Address   *address
+		Addresses []address
+	}
+
+	type seen struct {
+		path  string
+		index []int
+	}
+
+	var got []seen
+
+	trans := transform.New(transform.WithTransformation("record", func(fl transform.FieldLevel) error {
+		got = append(got, seen{fl.Path(), fl.Index()})
+		return nil
+	}))
+
+	in := &testStruct{Address: &address{}, Addresses: []address{{}, {}}}
+
+	err := trans.Transform(in)
+	require.NoError(t, err)
+	require.Equal(t, []seen{
+		{"Base.ID", []int{0, 0}},
+		{"Name", []int{1}},
+		{"Address.City", []int{2, 0}},
+		{"Addresses[0].City", []int{0}},
+		{"Addresses[1].City", []int{0}},
+	}, got)
+
+	for _, s := range got[:3] {
+		require.True(t, reflect.ValueOf(in).Elem().FieldByIndex(s.index).IsValid(), s.path)
+	}
+}
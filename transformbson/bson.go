@@ -0,0 +1,51 @@
+// Package transformbson transforms documents before they are stored in MongoDB,
+// so sanitization is standardized at the persistence layer. The fields are named by their bson tags:
+//
+//	t := transformbson.New()
+//
+//	doc, err := transformbson.Document(t, &user)
+//	if err != nil {
+//		return err
+//	}
+//
+//	_, err = collection.InsertOne(ctx, doc)
+package transformbson
+
+import (
+	"fmt"
+
+	"github.com/zeiss/go-transform"
+)
+
+// New returns a transformer naming the fields in errors and rules by their bson tags
+func New(opts ...transform.TransformerOpt) *transform.TransformerImpl {
+	return transform.New(append([]transform.TransformerOpt{transform.WithNameTag("bson")}, opts...)...)
+}
+
+// Document returns a transformed copy of the document for an insertion or a replacement,
+// the document is a pointer to a struct and is not modified
+func Document(t *transform.TransformerImpl, doc interface{}) (interface{}, error) {
+	cp, _, err := t.TransformCOW(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	return cp, nil
+}
+
+// Documents returns transformed copies of the documents for InsertMany,
+// the errors name the index of the failed document
+func Documents(t *transform.TransformerImpl, docs ...interface{}) ([]interface{}, error) {
+	out := make([]interface{}, len(docs))
+
+	for i, doc := range docs {
+		cp, err := Document(t, doc)
+		if err != nil {
+			return nil, fmt.Errorf("document %d: %w", i, err)
+		}
+
+		out[i] = cp
+	}
+
+	return out, nil
+}
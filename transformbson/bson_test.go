@@ -0,0 +1,66 @@
+package transformbson_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/zeiss/go-transform"
+	"github.com/zeiss/go-transform/transformbson"
+
+	"github.com/stretchr/testify/require"
+)
+
+type address struct {
+	City string `bson:"city" json:"town" transform:"trim,fail"`
+}
+
+type user struct {
+	ID      string  `bson:"_id,omitempty"`
+	Email   string  `bson:"email" transform:"trim,lowercase"`
+	Address address `bson:"address"`
+}
+
+var errFail = errors.New("failed")
+
+func newTransformer() *transform.TransformerImpl {
+	return transformbson.New(transform.WithTransformation("fail", func(fl transform.FieldLevel) error {
+		if fl.String() == "fail" {
+			return errFail
+		}
+
+		return nil
+	}))
+}
+
+func TestDocument(t *testing.T) {
+	u := &user{ID: "1", Email: " Jane@Example.com ", Address: address{City: " Berlin "}}
+
+	doc, err := transformbson.Document(newTransformer(), u)
+	require.NoError(t, err)
+	require.Equal(t, &user{ID: "1", Email: "jane@example.com", Address: address{City: "Berlin"}}, doc)
+	require.Equal(t, " Jane@Example.com ", u.Email)
+
+	_, err = transformbson.Document(newTransformer(), &user{Address: address{City: "fail"}})
+	require.ErrorIs(t, err, errFail)
+
+	var ferr *transform.FieldError
+	require.ErrorAs(t, err, &ferr)
+	require.Equal(t, "/address/city", ferr.Pointer)
+	require.Equal(t, "city", ferr.JSON)
+}
+
+func TestDocuments(t *testing.T) {
+	docs, err := transformbson.Documents(newTransformer(), &user{Email: " A "}, &user{Email: "b"})
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{&user{Email: "a"}, &user{Email: "b"}}, docs)
+
+	_, err = transformbson.Documents(newTransformer(), &user{}, &user{Address: address{City: "fail"}})
+	require.ErrorIs(t, err, errFail)
+	require.ErrorContains(t, err, "document 1: Address.City")
+}
+
+func TestRules(t *testing.T) {
+	rules, err := newTransformer().Rules(user{})
+	require.NoError(t, err)
+	require.Equal(t, "address.city", rules[1].JSONPath)
+}
@@ -0,0 +1,112 @@
+// Package transformcel registers the cel function in the default registry, it evaluates
+// a Google CEL expression and sets the field to its result. Import it for its side effects:
+//
+//	import _ "github.com/zeiss/go-transform/transformcel"
+//
+// The expression is the parameter of the function, the value of the field is the
+// variable value and the name of the field is the variable field. The string
+// extensions (e.g. lowerAscii, trim, replace) are available.
+//
+//	Country string `transform:"cel=value == '' ? 'N/A' : value.upperAscii()"`
+//
+// Expressions must evaluate to a string, they are compiled once and cached.
+// Comprehensions (e.g. map and filter) stop when the context of TransformCtx is done.
+package transformcel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/ext"
+
+	"github.com/zeiss/go-transform"
+)
+
+// ErrNoString is returned if an expression does not evaluate to a string
+var ErrNoString = errors.New("transformcel: expression must evaluate to a string")
+
+// interruptCheckFrequency is the number of iterations of a comprehension between the checks of the context
+const interruptCheckFrequency = 100
+
+var (
+	envOnce sync.Once
+	env     *cel.Env
+	envErr  error
+
+	// programs caches the compiled programs by their expression
+	programs sync.Map
+)
+
+func init() {
+	transform.Register("cel", transform.FuncCtx(celFunc).Func())
+}
+
+// celFunc evaluates the expression in the parameter with the context of the transformation
+func celFunc(ctx context.Context, fl transform.FieldLevel) error {
+	prg, err := Compile(fl.Param())
+	if err != nil {
+		return err
+	}
+
+	out, _, err := prg.ContextEval(ctx, map[string]interface{}{
+		"value": fl.String(),
+		"field": fl.FieldName(),
+	})
+	if cerr := ctx.Err(); err != nil && cerr != nil {
+		return fmt.Errorf("transformcel: %w", cerr)
+	}
+
+	if err != nil {
+		return fmt.Errorf("transformcel: %w", err)
+	}
+
+	s, ok := out.Value().(string)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrNoString, fl.Param())
+	}
+
+	transform.SetString(fl, s)
+
+	return nil
+}
+
+// Compile compiles the expression, it is used to validate expressions of
+// tags or rule files before they are used
+func Compile(expr string) (cel.Program, error) {
+	if prg, ok := programs.Load(expr); ok {
+		return prg.(cel.Program), nil
+	}
+
+	envOnce.Do(func() {
+		env, envErr = cel.NewEnv(
+			cel.Variable("value", cel.StringType),
+			cel.Variable("field", cel.StringType),
+			ext.Strings(),
+		)
+	})
+
+	if envErr != nil {
+		return nil, fmt.Errorf("transformcel: %w", envErr)
+	}
+
+	ast, iss := env.Compile(expr)
+	if iss.Err() != nil {
+		return nil, fmt.Errorf("%w: %s", transform.ErrInvalidParam, iss.Err())
+	}
+
+	if !ast.OutputType().IsExactType(cel.StringType) && !ast.OutputType().IsExactType(cel.DynType) {
+		return nil, fmt.Errorf("%w: %s", ErrNoString, expr)
+	}
+
+	prg, err := env.Program(ast, cel.InterruptCheckFrequency(interruptCheckFrequency))
+	if err != nil {
+		return nil, fmt.Errorf("transformcel: %w", err)
+	}
+
+	programs.Store(expr, prg)
+
+	return prg, nil
+}
@@ -0,0 +1,63 @@
+package transformcel_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zeiss/go-transform"
+	"github.com/zeiss/go-transform/transformcel"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCEL(t *testing.T) {
+	type testStruct struct {
+		Country string `transform:"trim,cel=value == '' ? 'N/A' : value.upperAscii()"`
+		Empty   string `transform:"cel=value == '' ? 'N/A' : value"`
+		Field   string `transform:"cel=field + ':' + value"`
+	}
+
+	in := &testStruct{Country: " de ", Field: "x"}
+
+	err := transform.Transform(in)
+	require.NoError(t, err)
+	require.Equal(t, &testStruct{Country: "DE", Empty: "N/A", Field: "Field:x"}, in)
+}
+
+func TestCELErrors(t *testing.T) {
+	_, err := transformcel.Compile("value.")
+	require.ErrorIs(t, err, transform.ErrInvalidParam)
+
+	_, err = transformcel.Compile("size(value)")
+	require.ErrorIs(t, err, transformcel.ErrNoString)
+
+	type testStruct struct {
+		Name string `transform:"cel=value + undefined"`
+	}
+
+	err = transform.Transform(&testStruct{})
+	require.ErrorIs(t, err, transform.ErrInvalidParam)
+}
+
+func TestCELContext(t *testing.T) {
+	type testStruct struct {
+		Name string `transform:"cel=value.split('').map(c\\, c.upperAscii()).join('')"`
+	}
+
+	in := &testStruct{Name: "abc"}
+
+	err := transform.TransformCtx(context.Background(), in)
+	require.NoError(t, err)
+	require.Equal(t, "ABC", in.Name)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+
+	err = transform.TransformCtx(ctx, &testStruct{Name: strings.Repeat("a", 1<<20)})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Less(t, time.Since(start), time.Second)
+}
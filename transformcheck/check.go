@@ -0,0 +1,180 @@
+// Package transformcheck property tests transform functions against laws
+// (e.g. idempotence), so misbehaving custom functions are caught in the tests of a project:
+//
+//	func TestSlug(t *testing.T) {
+//		trans := transform.New(transform.WithTransformation("slug", slug))
+//		require.NoError(t, transformcheck.Check(trans, "slug", transformcheck.Idempotent, transformcheck.PreservesUTF8))
+//	}
+package transformcheck
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing/quick"
+	"unicode/utf8"
+
+	"github.com/zeiss/go-transform"
+)
+
+// DefaultCount is the number of random values checked by Check
+const DefaultCount = 500
+
+// Applier applies the functions of a tag to a value
+type Applier func(s string) (string, error)
+
+// Law is a property the functions of a tag must satisfy for every value they accept
+type Law struct {
+	// Name is the name of the law
+	Name string
+	// Holds returns true if the law holds for the value and the result of its transformation
+	Holds func(apply Applier, in, out string) bool
+}
+
+// Idempotent requires that transforming a result again does not change it
+var Idempotent = Law{
+	Name: "idempotent",
+	Holds: func(apply Applier, _, out string) bool {
+		again, err := apply(out)
+		return err == nil && again == out
+	},
+}
+
+// PreservesUTF8 requires that valid UTF-8 stays valid UTF-8
+var PreservesUTF8 = Law{
+	Name: "preserves UTF-8",
+	Holds: func(_ Applier, in, out string) bool {
+		return !utf8.ValidString(in) || utf8.ValidString(out)
+	},
+}
+
+// NotLonger requires that the result has at most as many characters as the value
+var NotLonger = Law{
+	Name: "not longer",
+	Holds: func(_ Applier, in, out string) bool {
+		return utf8.RuneCountInString(out) <= utf8.RuneCountInString(in)
+	},
+}
+
+// MaxLength requires that the result has at most n characters
+func MaxLength(n int) Law {
+	return Law{
+		Name: fmt.Sprintf("max length %d", n),
+		Holds: func(_ Applier, _, out string) bool {
+			return utf8.RuneCountInString(out) <= n
+		},
+	}
+}
+
+// Violation is returned if a law does not hold for a value
+type Violation struct {
+	// Law is the name of the violated law
+	Law string
+	// Tag are the checked functions
+	Tag string
+	// Input is the value violating the law
+	Input string
+	// Output is the result of the transformation of the value
+	Output string
+}
+
+// Error implements the error interface
+func (v *Violation) Error() string {
+	return fmt.Sprintf("transformcheck: %q violates %s for %q (result %q)", v.Tag, v.Law, v.Input, v.Output)
+}
+
+// Apply returns a function applying the functions of the tag (e.g. trim,truncate=64) with the transformer
+func Apply(t *transform.TransformerImpl, tag string) Applier {
+	typ := reflect.StructOf([]reflect.StructField{{
+		Name: "V",
+		Type: reflect.TypeOf(""),
+		Tag:  reflect.StructTag(fmt.Sprintf(`%s:%q`, t.TagName, tag)),
+	}})
+
+	return func(s string) (string, error) {
+		v := reflect.New(typ)
+		v.Elem().Field(0).SetString(s)
+
+		if err := t.Transform(v.Interface()); err != nil {
+			return "", err
+		}
+
+		return v.Elem().Field(0).String(), nil
+	}
+}
+
+// Check checks the laws for DefaultCount random values and the edge cases of strings
+// (e.g. the empty string, whitespace and invalid UTF-8). Values the functions fail for
+// are not in the domain of the functions and are skipped.
+func Check(t *transform.TransformerImpl, tag string, laws ...Law) error {
+	return CheckWith(&quick.Config{MaxCount: DefaultCount}, t, tag, laws...)
+}
+
+// CheckWith is like Check, the config sets the number of values and the source of randomness
+func CheckWith(cfg *quick.Config, t *transform.TransformerImpl, tag string, laws ...Law) error {
+	apply := Apply(t, tag)
+
+	var violation *Violation
+
+	check := func(in string) bool {
+		out, err := apply(in)
+		if err != nil {
+			return true // the value is not in the domain of the functions
+		}
+
+		for _, l := range laws {
+			if !l.Holds(apply, in, out) {
+				violation = &Violation{Law: l.Name, Tag: tag, Input: in, Output: out}
+				return false
+			}
+		}
+
+		return true
+	}
+
+	for _, in := range edgeCases {
+		if !check(in) {
+			return violation
+		}
+	}
+
+	c := *cfg
+	if c.Values == nil {
+		c.Values = func(args []reflect.Value, r *rand.Rand) {
+			args[0] = reflect.ValueOf(randomString(r))
+		}
+	}
+
+	if err := quick.Check(check, &c); err != nil {
+		if violation != nil {
+			return violation
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// edgeCases are the values that are always checked
+var edgeCases = []string{"", " ", "\t\n", "a", " a ", "A,b=c", "\x00", "\xff", "ß", "İ", "👍🏽", strings.Repeat("x", 1024)}
+
+// alphabet are the characters of random strings, mixing ASCII, whitespace,
+// punctuation, characters changing their length with the case and emojis
+var alphabet = []rune("abcXYZ019 \t\n-_.,;:=/\\\"'<>&%$€äöüßİıſǅﬁ😀👍🏽​ \x00\x1b")
+
+// randomString returns a random string of up to 32 characters, some with an invalid UTF-8 byte
+func randomString(r *rand.Rand) string {
+	var b strings.Builder
+
+	for i := r.Intn(33); i > 0; i-- {
+		b.WriteRune(alphabet[r.Intn(len(alphabet))])
+	}
+
+	if r.Intn(10) == 0 {
+		b.WriteByte(0xff)
+	}
+
+	return b.String()
+}
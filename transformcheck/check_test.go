@@ -0,0 +1,59 @@
+package transformcheck_test
+
+import (
+	"testing"
+
+	"github.com/zeiss/go-transform"
+	"github.com/zeiss/go-transform/transformcheck"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheck(t *testing.T) {
+	trans := transform.New()
+
+	tests := []struct {
+		tag  string
+		laws []transformcheck.Law
+	}{
+		{tag: "trim", laws: []transformcheck.Law{transformcheck.Idempotent, transformcheck.NotLonger, transformcheck.PreservesUTF8}},
+		{tag: "squish", laws: []transformcheck.Law{transformcheck.Idempotent, transformcheck.NotLonger}},
+		{tag: "truncate=5", laws: []transformcheck.Law{transformcheck.Idempotent, transformcheck.MaxLength(5)}},
+		{tag: "stripctl,validutf8", laws: []transformcheck.Law{transformcheck.Idempotent, transformcheck.PreservesUTF8}},
+		{tag: "float=2", laws: []transformcheck.Law{transformcheck.Idempotent}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.tag, func(t *testing.T) {
+			require.NoError(t, transformcheck.Check(trans, tc.tag, tc.laws...))
+		})
+	}
+}
+
+func TestCheckViolation(t *testing.T) {
+	trans := transform.New(transform.WithTransformation("exclaim", func(fl transform.FieldLevel) error {
+		transform.SetString(fl, fl.String()+"!")
+		return nil
+	}))
+
+	err := transformcheck.Check(trans, "trim,exclaim", transformcheck.Idempotent)
+	require.Error(t, err)
+
+	var v *transformcheck.Violation
+	require.ErrorAs(t, err, &v)
+	require.Equal(t, "idempotent", v.Law)
+	require.Equal(t, "", v.Input)
+	require.Equal(t, "!", v.Output)
+
+	err = transformcheck.Check(trans, "exclaim", transformcheck.MaxLength(10))
+	require.ErrorAs(t, err, &v)
+	require.Equal(t, "max length 10", v.Law)
+}
+
+func TestApply(t *testing.T) {
+	apply := transformcheck.Apply(transform.New(), "trim,uppercase")
+
+	out, err := apply(" abc ")
+	require.NoError(t, err)
+	require.Equal(t, "ABC", out)
+}
@@ -0,0 +1,44 @@
+// Package transformcrypto registers hashing transform functions in the default registry.
+// Import it for its side effects:
+//
+//	import _ "github.com/zeiss/go-transform/transformcrypto"
+//
+// The functions replace non-empty values with their hex encoded digest
+//
+//	sha256  SHA-256
+//	sha512  SHA-512
+//
+// Use the built-in pseudonym function for values with low entropy (e.g. email addresses),
+// their plain digests can be reversed with a dictionary.
+package transformcrypto
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"hash"
+
+	"github.com/zeiss/go-transform"
+)
+
+func init() {
+	transform.Register("sha256", hashFunc(sha256.New))
+	transform.Register("sha512", hashFunc(sha512.New))
+}
+
+// hashFunc returns a transform function replacing the value with its hex encoded digest
+func hashFunc(h func() hash.Hash) transform.Func {
+	return func(fl transform.FieldLevel) error {
+		s := fl.String()
+		if s == "" {
+			return nil
+		}
+
+		d := h()
+		d.Write([]byte(s))
+
+		transform.SetString(fl, hex.EncodeToString(d.Sum(nil)))
+
+		return nil
+	}
+}
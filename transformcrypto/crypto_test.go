@@ -0,0 +1,27 @@
+package transformcrypto_test
+
+import (
+	"testing"
+
+	"github.com/zeiss/go-transform"
+	_ "github.com/zeiss/go-transform/transformcrypto"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHash(t *testing.T) {
+	type testStruct struct {
+		SHA256 string `transform:"sha256"`
+		SHA512 string `transform:"sha512"`
+		Empty  string `transform:"sha256"`
+	}
+
+	in := &testStruct{SHA256: "abc", SHA512: "abc"}
+
+	err := transform.Transform(in)
+	require.NoError(t, err)
+	require.Equal(t, &testStruct{
+		SHA256: "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad",
+		SHA512: "ddaf35a193617abacc417349ae20413112e6fa4e89a97ea20a9eeee64b55d39a2192992a274fc1a836ba3c23a3feebbd454d4423643ce80e2a9ac94fa54ca49f",
+	}, in)
+}
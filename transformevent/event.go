@@ -0,0 +1,46 @@
+// Package transformevent serializes event payloads for outbox tables and audit logs,
+// personal data and secrets are masked and the JSON is canonical, so equal events
+// have equal bytes and can be signed or deduplicated:
+//
+//	payload, err := transformevent.Scrub(&OrderPlaced{ID: id, Email: email})
+//	if err != nil {
+//		return err
+//	}
+//
+//	_, err = tx.ExecContext(ctx, "INSERT INTO outbox (payload) VALUES ($1)", payload)
+package transformevent
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/zeiss/go-transform"
+	"github.com/zeiss/go-transform/preset"
+)
+
+// ScrubFunc returns the canonical JSON of a masked copy of an event
+type ScrubFunc func(v interface{}) ([]byte, error)
+
+// scrub is the ScrubFunc of Scrub
+var scrub = NewScrubber()
+
+// Scrub returns the canonical JSON of a masked copy of the event, the event is not modified.
+// The fields of structs are masked as by preset.LogScrubber: fields tagged with log:"secret" or log:"pii"
+// and fields whose names look like secrets or personal data are redacted, log:"plain" keeps a field.
+func Scrub(v interface{}) ([]byte, error) {
+	return scrub(v)
+}
+
+// NewScrubber returns a ScrubFunc classifying fields with the additional names as secret
+func NewScrubber(names ...string) ScrubFunc {
+	mask := preset.LogScrubber(names...)
+
+	return func(v interface{}) ([]byte, error) {
+		b, err := json.Marshal(mask(v))
+		if err != nil {
+			return nil, fmt.Errorf("transformevent: %w", err)
+		}
+
+		return transform.CanonicalJSON(b)
+	}
+}
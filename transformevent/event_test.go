@@ -0,0 +1,79 @@
+package transformevent_test
+
+import (
+	"testing"
+
+	"github.com/zeiss/go-transform/transformevent"
+
+	"github.com/stretchr/testify/require"
+)
+
+type item struct {
+	SKU      string  `json:"sku"`
+	Price    float64 `json:"price"`
+	Serial   string  `json:"serial"`
+	Quantity int     `json:"quantity"`
+}
+
+type orderPlaced struct {
+	ID       string `json:"id"`
+	Email    string `json:"email"`
+	Password string `json:"password"`
+	Note     string `json:"note" log:"pii"`
+	Items    []item `json:"items"`
+}
+
+func TestScrub(t *testing.T) {
+	tests := []struct {
+		name string
+		in   interface{}
+		out  string
+	}{
+		{
+			name: "pointer",
+			in: &orderPlaced{
+				ID:       "o1",
+				Email:    "jane@example.com",
+				Password: "secret",
+				Note:     "call Jane",
+				Items:    []item{{SKU: "a", Price: 1.50, Serial: "s1", Quantity: 2}},
+			},
+			out: `{"email":"[REDACTED]","id":"o1","items":[{"price":1.5,"quantity":2,"serial":"s1","sku":"a"}],"note":"[REDACTED]","password":"[REDACTED]"}`,
+		},
+		{
+			name: "struct",
+			in:   orderPlaced{ID: "o2"},
+			out:  `{"email":"","id":"o2","items":null,"note":"","password":""}`,
+		},
+		{
+			name: "map",
+			in:   map[string]interface{}{"b": 1, "a": "x"},
+			out:  `{"a":"x","b":1}`,
+		},
+		{
+			name: "nil",
+			in:   nil,
+			out:  `null`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			b, err := transformevent.Scrub(tc.in)
+			require.NoError(t, err)
+			require.Equal(t, tc.out, string(b))
+		})
+	}
+}
+
+func TestNewScrubber(t *testing.T) {
+	in := &orderPlaced{ID: "o1", Items: []item{{SKU: "a", Serial: "s1"}}}
+
+	b, err := transformevent.NewScrubber("serial")(in)
+	require.NoError(t, err)
+	require.Equal(t, `{"email":"","id":"o1","items":[{"price":0,"quantity":0,"serial":"[REDACTED]","sku":"a"}],"note":"","password":""}`, string(b))
+	require.Equal(t, "s1", in.Items[0].Serial)
+
+	_, err = transformevent.Scrub(make(chan int))
+	require.ErrorContains(t, err, "transformevent")
+}
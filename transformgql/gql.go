@@ -0,0 +1,94 @@
+// Package transformgql transforms the input objects of GraphQL resolvers.
+//
+// GraphQL inputs bypass the usual HTTP body binding, the arguments can be transformed
+// in a gqlgen field middleware before the resolver is called:
+//
+//	t := transform.New()
+//
+//	srv.AroundFields(func(ctx context.Context, next graphql.Resolver) (interface{}, error) {
+//		if fc := graphql.GetFieldContext(ctx); fc != nil {
+//			if err := transformgql.Args(t, fc.Args); err != nil {
+//				return nil, err
+//			}
+//		}
+//
+//		return next(ctx)
+//	})
+//
+// The package has no dependency on a GraphQL library, it works on the
+// resolver arguments as map[string]interface{}.
+package transformgql
+
+import (
+	"reflect"
+
+	"github.com/zeiss/go-transform"
+)
+
+// Args transforms the input objects in the resolver arguments.
+// Pointers to structs are transformed in place, struct values are replaced
+// by their transformed copy and slices of input objects are transformed element wise.
+// A nil transformer uses the default transformer.
+func Args(t *transform.TransformerImpl, args map[string]interface{}) error {
+	if t == nil {
+		t = transform.New()
+	}
+
+	for name, arg := range args {
+		v, err := value(t, reflect.ValueOf(arg))
+		if err != nil {
+			return err
+		}
+
+		if v.IsValid() {
+			args[name] = v.Interface()
+		}
+	}
+
+	return nil
+}
+
+// value transforms the argument and returns the value to store in the arguments,
+// an invalid value is returned if the argument was transformed in place or is not an input object
+func value(t *transform.TransformerImpl, v reflect.Value) (reflect.Value, error) {
+	// nolint:exhaustive
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() || v.Elem().Kind() != reflect.Struct {
+			return reflect.Value{}, nil
+		}
+
+		return reflect.Value{}, t.Transform(v.Interface())
+	case reflect.Struct:
+		p := reflect.New(v.Type())
+		p.Elem().Set(v)
+
+		if err := t.Transform(p.Interface()); err != nil {
+			return reflect.Value{}, err
+		}
+
+		return p.Elem(), nil
+	case reflect.Slice:
+		if v.IsNil() {
+			return reflect.Value{}, nil
+		}
+
+		s := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		reflect.Copy(s, v)
+
+		for i := 0; i < s.Len(); i++ {
+			e, err := value(t, s.Index(i))
+			if err != nil {
+				return reflect.Value{}, err
+			}
+
+			if e.IsValid() {
+				s.Index(i).Set(e)
+			}
+		}
+
+		return s, nil
+	default:
+		return reflect.Value{}, nil
+	}
+}
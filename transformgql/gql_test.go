@@ -0,0 +1,55 @@
+package transformgql_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/zeiss/go-transform/transformgql"
+
+	"github.com/stretchr/testify/require"
+)
+
+type newTodo struct {
+	Text   string `transform:"trim"`
+	UserID string `transform:"trim,lowercase"`
+}
+
+func ExampleArgs() {
+	args := map[string]interface{}{
+		"input": newTodo{Text: "  Buy milk  ", UserID: " USER-1 "},
+		"limit": 10,
+	}
+
+	if err := transformgql.Args(nil, args); err != nil {
+		panic(err)
+	}
+
+	fmt.Printf("%+v\n", args["input"])
+	// Output: {Text:Buy milk UserID:user-1}
+}
+
+func TestArgs(t *testing.T) {
+	ptr := &newTodo{Text: "  ptr  "}
+	list := []newTodo{{Text: "  first  "}, {Text: "  second  "}}
+
+	args := map[string]interface{}{
+		"ptr":   ptr,
+		"value": newTodo{Text: "  value  "},
+		"list":  list,
+		"ptrs":  []*newTodo{{Text: "  pointer  "}, nil},
+		"nil":   (*newTodo)(nil),
+		"id":    "  id  ",
+	}
+
+	err := transformgql.Args(nil, args)
+	require.NoError(t, err)
+
+	require.Same(t, ptr, args["ptr"])
+	require.Equal(t, "ptr", ptr.Text)
+	require.Equal(t, newTodo{Text: "value"}, args["value"])
+	require.Equal(t, []newTodo{{Text: "first"}, {Text: "second"}}, args["list"])
+	require.Equal(t, "  first  ", list[0].Text)
+	require.Equal(t, []*newTodo{{Text: "pointer"}, nil}, args["ptrs"])
+	require.Nil(t, args["nil"])
+	require.Equal(t, "  id  ", args["id"])
+}
@@ -0,0 +1,78 @@
+// Package transformhtml registers transform functions for HTML in the default registry.
+// Import it for its side effects:
+//
+//	import _ "github.com/zeiss/go-transform/transformhtml"
+//
+// The functions are
+//
+//	striphtml     removes HTML tags and comments and unescapes entities
+//	escapehtml    escapes <, >, &, ' and "
+//	unescapehtml  unescapes entities like &lt; and &#39;
+package transformhtml
+
+import (
+	"html"
+	"strings"
+
+	"github.com/zeiss/go-transform"
+)
+
+func init() {
+	transform.Register("striphtml", stripHTMLFunc)
+	transform.Register("escapehtml", escapeHTMLFunc)
+	transform.Register("unescapehtml", unescapeHTMLFunc)
+}
+
+func stripHTMLFunc(fl transform.FieldLevel) error {
+	transform.SetString(fl, StripHTML(fl.String()))
+
+	return nil
+}
+
+func escapeHTMLFunc(fl transform.FieldLevel) error {
+	transform.SetString(fl, html.EscapeString(fl.String()))
+
+	return nil
+}
+
+func unescapeHTMLFunc(fl transform.FieldLevel) error {
+	transform.SetString(fl, html.UnescapeString(fl.String()))
+
+	return nil
+}
+
+// StripHTML removes HTML tags and comments and unescapes the entities of the text.
+// The result is plain text, it must be escaped before it is embedded in HTML again.
+func StripHTML(s string) string {
+	if !strings.ContainsAny(s, "<&") {
+		return s
+	}
+
+	var b strings.Builder
+	b.Grow(len(s))
+
+	for len(s) > 0 {
+		i := strings.IndexByte(s, '<')
+		if i < 0 {
+			b.WriteString(s)
+			break
+		}
+
+		b.WriteString(s[:i])
+		s = s[i:]
+
+		end := ">"
+		if strings.HasPrefix(s, "<!--") {
+			end = "-->"
+		}
+
+		j := strings.Index(s, end)
+		if j < 0 {
+			break // an unterminated tag is dropped
+		}
+
+		s = s[j+len(end):]
+	}
+
+	return html.UnescapeString(b.String())
+}
@@ -0,0 +1,51 @@
+package transformhtml_test
+
+import (
+	"testing"
+
+	"github.com/zeiss/go-transform"
+	_ "github.com/zeiss/go-transform/transformhtml"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTML(t *testing.T) {
+	type testStruct struct {
+		Strip    string `transform:"striphtml,trim"`
+		Escape   string `transform:"escapehtml"`
+		Unescape string `transform:"unescapehtml"`
+	}
+
+	in := &testStruct{
+		Strip:    ` <p class="x">Fish &amp; <b>Chips</b></p><!-- <i>hidden</i> --><br/> <script`,
+		Escape:   `<a href="x">Tom & Jerry</a>`,
+		Unescape: "&lt;b&gt; &#39;quoted&#39;",
+	}
+
+	err := transform.Transform(in)
+	require.NoError(t, err)
+	require.Equal(t, &testStruct{
+		Strip:    "Fish & Chips",
+		Escape:   "&lt;a href=&#34;x&#34;&gt;Tom &amp; Jerry&lt;/a&gt;",
+		Unescape: "<b> 'quoted'",
+	}, in)
+}
+
+func TestWithoutDefaults(t *testing.T) {
+	type testStruct struct {
+		Text string `transform:"striphtml"`
+	}
+
+	in := &testStruct{Text: "<b>bold</b>"}
+
+	err := transform.New(transform.WithoutDefaults()).Transform(in)
+	require.NoError(t, err)
+	require.Equal(t, "<b>bold</b>", in.Text)
+
+	require.Panics(t, func() {
+		transform.Register("striphtml", func(transform.FieldLevel) error { return nil })
+	})
+	require.Panics(t, func() {
+		transform.Register("trim", func(transform.FieldLevel) error { return nil })
+	})
+}
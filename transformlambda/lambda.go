@@ -0,0 +1,127 @@
+// Package transformlambda binds AWS API Gateway proxy events to structs and transforms them.
+package transformlambda
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+
+	"github.com/zeiss/go-transform"
+	"github.com/zeiss/go-transform/internal/binding"
+)
+
+// ErrUnsupportedMediaType is returned when the body is neither JSON nor a form
+var ErrUnsupportedMediaType = errors.New("transformlambda: unsupported media type")
+
+// Error is returned when the event can't be bound to the struct
+type Error struct {
+	// StatusCode is the HTTP status code of the response
+	StatusCode int
+	// Message is the message of the response
+	Message string
+	// Err is the underlying error
+	Err error
+}
+
+// Error implements the error interface
+func (e *Error) Error() string {
+	return e.Message + ": " + e.Err.Error()
+}
+
+// Unwrap returns the underlying error
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Response returns an API Gateway response with a JSON body describing the error
+func (e *Error) Response() events.APIGatewayProxyResponse {
+	b, _ := json.Marshal(struct {
+		Message string `json:"message"`
+		Error   string `json:"error"`
+	}{e.Message, e.Err.Error()})
+
+	return events.APIGatewayProxyResponse{
+		StatusCode: e.StatusCode,
+		Headers:    map[string]string{"Content-Type": "application/json"},
+		Body:       string(b),
+	}
+}
+
+// Bind decodes the body of the event into the struct v points to and transforms it.
+// JSON bodies are decoded with encoding/json, form bodies use the `form` tag,
+// the `json` tag or the field name as key. Errors are of type *Error.
+func Bind(event events.APIGatewayProxyRequest, v interface{}, opts ...transform.TransformerOpt) error {
+	body := []byte(event.Body)
+
+	if event.IsBase64Encoded {
+		b, err := base64.StdEncoding.DecodeString(event.Body)
+		if err != nil {
+			return &Error{http.StatusBadRequest, "invalid body encoding", err}
+		}
+
+		body = b
+	}
+
+	if err := decode(header(event.Headers, "Content-Type"), body, v); err != nil {
+		return err
+	}
+
+	if err := transform.New(opts...).Transform(v); err != nil {
+		return &Error{http.StatusUnprocessableEntity, "invalid request", err}
+	}
+
+	return nil
+}
+
+func decode(contentType string, body []byte, v interface{}) error {
+	if len(body) == 0 {
+		return nil
+	}
+
+	mt := "application/json"
+	if contentType != "" {
+		var err error
+
+		mt, _, err = mime.ParseMediaType(contentType)
+		if err != nil {
+			return &Error{http.StatusUnsupportedMediaType, "invalid content type", err}
+		}
+	}
+
+	switch {
+	case mt == "application/json" || strings.HasSuffix(mt, "+json"):
+		if err := json.Unmarshal(body, v); err != nil {
+			return &Error{http.StatusBadRequest, "invalid JSON body", err}
+		}
+	case mt == "application/x-www-form-urlencoded":
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			return &Error{http.StatusBadRequest, "invalid form body", err}
+		}
+
+		if err := binding.Decode(values, v, "form", "json"); err != nil {
+			return &Error{http.StatusBadRequest, "invalid form body", err}
+		}
+	default:
+		return &Error{http.StatusUnsupportedMediaType, "unsupported content type", ErrUnsupportedMediaType}
+	}
+
+	return nil
+}
+
+// header returns the value of the header, API Gateway does not normalize the case of header names
+func header(headers map[string]string, name string) string {
+	for k, v := range headers {
+		if strings.EqualFold(k, name) {
+			return v
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,100 @@
+package transformlambda_test
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+
+	"github.com/zeiss/go-transform/transformlambda"
+
+	"github.com/stretchr/testify/require"
+)
+
+type signup struct {
+	Email string `json:"email" transform:"trim,lowercase"`
+	Name  string `json:"name" transform:"trim"`
+}
+
+func TestBind(t *testing.T) {
+	tests := []struct {
+		name   string
+		event  events.APIGatewayProxyRequest
+		out    *signup
+		status int
+	}{
+		{
+			name:  "empty",
+			event: events.APIGatewayProxyRequest{},
+			out:   &signup{},
+		},
+		{
+			name: "json",
+			event: events.APIGatewayProxyRequest{
+				Headers: map[string]string{"content-type": "application/json; charset=utf-8"},
+				Body:    `{"email": "  John@Example.COM ", "name": " John "}`,
+			},
+			out: &signup{Email: "john@example.com", Name: "John"},
+		},
+		{
+			name: "base64",
+			event: events.APIGatewayProxyRequest{
+				Body:            base64.StdEncoding.EncodeToString([]byte(`{"email": " JOHN@EXAMPLE.COM "}`)),
+				IsBase64Encoded: true,
+			},
+			out: &signup{Email: "john@example.com"},
+		},
+		{
+			name: "form",
+			event: events.APIGatewayProxyRequest{
+				Headers: map[string]string{"Content-Type": "application/x-www-form-urlencoded"},
+				Body:    "email=+John%40Example.com+&name=+John+",
+			},
+			out: &signup{Email: "john@example.com", Name: "John"},
+		},
+		{
+			name: "invalid json",
+			event: events.APIGatewayProxyRequest{
+				Body: `{"email":`,
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name: "invalid base64",
+			event: events.APIGatewayProxyRequest{
+				Body:            "!",
+				IsBase64Encoded: true,
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name: "unsupported media type",
+			event: events.APIGatewayProxyRequest{
+				Headers: map[string]string{"Content-Type": "text/plain"},
+				Body:    "email",
+			},
+			status: http.StatusUnsupportedMediaType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &signup{}
+			err := transformlambda.Bind(tt.event, out)
+
+			if tt.status != 0 {
+				var bindErr *transformlambda.Error
+				require.True(t, errors.As(err, &bindErr))
+				require.Equal(t, tt.status, bindErr.StatusCode)
+				require.Equal(t, tt.status, bindErr.Response().StatusCode)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.out, out)
+		})
+	}
+}
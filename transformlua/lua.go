@@ -0,0 +1,224 @@
+// Package transformlua runs Lua scripts as transform functions, for teams that want
+// normalization rules that can be edited at runtime and need more than the rules DSL.
+//
+// A script defines the global function transform, it is called with the value
+// and the name of the field and returns the new value, or nil to keep the value.
+//
+//	function transform(value, field)
+//	  if value == "" then
+//	    return "N/A"
+//	  end
+//	  return string.upper(value)
+//	end
+//
+// Scripts run with the base, string, table and math libraries, without access to files,
+// the operating system, other modules, metatables and string.rep. A script runs until it
+// returns or the context of TransformCtx is done, use a context with a deadline to stop
+// scripts that do not return. The memory used by a script is not limited, limiting it
+// (e.g. by the size of the values) is the job of the caller.
+package transformlua
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	lua "github.com/yuin/gopher-lua"
+	"github.com/yuin/gopher-lua/parse"
+
+	"github.com/zeiss/go-transform"
+)
+
+// entrypoint is the name of the function a script defines
+const entrypoint = "transform"
+
+var (
+	// ErrNoEntrypoint is returned if a script does not define the transform function
+	ErrNoEntrypoint = errors.New("transformlua: script must define the function transform")
+	// ErrNoString is returned if the transform function returns a value other than a string or nil
+	ErrNoString = errors.New("transformlua: transform must return a string or nil")
+)
+
+// unsafeGlobals are removed from the base library of the Lua states
+var unsafeGlobals = []string{
+	"dofile", "loadfile", "load", "loadstring", "require", "module", "collectgarbage",
+	"setmetatable", "getmetatable",
+}
+
+// unsafeStringFuncs are removed from the string library of the Lua states
+var unsafeStringFuncs = []string{"rep"}
+
+// Script is a Lua script transforming strings.
+// It is safe for concurrent use, each goroutine runs its own Lua state.
+type Script struct {
+	prog atomic.Pointer[program]
+}
+
+// program is a compiled script with a pool of Lua states running it
+type program struct {
+	proto *lua.FunctionProto
+	pool  sync.Pool
+}
+
+// New compiles the script
+func New(src string) (*Script, error) {
+	s := &Script{}
+
+	if err := s.Reload(src); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Reload replaces the script with the new source, a script with errors is not loaded.
+// Running calls complete with the previous script.
+func (s *Script) Reload(src string) error {
+	chunk, err := parse.Parse(strings.NewReader(src), "<script>")
+	if err != nil {
+		return fmt.Errorf("transformlua: %w", err)
+	}
+
+	proto, err := lua.Compile(chunk, "<script>")
+	if err != nil {
+		return fmt.Errorf("transformlua: %w", err)
+	}
+
+	p := &program{proto: proto}
+
+	// the script is run once to check it defines the entrypoint
+	l, err := p.newState()
+	if err != nil {
+		return err
+	}
+
+	p.pool.Put(l)
+	s.prog.Store(p)
+
+	return nil
+}
+
+// Func returns the transform function running the script with the context of TransformCtx,
+// register it with transform.WithTransformation or transform.Register
+func (s *Script) Func() transform.Func {
+	return s.FuncCtx().Func()
+}
+
+// FuncCtx returns the transform function running the script with the context of the transformation,
+// register it with transform.WithTransformationCtx
+func (s *Script) FuncCtx() transform.FuncCtx {
+	return func(ctx context.Context, fl transform.FieldLevel) error {
+		v, err := s.TransformCtx(ctx, fl.String(), fl.FieldName())
+		if err != nil {
+			return err
+		}
+
+		transform.SetString(fl, v)
+
+		return nil
+	}
+}
+
+// Transform runs the transform function of the script
+func (s *Script) Transform(value, field string) (string, error) {
+	return s.TransformCtx(context.Background(), value, field)
+}
+
+// TransformCtx runs the transform function of the script until it returns or the context is done
+func (s *Script) TransformCtx(ctx context.Context, value, field string) (string, error) {
+	p := s.prog.Load()
+
+	l, ok := p.pool.Get().(*lua.LState)
+	if !ok {
+		var err error
+		if l, err = p.newState(); err != nil {
+			return "", err
+		}
+	}
+
+	// the context is checked before every instruction, a context that is never done is not set
+	if ctx.Done() != nil {
+		l.SetContext(ctx)
+	}
+
+	err := l.CallByParam(lua.P{
+		Fn:      l.GetGlobal(entrypoint),
+		NRet:    1,
+		Protect: true,
+	}, lua.LString(value), lua.LString(field))
+
+	if ctx.Done() != nil {
+		l.RemoveContext()
+	}
+
+	if err != nil {
+		// the state of a failed script (e.g. its globals and stack) is not reused
+		l.Close()
+
+		if cerr := ctx.Err(); cerr != nil {
+			return "", fmt.Errorf("transformlua: %w", cerr)
+		}
+
+		return "", fmt.Errorf("transformlua: %w", err)
+	}
+
+	defer p.pool.Put(l)
+
+	ret := l.Get(-1)
+	l.Pop(1)
+
+	switch v := ret.(type) {
+	case lua.LString:
+		return string(v), nil
+	case *lua.LNilType:
+		return value, nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrNoString, ret.Type())
+	}
+}
+
+// newState returns a Lua state with the restricted libraries that ran the script
+func (p *program) newState() (*lua.LState, error) {
+	l := lua.NewState(lua.Options{SkipOpenLibs: true})
+
+	for _, lib := range []struct {
+		name string
+		fn   lua.LGFunction
+	}{
+		{lua.BaseLibName, lua.OpenBase},
+		{lua.StringLibName, lua.OpenString},
+		{lua.TabLibName, lua.OpenTable},
+		{lua.MathLibName, lua.OpenMath},
+	} {
+		l.Push(l.NewFunction(lib.fn))
+		l.Push(lua.LString(lib.name))
+		l.Call(1, 0)
+	}
+
+	for _, name := range unsafeGlobals {
+		l.SetGlobal(name, lua.LNil)
+	}
+
+	if str, ok := l.GetGlobal(lua.StringLibName).(*lua.LTable); ok {
+		for _, name := range unsafeStringFuncs {
+			str.RawSetString(name, lua.LNil)
+		}
+	}
+
+	l.Push(l.NewFunctionFromProto(p.proto))
+
+	if err := l.PCall(0, 0, nil); err != nil {
+		l.Close()
+		return nil, fmt.Errorf("transformlua: %w", err)
+	}
+
+	if l.GetGlobal(entrypoint).Type() != lua.LTFunction {
+		l.Close()
+		return nil, ErrNoEntrypoint
+	}
+
+	return l, nil
+}
@@ -0,0 +1,140 @@
+package transformlua_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/zeiss/go-transform"
+	"github.com/zeiss/go-transform/transformlua"
+
+	"github.com/stretchr/testify/require"
+)
+
+const script = `
+function transform(value, field)
+  if field == "Keep" then
+    return nil
+  end
+  if value == "" then
+    return "N/A"
+  end
+  return string.upper(value)
+end
+`
+
+func TestScript(t *testing.T) {
+	s, err := transformlua.New(script)
+	require.NoError(t, err)
+
+	trans := transform.New(transform.WithTransformation("lua", s.Func()))
+
+	type testStruct struct {
+		Name  string `transform:"trim,lua"`
+		Empty string `transform:"lua"`
+		Keep  string `transform:"lua"`
+	}
+
+	in := &testStruct{Name: " jena ", Keep: "keep"}
+
+	err = trans.Transform(in)
+	require.NoError(t, err)
+	require.Equal(t, &testStruct{Name: "JENA", Empty: "N/A", Keep: "keep"}, in)
+
+	err = s.Reload(`function transform(value) return string.lower(value) end`)
+	require.NoError(t, err)
+
+	err = trans.Transform(in)
+	require.NoError(t, err)
+	require.Equal(t, &testStruct{Name: "jena", Empty: "n/a", Keep: "keep"}, in)
+}
+
+func TestScriptErrors(t *testing.T) {
+	_, err := transformlua.New(`function transform(`)
+	require.Error(t, err)
+
+	_, err = transformlua.New(`x = 1`)
+	require.ErrorIs(t, err, transformlua.ErrNoEntrypoint)
+
+	s, err := transformlua.New(`function transform(value) return 42 end`)
+	require.NoError(t, err)
+
+	_, err = s.Transform("x", "X")
+	require.ErrorIs(t, err, transformlua.ErrNoString)
+
+	s, err = transformlua.New(`function transform(value) return dofile("/etc/passwd") end`)
+	require.NoError(t, err)
+
+	_, err = s.Transform("x", "X")
+	require.Error(t, err)
+
+	// a failed reload keeps the previous script
+	err = s.Reload(`function transform(`)
+	require.Error(t, err)
+}
+
+func TestScriptTimeout(t *testing.T) {
+	s, err := transformlua.New(`
+function transform(value)
+  while value == "loop" do end
+  return string.upper(value)
+end
+`)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err = s.TransformCtx(ctx, "loop", "X")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	type testStruct struct {
+		Name string `transform:"lua"`
+	}
+
+	trans := transform.New(transform.WithTransformation("lua", s.Func()))
+
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err = trans.TransformCtx(ctx, &testStruct{Name: "loop"})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// the script still runs after a timeout
+	v, err := s.TransformCtx(context.Background(), "x", "X")
+	require.NoError(t, err)
+	require.Equal(t, "X", v)
+}
+
+func TestScriptFailedState(t *testing.T) {
+	s, err := transformlua.New(`
+function transform(value)
+  if value == "fail" then
+    prefix = "broken"
+    error("failed")
+  end
+  return (prefix or "") .. value
+end
+`)
+	require.NoError(t, err)
+
+	_, err = s.Transform("fail", "X")
+	require.Error(t, err)
+
+	// the next call does not see the globals of the failed call
+	v, err := s.Transform("x", "X")
+	require.NoError(t, err)
+	require.Equal(t, "x", v)
+
+	for _, src := range []string{
+		`function transform(value) return string.rep(value, 1000000000) end`,
+		`function transform(value) return setmetatable({}, {}) end`,
+		`function transform(value) return getmetatable("") end`,
+	} {
+		s, err := transformlua.New(src)
+		require.NoError(t, err)
+
+		_, err = s.Transform("x", "X")
+		require.Error(t, err, src)
+	}
+}
@@ -0,0 +1,125 @@
+// Package transformmultipart binds multipart forms to structs, transforms them
+// and sanitizes the metadata of the uploaded files.
+package transformmultipart
+
+import (
+	"mime/multipart"
+	"net/http"
+	"reflect"
+
+	"github.com/zeiss/go-transform"
+	"github.com/zeiss/go-transform/internal/binding"
+)
+
+// DefaultMaxMemory is the maximum memory used to parse the form, the rest is stored on disk
+const DefaultMaxMemory = 32 << 20
+
+// tags are the tags used to look up the form key of a field
+var tags = []string{"form", "json"}
+
+var (
+	fileHeaderType      = reflect.TypeOf((*multipart.FileHeader)(nil))
+	fileHeaderSliceType = reflect.TypeOf([]*multipart.FileHeader(nil))
+)
+
+// fileMeta is the metadata of a file part
+type fileMeta struct {
+	Filename    string `transform:"safefilename"`
+	ContentType string `transform:"mimetype"`
+}
+
+// Bind parses the multipart form of the request and decodes the values and files into
+// the struct v points to. The key of a field is taken from the `form` tag, the `json` tag
+// or the field name. Files are bound to fields of type *multipart.FileHeader or
+// []*multipart.FileHeader and their file name and content type are sanitized.
+// The struct is transformed afterwards, by the same transformer configured by the options.
+func Bind(r *http.Request, v interface{}, opts ...transform.TransformerOpt) error {
+	if r.MultipartForm == nil {
+		if err := r.ParseMultipartForm(DefaultMaxMemory); err != nil {
+			return err
+		}
+	}
+
+	if err := binding.Decode(r.MultipartForm.Value, v, tags...); err != nil {
+		return err
+	}
+
+	trans := transform.New(opts...)
+
+	if err := bindFiles(trans, r.MultipartForm.File, reflect.ValueOf(v).Elem()); err != nil {
+		return err
+	}
+
+	return trans.Transform(v)
+}
+
+// SanitizeFile sanitizes the file name and content type of a file part with the functions
+// safefilename and mimetype of a transformer configured by the options
+func SanitizeFile(fh *multipart.FileHeader, opts ...transform.TransformerOpt) error {
+	return sanitizeFile(transform.New(opts...), fh)
+}
+
+// sanitizeFile sanitizes the file name and content type of a file part with the transformer
+func sanitizeFile(trans *transform.TransformerImpl, fh *multipart.FileHeader) error {
+	if fh == nil {
+		return nil
+	}
+
+	meta := &fileMeta{
+		Filename:    fh.Filename,
+		ContentType: fh.Header.Get("Content-Type"),
+	}
+
+	if err := trans.Transform(meta); err != nil {
+		return err
+	}
+
+	fh.Filename = meta.Filename
+
+	if fh.Header != nil && meta.ContentType != "" {
+		fh.Header.Set("Content-Type", meta.ContentType)
+	}
+
+	return nil
+}
+
+// bindFiles sets the file fields of the struct to the sanitized file parts of their keys
+func bindFiles(trans *transform.TransformerImpl, files map[string][]*multipart.FileHeader, rv reflect.Value) error {
+	rt := rv.Type()
+
+	for i := 0; i < rt.NumField(); i++ {
+		ft := rt.Field(i)
+		fv := rv.Field(i)
+
+		if ft.Anonymous && ft.Type.Kind() == reflect.Struct {
+			if err := bindFiles(trans, files, fv); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if !fv.CanSet() || (ft.Type != fileHeaderType && ft.Type != fileHeaderSliceType) {
+			continue
+		}
+
+		fhs := files[binding.FieldKey(ft, tags...)]
+		if len(fhs) == 0 {
+			continue
+		}
+
+		for _, fh := range fhs {
+			if err := sanitizeFile(trans, fh); err != nil {
+				return err
+			}
+		}
+
+		if ft.Type == fileHeaderType {
+			fv.Set(reflect.ValueOf(fhs[0]))
+		} else {
+			fv.Set(reflect.ValueOf(fhs))
+		}
+	}
+
+	return nil
+}
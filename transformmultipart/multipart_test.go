@@ -0,0 +1,108 @@
+package transformmultipart_test
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	"github.com/zeiss/go-transform"
+	"github.com/zeiss/go-transform/transformmultipart"
+
+	"github.com/stretchr/testify/require"
+)
+
+type upload struct {
+	Title       string                  `form:"title" transform:"trim"`
+	File        *multipart.FileHeader   `form:"file"`
+	Attachments []*multipart.FileHeader `form:"attachments"`
+}
+
+func newRequest(t *testing.T) *http.Request {
+	t.Helper()
+
+	body := &bytes.Buffer{}
+	w := multipart.NewWriter(body)
+
+	require.NoError(t, w.WriteField("title", "  My Upload  "))
+
+	files := []struct {
+		field       string
+		filename    string
+		contentType string
+	}{
+		{"file", "../../etc/passwd", "Text/Plain; Charset=UTF-8"},
+		{"attachments", `C:\tmp\.report.pdf`, "application/pdf"},
+		{"attachments", "image.png", "invalid"},
+	}
+
+	for _, f := range files {
+		h := make(textproto.MIMEHeader)
+		h.Set("Content-Disposition", `form-data; name="`+f.field+`"; filename="`+f.filename+`"`)
+		h.Set("Content-Type", f.contentType)
+
+		part, err := w.CreatePart(h)
+		require.NoError(t, err)
+
+		_, err = part.Write([]byte("content"))
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, w.Close())
+
+	r := httptest.NewRequest(http.MethodPost, "/upload", body)
+	r.Header.Set("Content-Type", w.FormDataContentType())
+
+	return r
+}
+
+func TestBind(t *testing.T) {
+	out := &upload{}
+
+	err := transformmultipart.Bind(newRequest(t), out)
+	require.NoError(t, err)
+
+	require.Equal(t, "My Upload", out.Title)
+	require.NotNil(t, out.File)
+	require.Equal(t, "passwd", out.File.Filename)
+	require.Equal(t, "text/plain; charset=UTF-8", out.File.Header.Get("Content-Type"))
+	require.Len(t, out.Attachments, 2)
+	require.Equal(t, "report.pdf", out.Attachments[0].Filename)
+	require.Equal(t, "application/pdf", out.Attachments[0].Header.Get("Content-Type"))
+	require.Equal(t, "image.png", out.Attachments[1].Filename)
+	require.Equal(t, "application/octet-stream", out.Attachments[1].Header.Get("Content-Type"))
+}
+
+func TestBindOptions(t *testing.T) {
+	upper := transform.WithTransformation("safefilename", func(fl transform.FieldLevel) error {
+		transform.SetString(fl, strings.ToUpper(fl.String()))
+		return nil
+	})
+
+	out := &upload{}
+
+	err := transformmultipart.Bind(newRequest(t), out, upper)
+	require.NoError(t, err)
+	require.Equal(t, "PASSWD", out.File.Filename)
+
+	fh := &multipart.FileHeader{Filename: "a/b.txt", Header: textproto.MIMEHeader{}}
+
+	err = transformmultipart.SanitizeFile(fh, upper)
+	require.NoError(t, err)
+	require.Equal(t, "A/B.TXT", fh.Filename)
+
+	err = transformmultipart.SanitizeFile(fh)
+	require.NoError(t, err)
+	require.Equal(t, "B.TXT", fh.Filename)
+}
+
+func TestBindNoMultipart(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/upload", bytes.NewBufferString("title=test"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	err := transformmultipart.Bind(r, &upload{})
+	require.Error(t, err)
+}
@@ -0,0 +1,86 @@
+// Package transformpii registers transform functions masking personal data in the default registry.
+// Import it for its side effects:
+//
+//	import _ "github.com/zeiss/go-transform/transformpii"
+//
+// The functions are
+//
+//	maskemail  keeps the first character of the local part and the domain (j***@example.com)
+//	maskphone  keeps the last 4 digits of a phone number (********4567)
+//	maskcard   keeps the last 4 digits of a card number (************1111)
+//	redact     replaces a non-empty value with [REDACTED]
+package transformpii
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/zeiss/go-transform"
+)
+
+const (
+	// visibleDigits is the number of trailing digits kept by maskphone and maskcard
+	visibleDigits = 4
+	// redacted replaces the values of redact
+	redacted = "[REDACTED]"
+)
+
+func init() {
+	transform.Register("maskemail", maskEmailFunc)
+	transform.Register("maskphone", maskDigitsFunc)
+	transform.Register("maskcard", maskDigitsFunc)
+	transform.Register("redact", redactFunc)
+}
+
+func maskEmailFunc(fl transform.FieldLevel) error {
+	transform.SetString(fl, MaskEmail(fl.String()))
+
+	return nil
+}
+
+func maskDigitsFunc(fl transform.FieldLevel) error {
+	transform.SetString(fl, MaskDigits(fl.String(), visibleDigits))
+
+	return nil
+}
+
+func redactFunc(fl transform.FieldLevel) error {
+	if fl.String() != "" {
+		transform.SetString(fl, redacted)
+	}
+
+	return nil
+}
+
+// MaskEmail keeps the first character of the local part and the domain of an email address,
+// values without a domain are masked completely
+func MaskEmail(s string) string {
+	s = strings.TrimSpace(s)
+
+	local, domain, ok := strings.Cut(s, "@")
+	if !ok || local == "" {
+		return strings.Repeat("*", len([]rune(s)))
+	}
+
+	r := []rune(local)
+
+	return string(r[0]) + "***@" + domain
+}
+
+// MaskDigits keeps the last n digits and replaces all other digits with *,
+// separators like spaces and dashes are removed
+func MaskDigits(s string, n int) string {
+	digits := make([]rune, 0, len(s))
+
+	for _, r := range s {
+		if unicode.IsDigit(r) {
+			digits = append(digits, r)
+		}
+	}
+
+	for i := 0; i < len(digits)-n; i++ {
+		digits[i] = '*'
+	}
+
+	return string(digits)
+}
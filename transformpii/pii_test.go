@@ -0,0 +1,39 @@
+package transformpii_test
+
+import (
+	"testing"
+
+	"github.com/zeiss/go-transform"
+	_ "github.com/zeiss/go-transform/transformpii"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMask(t *testing.T) {
+	type testStruct struct {
+		Email   string `transform:"maskemail"`
+		Invalid string `transform:"maskemail"`
+		Phone   string `transform:"maskphone"`
+		Card    string `transform:"maskcard"`
+		Secret  string `transform:"redact"`
+		Empty   string `transform:"redact"`
+	}
+
+	in := &testStruct{
+		Email:   " john.doe@example.com ",
+		Invalid: "john",
+		Phone:   "+49 (0) 3641 1234567",
+		Card:    "4111-1111-1111-1111",
+		Secret:  "hunter2",
+	}
+
+	err := transform.Transform(in)
+	require.NoError(t, err)
+	require.Equal(t, &testStruct{
+		Email:   "j***@example.com",
+		Invalid: "****",
+		Phone:   "**********4567",
+		Card:    "************1111",
+		Secret:  "[REDACTED]",
+	}, in)
+}
@@ -0,0 +1,52 @@
+// Package transformplugin loads transform functions from Go plugins, so closed-source
+// deployments can add proprietary functions without forking the application.
+// It is a separate package, because importing the plugin package links the binary dynamically.
+package transformplugin
+
+import (
+	"fmt"
+	"plugin"
+
+	"github.com/zeiss/go-transform"
+)
+
+// Symbol is the symbol a Go plugin exports its transform functions with, either as
+//
+//	var Transformations = map[string]transform.Func{...}
+//
+// or as
+//
+//	func Transformations() map[string]transform.Func
+const Symbol = "Transformations"
+
+// Load opens the Go plugin (built with -buildmode=plugin) and adds its transform
+// functions to the default registry. Go plugins are only supported on some
+// platforms and must be built with the same version of this module.
+func Load(path string) error {
+	p, err := plugin.Open(path)
+	if err != nil {
+		return err
+	}
+
+	sym, err := p.Lookup(Symbol)
+	if err != nil {
+		return err
+	}
+
+	return Register(sym)
+}
+
+// Register adds the functions of a table symbol to the default registry,
+// it is called by Load with the symbol of the plugin
+func Register(sym interface{}) error {
+	switch table := sym.(type) {
+	case map[string]transform.Func:
+		return transform.RegisterAll(table)
+	case *map[string]transform.Func:
+		return transform.RegisterAll(*table)
+	case func() map[string]transform.Func:
+		return transform.RegisterAll(table())
+	default:
+		return fmt.Errorf("%w: %s has type %T", transform.ErrInvalidFunc, Symbol, sym)
+	}
+}
@@ -0,0 +1,43 @@
+package transformplugin_test
+
+import (
+	"testing"
+
+	"github.com/zeiss/go-transform"
+	"github.com/zeiss/go-transform/transformplugin"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegister(t *testing.T) {
+	table := map[string]transform.Func{
+		"plugin_upper": func(fl transform.FieldLevel) error {
+			transform.SetString(fl, "UPPER")
+			return nil
+		},
+	}
+
+	err := transformplugin.Register(func() map[string]transform.Func { return table })
+	require.NoError(t, err)
+
+	type testStruct struct {
+		Name string `transform:"plugin_upper"`
+	}
+
+	in := &testStruct{Name: "lower"}
+
+	err = transform.Transform(in)
+	require.NoError(t, err)
+	require.Equal(t, "UPPER", in.Name)
+
+	err = transformplugin.Register(&table)
+	require.ErrorIs(t, err, transform.ErrDuplicateFunc)
+
+	err = transformplugin.Register("invalid")
+	require.ErrorIs(t, err, transform.ErrInvalidFunc)
+}
+
+func TestLoad(t *testing.T) {
+	err := transformplugin.Load("testdata/missing.so")
+	require.Error(t, err)
+}
@@ -0,0 +1,121 @@
+// Package transformproblem encodes the errors of a transformation
+// as problem details (RFC 9457), so APIs can report all failed fields in a standard format.
+package transformproblem
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/zeiss/go-transform"
+)
+
+// ContentType is the media type of problem details
+const ContentType = "application/problem+json"
+
+// Problem is the problem details of a failed transformation
+type Problem struct {
+	// Type is a URI reference identifying the problem type
+	Type string `json:"type"`
+	// Title is a short summary of the problem type
+	Title string `json:"title"`
+	// Status is the HTTP status code
+	Status int `json:"status"`
+	// Detail is an explanation of the occurrence of the problem
+	Detail string `json:"detail,omitempty"`
+	// Instance is a URI reference identifying the occurrence of the problem
+	Instance string `json:"instance,omitempty"`
+	// Errors are the failed fields
+	Errors []FieldProblem `json:"errors,omitempty"`
+}
+
+// FieldProblem is the failure of a single field
+type FieldProblem struct {
+	// Pointer is the JSON pointer of the field as URI fragment (e.g. #/address/city)
+	Pointer string `json:"pointer"`
+	// Path is the Go path of the field (e.g. Address.City)
+	Path string `json:"path"`
+	// Func is the name of the failed function, it is empty for an interface handler
+	Func string `json:"func,omitempty"`
+	// Detail is the error of the function
+	Detail string `json:"detail"`
+}
+
+// New returns the problem details of the error returned by a transformation, it is nil if the error is nil.
+// The failed fields are reported with status 422, any other error is reported as internal server error
+// without details, as it is a problem of the server (e.g. an unknown function).
+func New(err error) *Problem {
+	if err == nil {
+		return nil
+	}
+
+	var fields []FieldProblem
+	if !collect(err, &fields) {
+		return &Problem{
+			Type:   "about:blank",
+			Title:  http.StatusText(http.StatusInternalServerError),
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	return &Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(http.StatusUnprocessableEntity),
+		Status: http.StatusUnprocessableEntity,
+		Detail: "the request contains invalid fields",
+		Errors: fields,
+	}
+}
+
+// Write writes the problem details of the error to the response
+func Write(w http.ResponseWriter, err error) error {
+	p := New(err)
+	if p == nil {
+		return nil
+	}
+
+	b, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(p.Status)
+
+	_, err = w.Write(b)
+
+	return err
+}
+
+// collect appends the failed fields of the error,
+// it reports false if the error contains an error not caused by a field
+func collect(err error, fields *[]FieldProblem) bool {
+	var ferr *transform.FieldError
+
+	switch e := err.(type) { // nolint:errorlint
+	case *transform.FieldError:
+		ferr = e
+	case interface{ Unwrap() []error }:
+		ok := true
+		for _, err := range e.Unwrap() {
+			ok = collect(err, fields) && ok
+		}
+
+		return ok
+	default:
+		if next := errors.Unwrap(err); next != nil {
+			return collect(next, fields)
+		}
+
+		return false
+	}
+
+	*fields = append(*fields, FieldProblem{
+		Pointer: "#" + ferr.Pointer,
+		Path:    ferr.Path,
+		Func:    ferr.Func,
+		Detail:  ferr.Err.Error(),
+	})
+
+	return true
+}
@@ -0,0 +1,133 @@
+package transformproblem_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/zeiss/go-transform"
+	"github.com/zeiss/go-transform/transformproblem"
+
+	"github.com/stretchr/testify/require"
+)
+
+type address struct {
+	City string `json:"city" transform:"fail"`
+}
+
+type request struct {
+	Name      string    `json:"name" transform:"trim,fail"`
+	Addresses []address `json:"addresses"`
+}
+
+func newTransformer(opts ...transform.TransformerOpt) *transform.TransformerImpl {
+	opts = append(opts, transform.WithTransformation("fail", func(fl transform.FieldLevel) error {
+		if fl.String() == "" {
+			return nil
+		}
+
+		return errors.New("invalid value")
+	}))
+
+	return transform.New(opts...)
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name string
+		err  func() error
+		out  *transformproblem.Problem
+	}{
+		{
+			name: "nil",
+			err:  func() error { return nil },
+		},
+		{
+			name: "field",
+			err: func() error {
+				return newTransformer().Transform(&request{Name: "x"})
+			},
+			out: &transformproblem.Problem{
+				Type:   "about:blank",
+				Title:  "Unprocessable Entity",
+				Status: http.StatusUnprocessableEntity,
+				Detail: "the request contains invalid fields",
+				Errors: []transformproblem.FieldProblem{
+					{Pointer: "#/name", Path: "Name", Func: "fail", Detail: "invalid value"},
+				},
+			},
+		},
+		{
+			name: "aggregated",
+			err: func() error {
+				r := newTransformer(transform.WithIsolatedElements()).TransformWithReport(&request{
+					Addresses: []address{{City: "a"}, {}, {City: "b"}},
+				})
+
+				return r.Err()
+			},
+			out: &transformproblem.Problem{
+				Type:   "about:blank",
+				Title:  "Unprocessable Entity",
+				Status: http.StatusUnprocessableEntity,
+				Detail: "the request contains invalid fields",
+				Errors: []transformproblem.FieldProblem{
+					{Pointer: "#/addresses/0/city", Path: "Addresses[0].City", Func: "fail", Detail: "invalid value"},
+					{Pointer: "#/addresses/2/city", Path: "Addresses[2].City", Func: "fail", Detail: "invalid value"},
+				},
+			},
+		},
+		{
+			name: "collected",
+			err: func() error {
+				return newTransformer(transform.WithCollectErrors()).Transform(&request{
+					Name:      "x",
+					Addresses: []address{{City: "a"}},
+				})
+			},
+			out: &transformproblem.Problem{
+				Type:   "about:blank",
+				Title:  "Unprocessable Entity",
+				Status: http.StatusUnprocessableEntity,
+				Detail: "the request contains invalid fields",
+				Errors: []transformproblem.FieldProblem{
+					{Pointer: "#/name", Path: "Name", Func: "fail", Detail: "invalid value"},
+					{Pointer: "#/addresses/0/city", Path: "Addresses[0].City", Func: "fail", Detail: "invalid value"},
+				},
+			},
+		},
+		{
+			name: "unknown function",
+			err: func() error {
+				return transform.New(transform.WithErrorOnUnknownFunc()).Transform(&request{Name: "x"})
+			},
+			out: &transformproblem.Problem{
+				Type:   "about:blank",
+				Title:  "Internal Server Error",
+				Status: http.StatusInternalServerError,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.out, transformproblem.New(tc.err()))
+		})
+	}
+}
+
+func TestWrite(t *testing.T) {
+	rec := httptest.NewRecorder()
+
+	err := transformproblem.Write(rec, newTransformer().Transform(&request{Name: "x"}))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	require.Equal(t, transformproblem.ContentType, rec.Header().Get("Content-Type"))
+
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.Equal(t, "about:blank", body["type"])
+	require.Len(t, body["errors"], 1)
+}
@@ -0,0 +1,20 @@
+// Package transformquery binds query strings to structs and transforms them,
+// so GET endpoints get the same sanitization as body bound POST endpoints.
+package transformquery
+
+import (
+	"net/url"
+
+	"github.com/zeiss/go-transform"
+	"github.com/zeiss/go-transform/internal/binding"
+)
+
+// Decode decodes the query values into the struct v points to and transforms it.
+// The key of a field is taken from the `query` tag, the `json` tag or the field name.
+func Decode(q url.Values, v interface{}, opts ...transform.TransformerOpt) error {
+	if err := binding.Decode(q, v, "query", "json"); err != nil {
+		return err
+	}
+
+	return transform.New(opts...).Transform(v)
+}
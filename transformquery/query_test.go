@@ -0,0 +1,77 @@
+package transformquery_test
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/zeiss/go-transform/transformquery"
+
+	"github.com/stretchr/testify/require"
+)
+
+type pagination struct {
+	Limit int `query:"limit"`
+}
+
+type search struct {
+	pagination
+	Query  string   `query:"q" transform:"trim,lowercase"`
+	Sort   *string  `json:"sort" transform:"trim"`
+	Tags   []string `query:"tag"`
+	Active bool     `query:"active"`
+	Ignore string   `query:"-"`
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name string
+		in   url.Values
+		out  *search
+		err  bool
+	}{
+		{
+			name: "empty",
+			in:   url.Values{},
+			out:  &search{},
+		},
+		{
+			name: "values",
+			in: url.Values{
+				"q":      {"  Hello World  "},
+				"sort":   {" name "},
+				"tag":    {"a", "b"},
+				"active": {"true"},
+				"limit":  {"10"},
+				"Ignore": {"x"},
+			},
+			out: &search{
+				pagination: pagination{Limit: 10},
+				Query:      "hello world",
+				Sort:       &[]string{"name"}[0],
+				Tags:       []string{"a", "b"},
+				Active:     true,
+			},
+		},
+		{
+			name: "invalid",
+			in:   url.Values{"limit": {"ten"}},
+			out:  &search{},
+			err:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &search{}
+			err := transformquery.Decode(tt.in, out)
+
+			if tt.err {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.out, out)
+		})
+	}
+}
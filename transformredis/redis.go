@@ -0,0 +1,76 @@
+// Package transformredis transforms values before they are cached in Redis,
+// so caches never hold unsanitized values. It has no dependency on a Redis client,
+// the values implement encoding.BinaryMarshaler as expected by the commands of go-redis:
+//
+//	t := transform.New()
+//
+//	err := rdb.Set(ctx, key, transformredis.Value(t, &user), time.Hour).Err()
+//
+// The marshal function of go-redis/cache is wrapped with Marshal:
+//
+//	c := cache.New(&cache.Options{Redis: rdb, Marshal: transformredis.Marshal(t, msgpack.Marshal)})
+package transformredis
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+
+	"github.com/zeiss/go-transform"
+)
+
+// MarshalFunc encodes a value for the cache (e.g. json.Marshal)
+type MarshalFunc func(v interface{}) ([]byte, error)
+
+// Marshal returns a marshal function that encodes a transformed copy of structs and pointers to structs,
+// other values are encoded as is. The cached values are never modified.
+func Marshal(t *transform.TransformerImpl, marshal MarshalFunc) MarshalFunc {
+	return func(v interface{}) ([]byte, error) {
+		cp, err := sanitize(t, v)
+		if err != nil {
+			return nil, err
+		}
+
+		return marshal(cp)
+	}
+}
+
+// Value returns the value for a Redis command, it is encoded as JSON after the transformation
+func Value(t *transform.TransformerImpl, v interface{}) encoding.BinaryMarshaler {
+	return &value{marshal: Marshal(t, json.Marshal), v: v}
+}
+
+// value is a value encoded on demand by a command
+type value struct {
+	marshal MarshalFunc
+	v       interface{}
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (v *value) MarshalBinary() ([]byte, error) {
+	return v.marshal(v.v)
+}
+
+// sanitize returns a transformed copy of a struct or a pointer to a struct
+func sanitize(t *transform.TransformerImpl, v interface{}) (interface{}, error) {
+	rv := reflect.ValueOf(v)
+
+	switch {
+	case rv.Kind() == reflect.Ptr && !rv.IsNil() && rv.Elem().Kind() == reflect.Struct:
+		cp, _, err := t.TransformCOW(v)
+
+		return cp, err
+	case rv.Kind() == reflect.Struct:
+		p := reflect.New(rv.Type())
+		p.Elem().Set(rv)
+
+		cp, _, err := t.TransformCOW(p.Interface())
+		if err != nil {
+			return nil, err
+		}
+
+		return reflect.ValueOf(cp).Elem().Interface(), nil
+	default:
+		return v, nil
+	}
+}
@@ -0,0 +1,88 @@
+package transformredis_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/zeiss/go-transform"
+	"github.com/zeiss/go-transform/transformredis"
+
+	"github.com/stretchr/testify/require"
+)
+
+type session struct {
+	Email string `json:"email" transform:"trim,lowercase"`
+	Token string `json:"token" transform:"fail"`
+}
+
+var errFail = errors.New("failed")
+
+func newTransformer() *transform.TransformerImpl {
+	return transform.New(transform.WithTransformation("fail", func(fl transform.FieldLevel) error {
+		if fl.String() == "fail" {
+			return errFail
+		}
+
+		return nil
+	}))
+}
+
+func TestMarshal(t *testing.T) {
+	marshal := transformredis.Marshal(newTransformer(), json.Marshal)
+
+	tests := []struct {
+		name string
+		in   interface{}
+		out  string
+		err  error
+	}{
+		{
+			name: "pointer",
+			in:   &session{Email: " Jane@Example.com "},
+			out:  `{"email":"jane@example.com","token":""}`,
+		},
+		{
+			name: "struct",
+			in:   session{Email: " Jane@Example.com "},
+			out:  `{"email":"jane@example.com","token":""}`,
+		},
+		{
+			name: "nil pointer",
+			in:   (*session)(nil),
+			out:  `null`,
+		},
+		{
+			name: "string",
+			in:   " x ",
+			out:  `" x "`,
+		},
+		{
+			name: "error",
+			in:   &session{Token: "fail"},
+			err:  errFail,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			b, err := marshal(tc.in)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.JSONEq(t, tc.out, string(b))
+		})
+	}
+}
+
+func TestValue(t *testing.T) {
+	s := &session{Email: " Jane@Example.com "}
+
+	b, err := transformredis.Value(newTransformer(), s).MarshalBinary()
+	require.NoError(t, err)
+	require.JSONEq(t, `{"email":"jane@example.com","token":""}`, string(b))
+	require.Equal(t, " Jane@Example.com ", s.Email)
+}
@@ -0,0 +1,133 @@
+// Package transformwasm runs transform functions implemented as WASM modules,
+// so tenants of a platform can provide sandboxed normalization logic that can
+// be replaced at runtime. The package is experimental.
+//
+// The package does not depend on a WASM runtime, an instantiated module is
+// accessed through the Instance interface. The api.Module of wazero satisfies
+// it with a small adapter:
+//
+//	type instance struct{ api.Module }
+//
+//	func (i instance) Call(ctx context.Context, name string, params ...uint64) ([]uint64, error) {
+//		fn := i.ExportedFunction(name)
+//		if fn == nil {
+//			return nil, fmt.Errorf("missing export %s", name)
+//		}
+//		return fn.Call(ctx, params...)
+//	}
+//
+//	func (i instance) Read(offset, size uint32) ([]byte, bool) { return i.Memory().Read(offset, size) }
+//	func (i instance) Write(offset uint32, b []byte) bool     { return i.Memory().Write(offset, b) }
+//
+// A module exports its linear memory and the functions
+//
+//	alloc(size i32) i32                 returns a buffer of size bytes for the input
+//	transform(ptr i32, size i32) i64    returns the output as ptr<<32 | size
+//
+// The module owns all buffers, the output must stay valid until the next call of alloc.
+package transformwasm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/zeiss/go-transform"
+)
+
+// ErrMemory is returned if a module accesses memory out of its bounds
+var ErrMemory = errors.New("transformwasm: memory access out of bounds")
+
+// Instance is an instantiated WASM module
+type Instance interface {
+	// Call calls the exported function with the parameters and returns its results
+	Call(ctx context.Context, name string, params ...uint64) ([]uint64, error)
+	// Read returns size bytes of the memory at the offset
+	Read(offset, size uint32) ([]byte, bool)
+	// Write writes the bytes to the memory at the offset
+	Write(offset uint32, b []byte) bool
+}
+
+// Module is a transform function implemented by a WASM module.
+// It is safe for concurrent use, the calls of the instance are serialized.
+type Module struct {
+	mu   sync.Mutex
+	inst Instance
+}
+
+// New returns the module running the instance
+func New(inst Instance) *Module {
+	return &Module{inst: inst}
+}
+
+// Swap replaces the instance of the module and returns the previous one,
+// running calls complete with the previous instance
+func (m *Module) Swap(inst Instance) Instance {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	old := m.inst
+	m.inst = inst
+
+	return old
+}
+
+// Func returns the transform function running the module with the context of TransformCtx,
+// register it with transform.WithTransformation or transform.Register
+func (m *Module) Func() transform.Func {
+	return m.FuncCtx().Func()
+}
+
+// FuncCtx returns the transform function running the module with the context of the transformation,
+// register it with transform.WithTransformationCtx
+func (m *Module) FuncCtx() transform.FuncCtx {
+	return func(ctx context.Context, fl transform.FieldLevel) error {
+		s, err := m.Transform(ctx, fl.String())
+		if err != nil {
+			return err
+		}
+
+		transform.SetString(fl, s)
+
+		return nil
+	}
+}
+
+// Transform runs the module with the input and returns the output
+func (m *Module) Transform(ctx context.Context, s string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	size := uint64(len(s))
+
+	res, err := m.inst.Call(ctx, "alloc", size)
+	if err != nil {
+		return "", fmt.Errorf("transformwasm: alloc: %w", err)
+	}
+
+	if len(res) != 1 {
+		return "", fmt.Errorf("transformwasm: alloc returned %d results", len(res))
+	}
+
+	ptr := uint32(res[0])
+	if !m.inst.Write(ptr, []byte(s)) {
+		return "", ErrMemory
+	}
+
+	res, err = m.inst.Call(ctx, "transform", uint64(ptr), size)
+	if err != nil {
+		return "", fmt.Errorf("transformwasm: transform: %w", err)
+	}
+
+	if len(res) != 1 {
+		return "", fmt.Errorf("transformwasm: transform returned %d results", len(res))
+	}
+
+	b, ok := m.inst.Read(uint32(res[0]>>32), uint32(res[0]))
+	if !ok {
+		return "", ErrMemory
+	}
+
+	return string(b), nil
+}
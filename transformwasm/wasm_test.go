@@ -0,0 +1,109 @@
+package transformwasm_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/zeiss/go-transform"
+	"github.com/zeiss/go-transform/transformwasm"
+
+	"github.com/stretchr/testify/require"
+)
+
+// instance emulates a module with a linear memory and the exports of the ABI
+type instance struct {
+	mem []byte
+	fn  func(string) string
+	ctx context.Context
+}
+
+func newInstance(fn func(string) string) *instance {
+	return &instance{mem: make([]byte, 1024), fn: fn}
+}
+
+func (i *instance) Call(ctx context.Context, name string, params ...uint64) ([]uint64, error) {
+	i.ctx = ctx
+
+	switch name {
+	case "alloc":
+		return []uint64{16}, nil
+	case "transform":
+		in := string(i.mem[params[0] : params[0]+params[1]])
+		out := i.fn(in)
+
+		copy(i.mem[512:], out)
+
+		return []uint64{512<<32 | uint64(len(out))}, nil
+	default:
+		return nil, errors.New("missing export")
+	}
+}
+
+func (i *instance) Read(offset, size uint32) ([]byte, bool) {
+	if int(offset)+int(size) > len(i.mem) {
+		return nil, false
+	}
+
+	return i.mem[offset : offset+size], true
+}
+
+func (i *instance) Write(offset uint32, b []byte) bool {
+	if int(offset)+len(b) > len(i.mem) {
+		return false
+	}
+
+	copy(i.mem[offset:], b)
+
+	return true
+}
+
+func TestModule(t *testing.T) {
+	m := transformwasm.New(newInstance(strings.ToUpper))
+
+	trans := transform.New(transform.WithTransformation("wasm", m.Func()))
+
+	type testStruct struct {
+		Name string `transform:"trim,wasm"`
+	}
+
+	in := &testStruct{Name: " hello "}
+
+	err := trans.Transform(in)
+	require.NoError(t, err)
+	require.Equal(t, "HELLO", in.Name)
+
+	// the module is replaced at runtime
+	m.Swap(newInstance(strings.ToLower))
+
+	err = trans.Transform(in)
+	require.NoError(t, err)
+	require.Equal(t, "hello", in.Name)
+}
+
+type ctxKey struct{}
+
+func TestModuleContext(t *testing.T) {
+	inst := newInstance(strings.ToUpper)
+	m := transformwasm.New(inst)
+
+	trans := transform.New(transform.WithTransformation("wasm", m.Func()))
+
+	type testStruct struct {
+		Name string `transform:"wasm"`
+	}
+
+	ctx := context.WithValue(context.Background(), ctxKey{}, "tenant")
+
+	err := trans.TransformCtx(ctx, &testStruct{Name: "hello"})
+	require.NoError(t, err)
+	require.Equal(t, "tenant", inst.ctx.Value(ctxKey{}))
+}
+
+func TestModuleMemory(t *testing.T) {
+	m := transformwasm.New(newInstance(strings.ToUpper))
+
+	_, err := m.Transform(context.Background(), strings.Repeat("x", 2000))
+	require.ErrorIs(t, err, transformwasm.ErrMemory)
+}
@@ -0,0 +1,149 @@
+package transform
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// unitDimension is a dimension of units with the factors to its base unit
+type unitDimension map[string]float64
+
+// unitDimensions are the dimensions of unit, length in meters and mass in kilograms
+var unitDimensions = []unitDimension{
+	{
+		"mm": 1e-3, "cm": 1e-2, "m": 1, "km": 1e3,
+		"in": 0.0254, "ft": 0.3048, "yd": 0.9144, "mi": 1609.344,
+	},
+	{
+		"mg": 1e-6, "g": 1e-3, "kg": 1, "t": 1e3,
+		"oz": 0.028349523125, "lb": 0.45359237,
+	},
+}
+
+// temperatureUnits are the units of temp with their symbols
+var temperatureUnits = map[string]string{"c": "°C", "f": "°F", "k": "K"}
+
+// significantDigits is the precision of converted values, it hides floating point artifacts
+const significantDigits = 10
+
+// unitFunc converts a value with a unit (unit=cm:in turns "12.7 cm" or "12.7" into "5 in")
+func unitFunc(fl FieldLevel) error {
+	from, to, ok := strings.Cut(fl.Param(), ":")
+
+	dim := dimensionOf(from)
+	if !ok || dim == nil || dim[to] == 0 {
+		return fmt.Errorf("%w: unit=%s", ErrInvalidParam, fl.Param())
+	}
+
+	s := strings.TrimSpace(fl.String())
+	if s == "" {
+		return nil
+	}
+
+	v, unit, err := splitUnit(s)
+	if err != nil {
+		return fmt.Errorf("unit: %w", err)
+	}
+
+	if unit == "" {
+		unit = from
+	}
+
+	factor, ok := dim[strings.ToLower(unit)]
+	if !ok {
+		return fmt.Errorf("unit: invalid unit %q of %q", unit, s)
+	}
+
+	SetString(fl, formatMeasure(v*factor/dim[to])+" "+to)
+
+	return nil
+}
+
+// tempFunc converts a temperature (temp=c:f turns "20 °C" or "20" into "68 °F")
+func tempFunc(fl FieldLevel) error {
+	from, to, ok := strings.Cut(strings.ToLower(fl.Param()), ":")
+
+	_, okFrom := temperatureUnits[from]
+	if _, okTo := temperatureUnits[to]; !ok || !okFrom || !okTo {
+		return fmt.Errorf("%w: temp=%s", ErrInvalidParam, fl.Param())
+	}
+
+	s := strings.TrimSpace(fl.String())
+	if s == "" {
+		return nil
+	}
+
+	v, unit, err := splitUnit(s)
+	if err != nil {
+		return fmt.Errorf("temp: %w", err)
+	}
+
+	if unit != "" {
+		from = strings.ToLower(strings.TrimPrefix(unit, "°"))
+		if _, ok := temperatureUnits[from]; !ok {
+			return fmt.Errorf("temp: invalid unit %q of %q", unit, s)
+		}
+	}
+
+	// convert to kelvin and then to the target unit
+	switch from {
+	case "c":
+		v += 273.15
+	case "f":
+		v = (v-32)*5/9 + 273.15
+	}
+
+	switch to {
+	case "c":
+		v -= 273.15
+	case "f":
+		v = (v-273.15)*9/5 + 32
+	}
+
+	SetString(fl, formatMeasure(v)+" "+temperatureUnits[to])
+
+	return nil
+}
+
+// dimensionOf returns the dimension of the unit
+func dimensionOf(unit string) unitDimension {
+	for _, d := range unitDimensions {
+		if _, ok := d[unit]; ok {
+			return d
+		}
+	}
+
+	return nil
+}
+
+// splitUnit splits a measurement into its value and unit (e.g. "12.5cm" or "12.5 cm")
+func splitUnit(s string) (float64, string, error) {
+	i := strings.IndexFunc(s, func(r rune) bool {
+		return !strings.ContainsRune("0123456789.+-eE", r)
+	})
+
+	// the exponent of a number (1e3) belongs to the number, no unit starts with e
+	num, unit := s, ""
+	if i >= 0 {
+		num, unit = s[:i], strings.TrimSpace(s[i:])
+	}
+
+	v, err := strconv.ParseFloat(strings.TrimSpace(num), 64)
+	if err != nil || math.IsInf(v, 0) {
+		return 0, "", fmt.Errorf("invalid number %q", s)
+	}
+
+	return v, unit, nil
+}
+
+// formatMeasure formats the value without exponent and floating point artifacts
+func formatMeasure(v float64) string {
+	v, _ = strconv.ParseFloat(strconv.FormatFloat(v, 'g', significantDigits, 64), 64)
+	if v == 0 {
+		v = 0 // no negative zero
+	}
+
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
@@ -0,0 +1,79 @@
+package transform_test
+
+import (
+	"testing"
+
+	"github.com/zeiss/go-transform"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnit(t *testing.T) {
+	type testStruct struct {
+		Length  string `transform:"unit=cm:in"`
+		Bare    string `transform:"unit=cm:in"`
+		Meters  string `transform:"unit=cm:mm"`
+		Weight  string `transform:"unit=kg:lb"`
+		Celsius string `transform:"temp=c:f"`
+		Kelvin  string `transform:"temp=c:k"`
+		Fahr    string `transform:"temp=f:c"`
+		Empty   string `transform:"unit=cm:in"`
+	}
+
+	in := &testStruct{
+		Length:  "12.7 cm",
+		Bare:    "2.54",
+		Meters:  "1.5m",
+		Weight:  " 1 kg ",
+		Celsius: "20 °C",
+		Kelvin:  "-273.15",
+		Fahr:    "98.6F",
+	}
+
+	err := transform.Transform(in)
+	require.NoError(t, err)
+	require.Equal(t, &testStruct{
+		Length:  "5 in",
+		Bare:    "1 in",
+		Meters:  "1500 mm",
+		Weight:  "2.204622622 lb",
+		Celsius: "68 °F",
+		Kelvin:  "0 K",
+		Fahr:    "37 °C",
+	}, in)
+}
+
+func TestUnitErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		in   interface{}
+		err  error
+	}{
+		{name: "invalid param", in: &struct {
+			V string `transform:"unit=cm:kg"`
+		}{V: "1"}, err: transform.ErrInvalidParam},
+		{name: "invalid temp param", in: &struct {
+			V string `transform:"temp=c"`
+		}{V: "1"}, err: transform.ErrInvalidParam},
+		{name: "invalid number", in: &struct {
+			V string `transform:"unit=cm:in"`
+		}{V: "abc"}},
+		{name: "other dimension", in: &struct {
+			V string `transform:"unit=cm:in"`
+		}{V: "1 kg"}},
+		{name: "invalid temp unit", in: &struct {
+			V string `transform:"temp=c:f"`
+		}{V: "1 X"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := transform.Transform(tt.in)
+			require.Error(t, err)
+
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,204 @@
+package transform
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// SkipNested is returned by a WalkFunc to skip the nested values of the field
+var SkipNested = errors.New("transformer: skip nested values") // nolint:revive,stylecheck
+
+// WalkFunc is called for every field visited by Walk, it may modify the field
+type WalkFunc func(fl FieldLevel) error
+
+// Walk calls fn for every exported field of the struct s points to.
+func Walk(s interface{}, fn WalkFunc) error {
+	return New().Walk(s, fn)
+}
+
+// Walk calls fn for every exported field of the struct s points to.
+// The fields of nested structs, pointers to structs and struct elements
+// of slices and arrays are visited after their field, every struct pointer
+// is visited once. Fields of skipped types and with the tag "-" are not visited,
+// the fields of an unexported embedded struct are visited without the struct.
+// The entries of maps are visited like fields named by their keys, and the values
+// held by interfaces are walked like the values of fields.
+func (t *TransformerImpl) Walk(s interface{}, fn WalkFunc) error {
+	ifv, err := structValue(s)
+	if err != nil || !ifv.IsValid() {
+		return err
+	}
+
+	w := &walker{t: t, fn: fn, seen: make(map[pointer]struct{})}
+
+	return w.walkStruct(ifv, location{})
+}
+
+// walker is the state of a single walk
+type walker struct {
+	t    *TransformerImpl
+	fn   WalkFunc
+	seen map[pointer]struct{}
+}
+
+// walkStruct visits the fields of the struct at the location
+func (w *walker) walkStruct(v reflect.Value, loc location) error {
+	vt := v.Type()
+
+	for i := 0; i < v.NumField(); i++ {
+		ft := vt.Field(i)
+
+		if ft.Tag.Get(w.t.TagName) == "-" || w.t.skipType(ft.Type) {
+			continue
+		}
+
+		fl := fieldLevel{
+			field:   ft,
+			val:     v.Field(i),
+			json:    ft.Tag.Get("json") != "",
+			tagName: w.t.TagName,
+			loc:     w.t.locate(loc, ft, i),
+			parent:  v,
+		}
+
+		if !ft.IsExported() {
+			// the exported fields of an unexported embedded struct are promoted and visited
+			if w.t.promoted(ft) {
+				if err := w.walkValue(fl.val, fl.loc); err != nil {
+					return err
+				}
+			}
+
+			continue
+		}
+
+		err := w.fn(fl)
+		if errors.Is(err, SkipNested) {
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if err := w.walkValue(fl.val, fl.loc); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// walkValue visits the nested structs of the value
+func (w *walker) walkValue(v reflect.Value, loc location) error {
+	// nolint:exhaustive
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() || v.Elem().Kind() != reflect.Struct {
+			return nil
+		}
+
+		p := pointer{v.Pointer(), v.Type()}
+		if _, ok := w.seen[p]; ok {
+			return nil
+		}
+
+		w.seen[p] = struct{}{}
+
+		return w.walkStruct(v.Elem(), loc)
+	case reflect.Struct:
+		return w.walkStruct(v, loc)
+	case reflect.Slice, reflect.Array:
+		if !nested(v.Type().Elem()) {
+			return nil
+		}
+
+		for i := 0; i < v.Len(); i++ {
+			if err := w.walkValue(v.Index(i), loc.element(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		return w.walkMap(v, loc)
+	case reflect.Interface:
+		if v.IsNil() || !v.CanSet() {
+			return nil
+		}
+
+		// the value of an interface is not addressable, it is walked on a copy
+		e := reflect.New(v.Elem().Type()).Elem()
+		e.Set(v.Elem())
+
+		if err := w.walkValue(e, loc); err != nil {
+			return err
+		}
+
+		v.Set(e)
+	}
+
+	return nil
+}
+
+// walkMap visits the entries of the map like fields named by their keys, in the order of the keys.
+// The values of a map are not addressable, they are visited on copies stored back into the map.
+func (w *walker) walkMap(v reflect.Value, loc location) error {
+	if v.Len() == 0 || !v.CanInterface() {
+		return nil
+	}
+
+	type entry struct {
+		key  reflect.Value
+		name string
+	}
+
+	entries := make([]entry, 0, v.Len())
+	for _, k := range v.MapKeys() {
+		entries = append(entries, entry{k, fmt.Sprint(k.Interface())})
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].name < entries[j].name })
+
+	et := v.Type().Elem()
+
+	for _, e := range entries {
+		fl := fieldLevel{
+			field:   reflect.StructField{Name: e.name, Type: et},
+			val:     reflect.New(et).Elem(),
+			tagName: w.t.TagName,
+			loc:     loc.key(e.name),
+		}
+		fl.val.Set(v.MapIndex(e.key))
+
+		err := w.fn(fl)
+		if err != nil && !errors.Is(err, SkipNested) {
+			return err
+		}
+
+		if err == nil {
+			if err := w.walkValue(fl.val, fl.loc); err != nil {
+				return err
+			}
+		}
+
+		v.SetMapIndex(e.key, fl.val)
+	}
+
+	return nil
+}
+
+// nested returns true if values of the type may hold fields to visit
+func nested(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	// nolint:exhaustive
+	switch t.Kind() {
+	case reflect.Struct, reflect.Map, reflect.Interface, reflect.Slice, reflect.Array:
+		return true
+	}
+
+	return false
+}
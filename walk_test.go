@@ -0,0 +1,116 @@
+package transform_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/zeiss/go-transform"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalk(t *testing.T) {
+	type address struct {
+		City string
+	}
+
+	type testStruct struct {
+		Name      string
+		Home      *address
+		Work      *address
+		Addresses []address
+		Skipped   address `transform:"-"`
+		Secret    address
+		hidden    string
+	}
+
+	home := &address{City: "Jena"}
+	in := &testStruct{
+		Home:      home,
+		Work:      home,
+		Addresses: []address{{City: "Berlin"}},
+		hidden:    "x",
+	}
+
+	paths := []string{}
+
+	err := transform.Walk(in, func(fl transform.FieldLevel) error {
+		paths = append(paths, fl.Path())
+
+		if fl.FieldName() == "Secret" {
+			return transform.SkipNested
+		}
+
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"Name",
+		"Home", "Home.City",
+		"Work",
+		"Addresses", "Addresses[0].City",
+		"Secret",
+	}, paths)
+}
+
+func TestWalkMapInterface(t *testing.T) {
+	type address struct {
+		City string
+	}
+
+	type testStruct struct {
+		Labels  map[string]string
+		Offices map[int]address
+		Any     interface{}
+	}
+
+	in := &testStruct{
+		Labels:  map[string]string{"b": "y", "a": "x"},
+		Offices: map[int]address{1: {City: "jena"}},
+		Any:     address{City: "berlin"},
+	}
+
+	paths := []string{}
+
+	err := transform.Walk(in, func(fl transform.FieldLevel) error {
+		paths = append(paths, fl.Path())
+
+		if fl.FieldName() == "City" || fl.FieldName() == "a" {
+			transform.SetString(fl, strings.ToUpper(fl.String()))
+		}
+
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"Labels", "Labels[a]", "Labels[b]",
+		"Offices", "Offices[1]", "Offices[1].City",
+		"Any", "Any.City",
+	}, paths)
+	require.Equal(t, &testStruct{
+		Labels:  map[string]string{"a": "X", "b": "y"},
+		Offices: map[int]address{1: {City: "JENA"}},
+		Any:     address{City: "BERLIN"},
+	}, in)
+}
+
+func TestWalkError(t *testing.T) {
+	type testStruct struct {
+		A string
+		B string
+	}
+
+	errStop := errors.New("stop")
+	n := 0
+
+	err := transform.Walk(&testStruct{}, func(fl transform.FieldLevel) error {
+		n++
+		return errStop
+	})
+	require.ErrorIs(t, err, errStop)
+	require.Equal(t, 1, n)
+
+	err = transform.Walk(testStruct{}, func(fl transform.FieldLevel) error { return nil })
+	require.ErrorIs(t, err, transform.ErrNoPointer)
+}